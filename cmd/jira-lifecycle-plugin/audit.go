@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	auditActionTransition       = "transition"
+	auditActionResolution       = "resolution"
+	auditActionPriority         = "priority"
+	auditActionTargetVersion    = "target-version"
+	auditActionFixVersion       = "fix-version"
+	auditActionComment          = "comment"
+	auditActionLabels           = "labels"
+	auditActionClone            = "clone"
+	auditActionCreate           = "create"
+	auditActionIssueLinkAdd     = "issue-link-add"
+	auditActionIssueLinkRemove  = "issue-link-remove"
+	auditActionRemoteLinkAdd    = "remote-link-add"
+	auditActionRemoteLinkUpdate = "remote-link-update"
+	auditActionRemoteLinkRemove = "remote-link-remove"
+)
+
+// recordAudit logs a structured, machine-parseable entry for a change the plugin made to a Jira issue
+// on behalf of the pull request and user that triggered the event.
+func recordAudit(log *logrus.Entry, e event, action, issueKey string, oldValue, newValue any) {
+	log.WithFields(logrus.Fields{
+		"audit":     true,
+		"action":    action,
+		"issue":     issueKey,
+		"old_value": oldValue,
+		"new_value": newValue,
+		"pr":        prURLFromCommentURL(e.htmlUrl),
+		"actor":     e.login,
+	}).Info("Jira issue mutated.")
+}
+
+// fieldChange is the old and new value of a field of a Jira issue changed by the plugin
+type fieldChange struct {
+	field    string
+	oldValue string
+	newValue string
+}
+
+// fieldChangesSummary describes the changes made to the fields of the issue for the comment on the pull request,
+// or returns an empty string if no fields were changed
+func fieldChangesSummary(issueKey string, changes []fieldChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	lines := []string{fmt.Sprintf("\n\nFields changed on %s:", issueKey)}
+	for _, change := range changes {
+		oldValue := change.oldValue
+		if oldValue == "" {
+			oldValue = "(none)"
+		}
+		lines = append(lines, fmt.Sprintf(" * %s: %s → %s", change.field, oldValue, change.newValue))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fieldChangesComment returns the summary of the changes made to the fields of the issue if the branch is configured
+// to comment with it, or an empty string otherwise
+func fieldChangesComment(options JiraBranchOptions, issueKey string, changes []fieldChange) string {
+	if options.CommentFieldChanges == nil || !*options.CommentFieldChanges {
+		return ""
+	}
+	return fieldChangesSummary(issueKey, changes)
+}
+
+// issueStatus returns the name of the status of the issue, or an empty string if it is not set
+func issueStatus(issue *jira.Issue) string {
+	if issue.Fields == nil || issue.Fields.Status == nil {
+		return ""
+	}
+	return issue.Fields.Status.Name
+}
+
+// issueResolution returns the name of the resolution of the issue, or an empty string if it is not set
+func issueResolution(issue *jira.Issue) string {
+	if issue.Fields == nil || issue.Fields.Resolution == nil {
+		return ""
+	}
+	return issue.Fields.Resolution.Name
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+)
+
+func TestHandleAudit(t *testing.T) {
+	modified := JiraBugState{Status: "MODIFIED"}
+	var testCases = []struct {
+		name     string
+		dryRun   bool
+		expected []map[string]any
+	}{
+		{
+			name: "status transition records an audit entry",
+			expected: []map[string]any{{
+				"action":    auditActionTransition,
+				"issue":     "OCPBUGS-123",
+				"old_value": "NEW",
+				"new_value": "MODIFIED",
+				"pr":        "https://github.com/org/repo/pull/1",
+				"actor":     "user",
+				"refKey":    "OCPBUGS-123",
+			}},
+		},
+		{
+			name:   "status transition in dry-run mode records an audit entry marked as a dry run",
+			dryRun: true,
+			expected: []map[string]any{{
+				"action":    auditActionTransition,
+				"issue":     "OCPBUGS-123",
+				"old_value": "NEW",
+				"new_value": "MODIFIED",
+				"pr":        "https://github.com/org/repo/pull/1",
+				"actor":     "user",
+				"refKey":    "OCPBUGS-123",
+				"dry-run":   true,
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&out)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+			jc := &fakejira.FakeClient{
+				Issues:      []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+				Transitions: []jira.Transition{{ID: "1", Name: "MODIFIED", To: jira.Status{Name: "MODIFIED"}}},
+			}
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			e := event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This PR fixes OCPBUGS-123", title: "OCPBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+			}
+			options := JiraBranchOptions{StateAfterValidation: &modified}
+			if err := handle(jc, fakeGHClient{gc}, &fakeBigQueryInserter{}, nil, nil, options, logrus.NewEntry(logger), e, sets.New("org/repo"), defaultBugProjects, tc.dryRun); err != nil {
+				t.Fatalf("handle failed: %v", err)
+			}
+
+			var entries []map[string]any
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("failed to parse log line %q: %v", line, err)
+				}
+				if entry["audit"] != true {
+					continue
+				}
+				for _, field := range []string{"audit", "level", "msg", "time"} {
+					delete(entry, field)
+				}
+				entries = append(entries, entry)
+			}
+			if diff := cmp.Diff(tc.expected, entries); diff != "" {
+				t.Errorf("incorrect audit entries: %s", diff)
+			}
+		})
+	}
+}
@@ -9,19 +9,40 @@ import (
 )
 
 const (
-	bigqueryTableName     = "verified"
-	verifyMergeType       = "merge"
-	verifyLaterType       = "later"
-	verifyRemoveType      = "remove"
-	verifyRemoveLaterType = "removeLater"
+	bigqueryTableName              = "verified"
+	bigqueryHandleMetricsTableName = "handle_metrics"
+	bigqueryTimeInStateTableName   = "time_in_state"
+	verifyMergeType                = "merge"
+	verifyLaterType                = "later"
+	verifyRemoveType               = "remove"
+	verifyRemoveLaterType          = "removeLater"
 )
 
 type BigQueryInserter interface {
 	Put(ctx context.Context, src any) (err error)
 }
 
+// tableInserter routes rows to the Big Query table for their type.
+type tableInserter struct {
+	verified      BigQueryInserter
+	handleMetrics BigQueryInserter
+	timeInState   BigQueryInserter
+}
+
+func (t *tableInserter) Put(ctx context.Context, src any) error {
+	if _, ok := src.(HandleMetrics); ok {
+		return t.handleMetrics.Put(ctx, src)
+	}
+	if _, ok := src.(TimeInState); ok {
+		return t.timeInState.Put(ctx, src)
+	}
+	return t.verified.Put(ctx, src)
+}
+
 type fakeBigQueryInserter struct {
-	insertedData []VerificationInfo
+	insertedData        []VerificationInfo
+	insertedMetrics     []HandleMetrics
+	insertedTimeInState []TimeInState
 }
 
 type VerificationInfo struct {
@@ -49,7 +70,87 @@ func (i *VerificationInfo) Save() (map[string]bigquery.Value, string, error) {
 	}, "", nil
 }
 
+// HandleMetrics records the cost of handling a single event.
+type HandleMetrics struct {
+	Org               string
+	Repo              string
+	PRNum             int
+	Branch            string
+	EventType         string
+	JiraGetIssueCalls int
+	JiraUpdateCalls   int
+	GitHubCalls       int
+	Duration          time.Duration
+	Timestamp         time.Time
+}
+
+// Save implements the ValueSaver interface.
+func (m *HandleMetrics) Save() (map[string]bigquery.Value, string, error) {
+	return map[string]bigquery.Value{
+		"Org":               m.Org,
+		"Repo":              m.Repo,
+		"PRNum":             m.PRNum,
+		"Branch":            m.Branch,
+		"EventType":         m.EventType,
+		"JiraGetIssueCalls": m.JiraGetIssueCalls,
+		"JiraUpdateCalls":   m.JiraUpdateCalls,
+		"GitHubCalls":       m.GitHubCalls,
+		"DurationSeconds":   m.Duration.Seconds(),
+		"Timestamp":         m.Timestamp,
+	}, "", nil
+}
+
+// TimeInState records how long a bug spent in a state before the plugin moved it to a new one.
+type TimeInState struct {
+	Org          string
+	Repo         string
+	PRNum        int
+	Branch       string
+	Issue        string
+	State        string
+	NewState     string
+	EnteredState time.Time
+	Duration     time.Duration
+	Timestamp    time.Time
+}
+
+// Save implements the ValueSaver interface.
+func (s *TimeInState) Save() (map[string]bigquery.Value, string, error) {
+	return map[string]bigquery.Value{
+		"Org":             s.Org,
+		"Repo":            s.Repo,
+		"PRNum":           s.PRNum,
+		"Branch":          s.Branch,
+		"Issue":           s.Issue,
+		"State":           s.State,
+		"NewState":        s.NewState,
+		"EnteredState":    s.EnteredState,
+		"DurationSeconds": s.Duration.Seconds(),
+		"Timestamp":       s.Timestamp,
+	}, "", nil
+}
+
 func (f *fakeBigQueryInserter) Put(ctx context.Context, data any) error {
+	if timeInState, ok := data.(TimeInState); ok {
+		if timeInState.Timestamp.IsZero() {
+			return errors.New("Time is unset")
+		}
+		if timeInState.Duration != timeInState.Timestamp.Sub(timeInState.EnteredState) {
+			return errors.New("Duration does not match the time the state was entered")
+		}
+		// the duration depends on the current time, so it is zeroed for unit tests along with the time
+		timeInState.Timestamp, timeInState.Duration = time.Time{}, 0
+		f.insertedTimeInState = append(f.insertedTimeInState, timeInState)
+		return nil
+	}
+	if metrics, ok := data.(HandleMetrics); ok {
+		if metrics.Timestamp.IsZero() {
+			return errors.New("Time is unset")
+		}
+		metrics.Timestamp = time.Time{}
+		f.insertedMetrics = append(f.insertedMetrics, metrics)
+		return nil
+	}
 	info, ok := data.(VerificationInfo)
 	if !ok {
 		return errors.New("Data is not a VerficationInfo struct")
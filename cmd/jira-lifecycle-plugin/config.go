@@ -3,26 +3,49 @@ package main
 import (
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Jira holds the config for the jira plugin.
 type Config struct {
 	// Default settings mapped by branch in any repo in any org.
-	// The `*` wildcard will apply to all branches.
+	// The `*` wildcard will apply to all branches, and keys that are regular
+	// expressions apply to all branches they match. See JiraOptionsForItem for
+	// the order of precedence.
 	Default map[string]JiraBranchOptions `json:"default,omitempty"`
 	// Options for specific orgs. The `*` wildcard will apply to all orgs.
 	Orgs map[string]JiraOrgOptions `json:"orgs,omitempty"`
+	// BugProjects are the Jira projects whose issues are treated as bugs.
+	// Defaults to OCPBUGS and DFBUGS when unset.
+	BugProjects []string `json:"bug_projects,omitempty"`
+	// MaintenanceMode pauses the plugin, for example during a Jira outage: events are
+	// still received, but no changes are made to Jira or GitHub. It takes effect when
+	// the configuration is reloaded, so it can be toggled without a redeploy.
+	MaintenanceMode bool `json:"maintenance_mode,omitempty"`
+	// MaintenanceNotice, when set, is commented once on each pull request that triggers
+	// the plugin while it is in maintenance mode.
+	MaintenanceNotice string `json:"maintenance_notice,omitempty"`
 }
 
+// defaultBugProjects are the projects treated as bugs when none are configured
+var defaultBugProjects = sets.New("OCPBUGS", "DFBUGS")
+
 // JiraOrgOptions holds options for checking Jira bugs for an org.
 type JiraOrgOptions struct {
 	// Default settings mapped by branch in any repo in this org.
-	// The `*` wildcard will apply to all branches.
+	// The `*` wildcard will apply to all branches, and keys that are regular
+	// expressions apply to all branches they match. See JiraOptionsForItem for
+	// the order of precedence.
 	Default map[string]JiraBranchOptions `json:"default,omitempty"`
 	// Options for specific repos. The `*` wildcard will apply to all repos.
 	Repos map[string]JiraRepoOptions `json:"repos,omitempty"`
@@ -31,7 +54,9 @@ type JiraOrgOptions struct {
 // JiraRepoOptions holds options for checking Jira bugs for a repo.
 type JiraRepoOptions struct {
 	// Options for specific branches in this repo.
-	// The `*` wildcard will apply to all branches.
+	// The `*` wildcard will apply to all branches, and keys that are regular
+	// expressions apply to all branches they match. See JiraOptionsForItem for
+	// the order of precedence.
 	Branches map[string]JiraBranchOptions `json:"branches,omitempty"`
 }
 
@@ -43,6 +68,14 @@ type JiraBugState struct {
 	Resolution string `json:"resolution,omitempty"`
 }
 
+// JiraCommentVisibility describes the group or role to which private comments
+// posted on Jira issues are restricted.
+type JiraCommentVisibility struct {
+	// Type is either `group` or `role`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
 // PrettyStatus returns:
 //   - "status (resolution)" if both status and resolution are not empty
 //   - "status" if only resolution is empty
@@ -66,6 +99,14 @@ func (s *JiraBugState) String() string {
 	return PrettyStatus(s.Status, s.Resolution)
 }
 
+// RemoteLinkIcon describes the icon shown next to an external link on a Jira issue.
+type RemoteLinkIcon struct {
+	// URL is the location of a 16x16 image for the icon.
+	URL string `json:"url"`
+	// Title is the tooltip shown for the icon.
+	Title string `json:"title"`
+}
+
 // JiraBranchOptions describes how to check if a Jira bug is valid or not.
 type JiraBranchOptions struct {
 	// ExcludeDefaults excludes defaults from more generic Jira configurations.
@@ -74,13 +115,36 @@ type JiraBranchOptions struct {
 	// ValidateByDefault determines whether a validation check is run for all pull
 	// requests by default
 	ValidateByDefault *bool `json:"validate_by_default,omitempty"`
+	// ValidateByDefaultMinimumSeverity limits validation by default to bugs with at least
+	// this severity. Bugs with a lower severity are handled as plain references unless
+	// validation is requested with `/jira refresh`, and pull requests that do not reference
+	// a bug are not validated. Only used when ValidateByDefault is set.
+	ValidateByDefaultMinimumSeverity *string `json:"validate_by_default_minimum_severity,omitempty"`
+	// ReferencesFromBody determines whether issues referenced in the body of a pull request,
+	// such as "Fixes OCPBUGS-123", are handled when the title does not reference any issue
+	ReferencesFromBody *bool `json:"references_from_body,omitempty"`
 
 	// IsOpen determines whether a bug needs to be open to be valid
 	IsOpen *bool `json:"is_open,omitempty"`
+	// RejectClosedBugs determines whether bugs that are already closed are invalid
+	RejectClosedBugs *bool `json:"reject_closed_bugs,omitempty"`
 	// SkipTargetVersionCheck exclude branch from the TargetVersion check
 	SkipTargetVersionCheck *bool `json:"skip_target_version_check,omitempty"`
 	// TargetVersion determines which release a bug needs to target to be valid
 	TargetVersion *string `json:"target_version,omitempty"`
+	// RequireActiveSprint determines whether a bug needs to be assigned to an active sprint to be valid
+	RequireActiveSprint *bool `json:"require_active_sprint,omitempty"`
+	// RequireSingleTargetVersion determines whether a bug that targets more than one
+	// release is invalid
+	RequireSingleTargetVersion *bool `json:"require_single_target_version,omitempty"`
+	// RequireConsistentTargetVersions determines whether the bugs referenced by a
+	// pull request are invalid when they do not all target the same release
+	RequireConsistentTargetVersions *bool `json:"require_consistent_target_versions,omitempty"`
+	// ShowLinkedPRs determines whether the comment for a valid bug lists all pull
+	// requests that are linked to the bug using the external bug tracker
+	ShowLinkedPRs *bool `json:"show_linked_prs,omitempty"`
+	// FixVersion determines which release a bug needs to have in its fix versions to be valid
+	FixVersion *string `json:"fix_version,omitempty"`
 	// ValidStates determine states in which the bug may be to be valid
 	ValidStates *[]JiraBugState `json:"valid_states,omitempty"`
 
@@ -91,7 +155,94 @@ type JiraBranchOptions struct {
 	// versions for dependent bugs.  If set, all blockers must have a
 	// valid target version.
 	DependentBugTargetVersions *[]string `json:"dependent_bug_target_versions,omitempty"`
+	// AutoQEApproveOnVerifiedDependents determines whether the QE approved label
+	// is added to a pull request once all dependents of the referenced bugs are
+	// VERIFIED, and removed again if any of them is no longer VERIFIED. A label
+	// that was added manually is never removed.
+	AutoQEApproveOnVerifiedDependents *bool `json:"auto_qe_approve_on_verified_dependents,omitempty"`
+	// RequireBlockedBy determines whether a bug needs to be blocked by at least
+	// one other bug to be valid
+	RequireBlockedBy *bool `json:"require_blocked_by,omitempty"`
+	// RequireBlockersResolved determines whether all bugs blocking a bug need
+	// to be resolved for the bug to be valid
+	RequireBlockersResolved *bool `json:"require_blockers_resolved,omitempty"`
+	// RequireLinkedIssueType determines the type of issue (e.g. `Test`) that a bug
+	// needs to be linked to in order to be valid. The type may be prefixed with a
+	// project key and a slash (e.g. `OCPQE/Test`) to also require the linked issue
+	// to belong to that project.
+	RequireLinkedIssueType *string `json:"require_linked_issue_type,omitempty"`
+	// RequireAffectsVersion determines whether a bug needs to have at least
+	// one affects version set to be valid
+	RequireAffectsVersion *bool `json:"require_affects_version,omitempty"`
+	// RequireDescription determines whether a bug needs to have a non-empty
+	// description to be valid
+	RequireDescription *bool `json:"require_description,omitempty"`
+	// NeedsInformationLabel determines whether the `jira/needs-information` label is
+	// applied when a referenced bug fails validation because required fields are not
+	// set at all (e.g. no target version), rather than set to unexpected values, or
+	// when it has no severity. The label is removed once the fields are set.
+	NeedsInformationLabel *bool `json:"needs_information_label,omitempty"`
+	// AllowedIssueTypes determines the set of issue types (e.g. Bug or Story)
+	// that referenced issues may have. If set, bugs of other types are invalid
+	// and references to non-bug issues of other types get a warning.
+	AllowedIssueTypes *[]string `json:"allowed_issue_types,omitempty"`
+	// NonBugIssueTypes determines the set of issue types (e.g. Story) that
+	// referenced non-bug issues may have. If set, references to non-bug issues
+	// of other types are invalid instead of always being valid.
+	NonBugIssueTypes *[]string `json:"non_bug_issue_types,omitempty"`
+	// RequireStoryPoints determines whether issues referenced by a pull request
+	// that are not bugs need to have story points set to be valid
+	RequireStoryPoints *bool `json:"require_story_points,omitempty"`
+	// AllowZeroStoryPoints determines whether story points of zero satisfy
+	// RequireStoryPoints. By default, only story points above zero do, as zero
+	// is often left in place of an estimate.
+	AllowZeroStoryPoints *bool `json:"allow_zero_story_points,omitempty"`
+	// RequireEpicLink determines whether referenced issues must be linked to an epic.
+	// Bugs without an epic link are invalid and references to non-bug issues without
+	// one get a warning. Only issues of the EpicLinkIssueTypes are checked.
+	RequireEpicLink *bool `json:"require_epic_link,omitempty"`
+	// EpicLinkIssueTypes determines the set of issue types that must be linked to an
+	// epic when RequireEpicLink is set. Defaults to Story.
+	EpicLinkIssueTypes *[]string `json:"epic_link_issue_types,omitempty"`
+	// StatusRequiredLabels maps a Jira status (e.g. `POST`) to the GitHub labels that a pull
+	// request must carry while a bug it references is in that status. Bugs in a status whose
+	// labels are missing from the pull request are invalid.
+	StatusRequiredLabels map[string][]string `json:"status_required_labels,omitempty"`
+	// MaxReferencedBugs determines the maximum number of bugs that the title
+	// of a pull request may reference. If more are referenced, none of them
+	// are validated.
+	MaxReferencedBugs *int `json:"max_referenced_bugs,omitempty"`
+	// RequireSameProject determines whether all Jira issues referenced in the
+	// title of a pull request must belong to the same Jira project. If they do
+	// not, none of them are validated.
+	RequireSameProject *bool `json:"require_same_project,omitempty"`
+	// SameProjectIgnoreNonBugs exempts references to non-bug issues from the
+	// RequireSameProject check, so only the projects of bugs are compared.
+	SameProjectIgnoreNonBugs *bool `json:"same_project_ignore_non_bugs,omitempty"`
+	// StrictProjectPrefixes determines whether references to issues whose project is
+	// neither a known bug project nor a project reachable in Jira are answered with a
+	// hint about the expected prefix format, instead of being treated as references
+	// to non-bug issues.
+	StrictProjectPrefixes *bool `json:"strict_project_prefixes,omitempty"`
+	// SkipDrafts determines whether bugs referenced by draft pull requests are
+	// left in their current state instead of being moved to the state after
+	// validation. Labels and comments are still applied. The bugs are moved
+	// once the pull request is marked ready for review.
+	SkipDrafts *bool `json:"skip_drafts,omitempty"`
+	// IgnoreAuthors is a list of GitHub logins, such as dependency-bump bots, whose pull requests
+	// are ignored entirely: no validation is done, and no labels or comments are applied.
+	IgnoreAuthors []string `json:"ignore_authors,omitempty"`
+	// PullRequestActions restricts the pull request actions (e.g. `opened`, `edited`
+	// or `closed`) that the plugin reacts to, for example to ignore `synchronize` to
+	// reduce churn. If unset, all supported actions are handled. Comments are not affected.
+	PullRequestActions *[]string `json:"pull_request_actions,omitempty"`
+	// PublishStatus determines whether the result of the validation is also published
+	// as a commit status on the pull request, so branch protection can require it
+	PublishStatus *bool `json:"publish_status,omitempty"`
 
+	// DisableTransitions determines whether bugs are never moved to a new state by the
+	// plugin, while still being validated and labelled, for example on experimental branches
+	DisableTransitions *bool `json:"disable_transitions,omitempty"`
 	// StateAfterValidation is the state to which the bug will be moved after being
 	// deemed valid and linked to a PR. Will implicitly be considered a part of `ValidStates`
 	// if others are set.
@@ -101,9 +252,37 @@ type JiraBranchOptions struct {
 	// AffectVersion and FixVersion are set to `premerge`. Will implicitly be considered a
 	// part of `ValidStates` if others are set.
 	PreMergeStateAfterValidation *JiraBugState `json:"premerge_state_after_validation,omitempty"`
+	// CommentFieldChanges determines whether the comment on the pull request summarizes the
+	// fields of the bug that were changed by the plugin, like its status, resolution and target version
+	CommentFieldChanges *bool `json:"comment_field_changes,omitempty"`
 	// AddExternalLink determines whether the pull request will be added to the Jira
 	// bug using the ExternalBug tracker API after being validated
 	AddExternalLink *bool `json:"add_external_link,omitempty"`
+	// CreateIssueProject is the Jira project in which the `/jira create` command
+	// creates bugs for pull requests that do not reference an issue
+	CreateIssueProject *string `json:"create_issue_project,omitempty"`
+	// CreateIssueAssignee is the Jira user that bugs created by the `/jira create`
+	// command are assigned to
+	CreateIssueAssignee *string `json:"create_issue_assignee,omitempty"`
+	// CloneDefaultAssignee is the Jira user that bugs cloned for cherrypicks and
+	// backports are assigned to when the original bug has no assignee. When unset,
+	// the assignee is left to Jira's automation.
+	CloneDefaultAssignee *string `json:"clone_default_assignee,omitempty"`
+	// BackportEligible marks the branch as a valid target of the `/jira backport` command. Once any
+	// branch of a repo sets it, backports are only allowed to the branches where it is true.
+	BackportEligible *bool `json:"backport_eligible,omitempty"`
+	// SlackChannel is the Slack channel notified when a referenced bug becomes
+	// invalid or an error is encountered while handling a pull request
+	SlackChannel *string `json:"slack_channel,omitempty"`
+	// PrivateComments determines whether comments added to Jira issues via the
+	// `/jira comment` command are restricted to the private visibility group
+	PrivateComments *bool `json:"private_comments,omitempty"`
+	// AddJiraCommentOnValidation determines whether a private comment that links the
+	// pull request is added to a bug when it is validated, unless the bug already has one
+	AddJiraCommentOnValidation *bool `json:"add_jira_comment_on_validation,omitempty"`
+	// PrivateCommentVisibility is the group or role to which private comments
+	// added to Jira issues are restricted. Defaults to the `Red Hat Employee` group.
+	PrivateCommentVisibility *JiraCommentVisibility `json:"private_comment_visibility,omitempty"`
 	// StateAfterMerge is the state to which the bug will be moved after all pull requests
 	// in the external bug tracker have been merged.
 	StateAfterMerge *JiraBugState `json:"state_after_merge,omitempty"`
@@ -111,6 +290,9 @@ type JiraBranchOptions struct {
 	// in the external bug tracker have been merged if the PR has the `qe-approved` label and both
 	// the FixVersion and AffectsVersion fields of the bug are set to `premerge`.
 	PreMergeStateAfterMerge *JiraBugState `json:"premerge_state_after_merge,omitempty"`
+	// RecordTimeInState determines whether the time a bug spent in its previous state is read
+	// from its changelog and uploaded to Big Query when it is moved after its pull requests merged.
+	RecordTimeInState *bool `json:"record_time_in_state,omitempty"`
 	// StateAfterClose is the state to which the bug will be moved if all pull requests
 	// in the external bug tracker have been closed.
 	StateAfterClose *JiraBugState `json:"state_after_close,omitempty"`
@@ -118,11 +300,18 @@ type JiraBranchOptions struct {
 	// in the external bug tracker have been close if the PR has the `qe-approved` label and both
 	// the FixVersion and AffectsVersion fields of the bug are set to `premerge`.
 	PreMergeStateAfterClose *JiraBugState `json:"premerge_state_after_close,omitempty"`
+	// CloseGracePeriod is the period after the last update of a bug during which it will not be
+	// moved to the StateAfterClose or PreMergeStateAfterClose state when its pull request is closed.
+	// The intended state change is recorded in a private comment on the bug instead.
+	CloseGracePeriod *metav1.Duration `json:"close_grace_period,omitempty"`
 
 	// AllowedSecurityLevels is a list of the name of jira issue security levels that the jira plugin can
 	// link to in PRs. If an issue has a security level that is not in this list, the jira
 	// plugin will not link the issue to the PR.
 	AllowedSecurityLevels []string `json:"allowed_security_levels,omitempty"`
+	// RequiredSecurityLevel is the name of the jira issue security level that a bug must have
+	// to be considered valid, for example on branches for embargoed fixes.
+	RequiredSecurityLevel *string `json:"required_security_level,omitempty"`
 
 	// RequireReleaseNotes indicates whether a jira bug requires the release notes to be filled in and not
 	// equal to ReleaseNotesDefaultText for the bug to be considered valid.
@@ -132,6 +321,103 @@ type JiraBranchOptions struct {
 
 	// IgnoreCloneLabels is a list of labels that should be excluded when cloning a bug for cherrypicks
 	IgnoreCloneLabels []string `json:"ignore_clone_labels,omitempty"`
+	// IgnoreCloneLabelPrefixes is a list of label prefixes; labels starting with any of them are excluded
+	// when cloning a bug for cherrypicks
+	IgnoreCloneLabelPrefixes []string `json:"ignore_clone_label_prefixes,omitempty"`
+	// CloneCopyFields is a list of custom field keys (e.g. `customfield_12310243`) that are copied from
+	// the bug onto its clone when cloning for cherrypicks and backports. Fields not set on the bug are skipped.
+	CloneCopyFields []string `json:"clone_copy_fields,omitempty"`
+	// AddCloneLabel determines whether a `jira/clone-<key>` label is added to pull requests that are
+	// retitled to reference a bug cloned for a cherrypick, mirroring the `jlp-<branch>:<key>` labels
+	// added to the original bug in Jira.
+	AddCloneLabel *bool `json:"add_clone_label,omitempty"`
+	// RetitleCommand replaces the `/retitle` command the plugin emits to retitle pull requests to reference
+	// cloned or created bugs. Setting it to an empty string disables the command, and the plugin instead
+	// asks for the pull request to be retitled manually.
+	RetitleCommand *string `json:"retitle_command,omitempty"`
+
+	// SeverityLabels maps a Jira severity (`Critical`, `Important`, `Moderate`, `Low`,
+	// or `Informational`) to the GitHub label that is applied for it, replacing the
+	// default `jira/severity-*` label for that severity
+	SeverityLabels map[string]string `json:"severity_labels,omitempty"`
+	// DisableSeverityLabels turns off the severity labels entirely, so the plugin neither adds
+	// nor removes them, regardless of the severity of the referenced bugs
+	DisableSeverityLabels *bool `json:"disable_severity_labels,omitempty"`
+	// SeverityOrder lists the severities from most to least severe, replacing the default order of
+	// `Critical`, `Important`, `Moderate`, `Low` and `Informational` for instances with custom
+	// severity schemes. It determines which severity label is applied when a pull request references
+	// multiple bugs and which bugs rank below the `validate_by_default_minimum_severity`.
+	SeverityOrder []string `json:"severity_order,omitempty"`
+
+	// CommentTemplates maps a type of message (`valid`, `invalid`, or `merged`) to a Go text/template
+	// that replaces the default wording of that message. Templates receive the issue key (`.Key`),
+	// the issue URL (`.URL`), the list of validations (`.Validations`), and, for the `merged` message,
+	// the list of merged pull requests (`.PullRequests`).
+	CommentTemplates map[string]string `json:"comment_templates,omitempty"`
+	// RemoteLinkTitleTemplate is a Go text/template that replaces the default title (`org/repo#1: PR title`)
+	// of the external links added to bugs for pull requests. Templates receive the org (`.Org`), repo (`.Repo`),
+	// pull request number (`.Number`), and pull request title (`.Title`).
+	RemoteLinkTitleTemplate *string `json:"remote_link_title_template,omitempty"`
+	// RemoteLinkIcon replaces the GitHub icon shown next to the external links added to bugs for
+	// pull requests, so that links to other code hosts render correctly.
+	RemoteLinkIcon *RemoteLinkIcon `json:"remote_link_icon,omitempty"`
+
+	// RefreshHint replaces the instruction that tells users to request a refresh with `/jira refresh`
+	// in the comments posted by the plugin. This allows repos that do not use comment-based refreshes
+	// to point users to a different workflow.
+	RefreshHint *string `json:"refresh_hint,omitempty"`
+	// RefreshCooldown is the minimum time between two `/jira refresh` commands on the same pull request.
+	// Refreshes arriving within it are skipped instead of re-validating the referenced bugs.
+	RefreshCooldown *metav1.Duration `json:"refresh_cooldown,omitempty"`
+	// RefreshCooldownReaction is the reaction (e.g. `eyes`) added to `/jira refresh` comments that are
+	// skipped due to the RefreshCooldown. When unset, skipped refreshes are ignored silently.
+	RefreshCooldownReaction *string `json:"refresh_cooldown_reaction,omitempty"`
+	// CommandReaction is the reaction (e.g. `eyes` or `+1`) added to `/jira refresh` and `/verified`
+	// comments as soon as they are received, so users know the command is being processed. When unset,
+	// commands are not reacted to.
+	CommandReaction *string `json:"command_reaction,omitempty"`
+	// CommentFooter is a line appended to the details block of every comment posted by the plugin,
+	// such as a repo-specific contact (e.g. `Reach out in #forum-my-team on Slack`).
+	CommentFooter *string `json:"comment_footer,omitempty"`
+	// JiraDisplayURL replaces the base URL of the Jira server in the links posted in comments, for
+	// Jira instances that users reach through a different address (e.g. a proxy) than the plugin.
+	JiraDisplayURL *string `json:"jira_display_url,omitempty"`
+	// CommentOnlyOnRefresh determines whether the validity comment is only posted in response to
+	// `/jira refresh` (or `/jira cc-qa`). Other events still update the labels and statuses of the
+	// pull request silently. Errors are always reported with a comment.
+	CommentOnlyOnRefresh *bool `json:"comment_only_on_refresh,omitempty"`
+
+	// VerifiedCommandUsers is a list of GitHub users allowed to run the `/verified` commands. When set,
+	// it replaces the check that the user is a collaborator on the repo, so non-collaborators in the
+	// list may verify PRs and collaborators not in the list may not.
+	VerifiedCommandUsers []string `json:"verified_command_users,omitempty"`
+	// QEReviewers is a list of GitHub users whose approving reviews mark a pull request as verified,
+	// the same way the `/verified` command does, for teams that give QE approval on GitHub.
+	QEReviewers []string `json:"qe_reviewers,omitempty"`
+	// VerifiedLabel is the GitHub label that marks a pull request as verified. Defaults to `verified`.
+	VerifiedLabel *string `json:"verified_label,omitempty"`
+	// VerifiedLaterLabel is the GitHub label that marks a pull request as to be verified after it
+	// merges. Defaults to `verified-later`.
+	VerifiedLaterLabel *string `json:"verified_later_label,omitempty"`
+	// RequireApprovalForVerified determines whether linked pull requests must also have
+	// the `lgtm` and `approved` labels for bugs to be moved to VERIFIED on merge
+	RequireApprovalForVerified *bool `json:"require_approval_for_verified,omitempty"`
+	// VerifiedResetIgnorePaths is a list of path globs (e.g. `docs/*`) for files whose changes
+	// do not reset the verified labels when new commits are pushed to a pull request
+	VerifiedResetIgnorePaths []string `json:"verified_reset_ignore_paths,omitempty"`
+
+	// AutoCCQA determines whether the QA contact of a bug is requested for review on the pull request
+	// whenever the bug is validated, as with `/jira cc-qa`. Problems resolving the QA contact to a
+	// GitHub user are reported as warnings in the validation comment instead of failing the validation.
+	AutoCCQA *bool `json:"auto_cc_qa,omitempty"`
+	// WarnSelfReportedFix determines whether a warning is added to the validation comment when the
+	// reporter of a bug maps to the author of the pull request by their public email, so that
+	// self-reported fixes get extra scrutiny. The warning does not affect the validity of the bug.
+	WarnSelfReportedFix *bool `json:"warn_self_reported_fix,omitempty"`
+	// WarnOnDefaultBranchConfig determines whether a warning is added to the validation comment when
+	// no configuration matches the base branch of the pull request other than the `*` wildcard, to help
+	// notice branches that were meant to be configured but are silently using the defaults.
+	WarnOnDefaultBranchConfig *bool `json:"warn_on_default_branch_config,omitempty"`
 }
 
 type JiraBugStateSet map[JiraBugState]any
@@ -177,42 +463,207 @@ func jiraStatesMatch(first, second []JiraBugState) bool {
 func (o JiraBranchOptions) matches(other JiraBranchOptions) bool {
 	validateByDefaultMatch := o.ValidateByDefault == nil && other.ValidateByDefault == nil ||
 		(o.ValidateByDefault != nil && other.ValidateByDefault != nil && *o.ValidateByDefault == *other.ValidateByDefault)
+	validateByDefaultMinimumSeverityMatch := o.ValidateByDefaultMinimumSeverity == nil && other.ValidateByDefaultMinimumSeverity == nil ||
+		(o.ValidateByDefaultMinimumSeverity != nil && other.ValidateByDefaultMinimumSeverity != nil && *o.ValidateByDefaultMinimumSeverity == *other.ValidateByDefaultMinimumSeverity)
+	referencesFromBodyMatch := o.ReferencesFromBody == nil && other.ReferencesFromBody == nil ||
+		(o.ReferencesFromBody != nil && other.ReferencesFromBody != nil && *o.ReferencesFromBody == *other.ReferencesFromBody)
 	isOpenMatch := o.IsOpen == nil && other.IsOpen == nil ||
 		(o.IsOpen != nil && other.IsOpen != nil && *o.IsOpen == *other.IsOpen)
+	rejectClosedBugsMatch := o.RejectClosedBugs == nil && other.RejectClosedBugs == nil ||
+		(o.RejectClosedBugs != nil && other.RejectClosedBugs != nil && *o.RejectClosedBugs == *other.RejectClosedBugs)
 	targetReleaseMatch := o.TargetVersion == nil && other.TargetVersion == nil ||
 		(o.TargetVersion != nil && other.TargetVersion != nil && *o.TargetVersion == *other.TargetVersion)
+	fixVersionMatch := o.FixVersion == nil && other.FixVersion == nil ||
+		(o.FixVersion != nil && other.FixVersion != nil && *o.FixVersion == *other.FixVersion)
+	requireActiveSprintMatch := o.RequireActiveSprint == nil && other.RequireActiveSprint == nil ||
+		(o.RequireActiveSprint != nil && other.RequireActiveSprint != nil && *o.RequireActiveSprint == *other.RequireActiveSprint)
+	requireBlockedByMatch := o.RequireBlockedBy == nil && other.RequireBlockedBy == nil ||
+		(o.RequireBlockedBy != nil && other.RequireBlockedBy != nil && *o.RequireBlockedBy == *other.RequireBlockedBy)
+	requireBlockersResolvedMatch := o.RequireBlockersResolved == nil && other.RequireBlockersResolved == nil ||
+		(o.RequireBlockersResolved != nil && other.RequireBlockersResolved != nil && *o.RequireBlockersResolved == *other.RequireBlockersResolved)
+	requireLinkedIssueTypeMatch := o.RequireLinkedIssueType == nil && other.RequireLinkedIssueType == nil ||
+		(o.RequireLinkedIssueType != nil && other.RequireLinkedIssueType != nil && *o.RequireLinkedIssueType == *other.RequireLinkedIssueType)
+	requiredSecurityLevelMatch := o.RequiredSecurityLevel == nil && other.RequiredSecurityLevel == nil ||
+		(o.RequiredSecurityLevel != nil && other.RequiredSecurityLevel != nil && *o.RequiredSecurityLevel == *other.RequiredSecurityLevel)
+	autoQEApproveOnVerifiedDependentsMatch := o.AutoQEApproveOnVerifiedDependents == nil && other.AutoQEApproveOnVerifiedDependents == nil ||
+		(o.AutoQEApproveOnVerifiedDependents != nil && other.AutoQEApproveOnVerifiedDependents != nil && *o.AutoQEApproveOnVerifiedDependents == *other.AutoQEApproveOnVerifiedDependents)
+	requireAffectsVersionMatch := o.RequireAffectsVersion == nil && other.RequireAffectsVersion == nil ||
+		(o.RequireAffectsVersion != nil && other.RequireAffectsVersion != nil && *o.RequireAffectsVersion == *other.RequireAffectsVersion)
+	requireDescriptionMatch := o.RequireDescription == nil && other.RequireDescription == nil ||
+		(o.RequireDescription != nil && other.RequireDescription != nil && *o.RequireDescription == *other.RequireDescription)
+	needsInformationLabelMatch := o.NeedsInformationLabel == nil && other.NeedsInformationLabel == nil ||
+		(o.NeedsInformationLabel != nil && other.NeedsInformationLabel != nil && *o.NeedsInformationLabel == *other.NeedsInformationLabel)
+	allowedIssueTypesMatch := o.AllowedIssueTypes == nil && other.AllowedIssueTypes == nil ||
+		(o.AllowedIssueTypes != nil && other.AllowedIssueTypes != nil && sets.New(*o.AllowedIssueTypes...).Equal(sets.New(*other.AllowedIssueTypes...)))
+	nonBugIssueTypesMatch := o.NonBugIssueTypes == nil && other.NonBugIssueTypes == nil ||
+		(o.NonBugIssueTypes != nil && other.NonBugIssueTypes != nil && sets.New(*o.NonBugIssueTypes...).Equal(sets.New(*other.NonBugIssueTypes...)))
+	requireStoryPointsMatch := o.RequireStoryPoints == nil && other.RequireStoryPoints == nil ||
+		(o.RequireStoryPoints != nil && other.RequireStoryPoints != nil && *o.RequireStoryPoints == *other.RequireStoryPoints)
+	allowZeroStoryPointsMatch := o.AllowZeroStoryPoints == nil && other.AllowZeroStoryPoints == nil ||
+		(o.AllowZeroStoryPoints != nil && other.AllowZeroStoryPoints != nil && *o.AllowZeroStoryPoints == *other.AllowZeroStoryPoints)
+	requireEpicLinkMatch := o.RequireEpicLink == nil && other.RequireEpicLink == nil ||
+		(o.RequireEpicLink != nil && other.RequireEpicLink != nil && *o.RequireEpicLink == *other.RequireEpicLink)
+	epicLinkIssueTypesMatch := o.EpicLinkIssueTypes == nil && other.EpicLinkIssueTypes == nil ||
+		(o.EpicLinkIssueTypes != nil && other.EpicLinkIssueTypes != nil && sets.New(*o.EpicLinkIssueTypes...).Equal(sets.New(*other.EpicLinkIssueTypes...)))
+	statusRequiredLabelsMatch := maps.EqualFunc(o.StatusRequiredLabels, other.StatusRequiredLabels, func(a, b []string) bool { return sets.New(a...).Equal(sets.New(b...)) })
+	maxReferencedBugsMatch := o.MaxReferencedBugs == nil && other.MaxReferencedBugs == nil ||
+		(o.MaxReferencedBugs != nil && other.MaxReferencedBugs != nil && *o.MaxReferencedBugs == *other.MaxReferencedBugs)
+	requireSameProjectMatch := o.RequireSameProject == nil && other.RequireSameProject == nil ||
+		(o.RequireSameProject != nil && other.RequireSameProject != nil && *o.RequireSameProject == *other.RequireSameProject)
+	sameProjectIgnoreNonBugsMatch := o.SameProjectIgnoreNonBugs == nil && other.SameProjectIgnoreNonBugs == nil ||
+		(o.SameProjectIgnoreNonBugs != nil && other.SameProjectIgnoreNonBugs != nil && *o.SameProjectIgnoreNonBugs == *other.SameProjectIgnoreNonBugs)
+	strictProjectPrefixesMatch := o.StrictProjectPrefixes == nil && other.StrictProjectPrefixes == nil ||
+		(o.StrictProjectPrefixes != nil && other.StrictProjectPrefixes != nil && *o.StrictProjectPrefixes == *other.StrictProjectPrefixes)
+	skipDraftsMatch := o.SkipDrafts == nil && other.SkipDrafts == nil ||
+		(o.SkipDrafts != nil && other.SkipDrafts != nil && *o.SkipDrafts == *other.SkipDrafts)
+	ignoreAuthorsMatch := len(o.IgnoreAuthors) == 0 && len(other.IgnoreAuthors) == 0 ||
+		(sets.New[string](o.IgnoreAuthors...).Equal(sets.New[string](other.IgnoreAuthors...)))
+	pullRequestActionsMatch := o.PullRequestActions == nil && other.PullRequestActions == nil ||
+		(o.PullRequestActions != nil && other.PullRequestActions != nil && sets.New(*o.PullRequestActions...).Equal(sets.New(*other.PullRequestActions...)))
+	publishStatusMatch := o.PublishStatus == nil && other.PublishStatus == nil ||
+		(o.PublishStatus != nil && other.PublishStatus != nil && *o.PublishStatus == *other.PublishStatus)
+	requireSingleTargetVersionMatch := o.RequireSingleTargetVersion == nil && other.RequireSingleTargetVersion == nil ||
+		(o.RequireSingleTargetVersion != nil && other.RequireSingleTargetVersion != nil && *o.RequireSingleTargetVersion == *other.RequireSingleTargetVersion)
+	requireConsistentTargetVersionsMatch := o.RequireConsistentTargetVersions == nil && other.RequireConsistentTargetVersions == nil ||
+		(o.RequireConsistentTargetVersions != nil && other.RequireConsistentTargetVersions != nil && *o.RequireConsistentTargetVersions == *other.RequireConsistentTargetVersions)
+	showLinkedPRsMatch := o.ShowLinkedPRs == nil && other.ShowLinkedPRs == nil ||
+		(o.ShowLinkedPRs != nil && other.ShowLinkedPRs != nil && *o.ShowLinkedPRs == *other.ShowLinkedPRs)
 	skipTargetVersionCheckMatch := o.SkipTargetVersionCheck == nil && other.SkipTargetVersionCheck == nil ||
 		(o.SkipTargetVersionCheck != nil && other.SkipTargetVersionCheck != nil && *o.SkipTargetVersionCheck == *other.SkipTargetVersionCheck)
 	bugStatesMatch := o.ValidStates == nil && other.ValidStates == nil ||
 		(o.ValidStates != nil && other.ValidStates != nil && jiraStatesMatch(*o.ValidStates, *other.ValidStates))
 	dependentBugStatesMatch := o.DependentBugStates == nil && other.DependentBugStates == nil ||
 		(o.DependentBugStates != nil && other.DependentBugStates != nil && jiraStatesMatch(*o.DependentBugStates, *other.DependentBugStates))
+	disableTransitionsMatch := o.DisableTransitions == nil && other.DisableTransitions == nil ||
+		(o.DisableTransitions != nil && other.DisableTransitions != nil && *o.DisableTransitions == *other.DisableTransitions)
+	commentFieldChangesMatch := o.CommentFieldChanges == nil && other.CommentFieldChanges == nil ||
+		(o.CommentFieldChanges != nil && other.CommentFieldChanges != nil && *o.CommentFieldChanges == *other.CommentFieldChanges)
 	statesAfterValidationMatch := o.StateAfterValidation == nil && other.StateAfterValidation == nil ||
 		(o.StateAfterValidation != nil && other.StateAfterValidation != nil && *o.StateAfterValidation == *other.StateAfterValidation)
 	addExternalLinkMatch := o.AddExternalLink == nil && other.AddExternalLink == nil ||
 		(o.AddExternalLink != nil && other.AddExternalLink != nil && *o.AddExternalLink == *other.AddExternalLink)
+	createIssueProjectMatch := o.CreateIssueProject == nil && other.CreateIssueProject == nil ||
+		(o.CreateIssueProject != nil && other.CreateIssueProject != nil && *o.CreateIssueProject == *other.CreateIssueProject)
+	createIssueAssigneeMatch := o.CreateIssueAssignee == nil && other.CreateIssueAssignee == nil ||
+		(o.CreateIssueAssignee != nil && other.CreateIssueAssignee != nil && *o.CreateIssueAssignee == *other.CreateIssueAssignee)
+	cloneDefaultAssigneeMatch := o.CloneDefaultAssignee == nil && other.CloneDefaultAssignee == nil ||
+		(o.CloneDefaultAssignee != nil && other.CloneDefaultAssignee != nil && *o.CloneDefaultAssignee == *other.CloneDefaultAssignee)
+	backportEligibleMatch := o.BackportEligible == nil && other.BackportEligible == nil ||
+		(o.BackportEligible != nil && other.BackportEligible != nil && *o.BackportEligible == *other.BackportEligible)
+	slackChannelMatch := o.SlackChannel == nil && other.SlackChannel == nil ||
+		(o.SlackChannel != nil && other.SlackChannel != nil && *o.SlackChannel == *other.SlackChannel)
+	privateCommentsMatch := o.PrivateComments == nil && other.PrivateComments == nil ||
+		(o.PrivateComments != nil && other.PrivateComments != nil && *o.PrivateComments == *other.PrivateComments)
+	addJiraCommentOnValidationMatch := o.AddJiraCommentOnValidation == nil && other.AddJiraCommentOnValidation == nil ||
+		(o.AddJiraCommentOnValidation != nil && other.AddJiraCommentOnValidation != nil && *o.AddJiraCommentOnValidation == *other.AddJiraCommentOnValidation)
+	privateCommentVisibilityMatch := o.PrivateCommentVisibility == nil && other.PrivateCommentVisibility == nil ||
+		(o.PrivateCommentVisibility != nil && other.PrivateCommentVisibility != nil && *o.PrivateCommentVisibility == *other.PrivateCommentVisibility)
 	statesAfterMergeMatch := o.StateAfterMerge == nil && other.StateAfterMerge == nil ||
 		(o.StateAfterMerge != nil && other.StateAfterMerge != nil && *o.StateAfterMerge == *other.StateAfterMerge)
 	preMergestatesAfterMergeMatch := o.PreMergeStateAfterMerge == nil && other.PreMergeStateAfterMerge == nil ||
 		(o.PreMergeStateAfterMerge != nil && other.PreMergeStateAfterMerge != nil && *o.PreMergeStateAfterMerge == *other.PreMergeStateAfterMerge)
+	recordTimeInStateMatch := o.RecordTimeInState == nil && other.RecordTimeInState == nil ||
+		(o.RecordTimeInState != nil && other.RecordTimeInState != nil && *o.RecordTimeInState == *other.RecordTimeInState)
+	closeGracePeriodMatch := o.CloseGracePeriod == nil && other.CloseGracePeriod == nil ||
+		(o.CloseGracePeriod != nil && other.CloseGracePeriod != nil && *o.CloseGracePeriod == *other.CloseGracePeriod)
 	releaseNotesMatch := o.RequireReleaseNotes == nil && other.RequireReleaseNotes == nil ||
 		(o.RequireReleaseNotes != nil && other.RequireReleaseNotes != nil && *o.RequireReleaseNotes == *other.RequireReleaseNotes)
 	releaseNotesTextMatch := o.ReleaseNotesDefaultText == nil && other.ReleaseNotesDefaultText == nil ||
 		(o.ReleaseNotesDefaultText != nil && other.ReleaseNotesDefaultText != nil && *o.ReleaseNotesDefaultText == *other.ReleaseNotesDefaultText)
 	ignoreCloneLabelsMatch := len(o.IgnoreCloneLabels) == 0 && len(other.IgnoreCloneLabels) == 0 ||
 		(sets.New[string](o.IgnoreCloneLabels...).Equal(sets.New[string](other.IgnoreCloneLabels...)))
-	return validateByDefaultMatch && isOpenMatch && targetReleaseMatch && skipTargetVersionCheckMatch && bugStatesMatch && dependentBugStatesMatch &&
-		statesAfterValidationMatch && addExternalLinkMatch && statesAfterMergeMatch && preMergestatesAfterMergeMatch &&
-		releaseNotesMatch && releaseNotesTextMatch && ignoreCloneLabelsMatch
+	ignoreCloneLabelPrefixesMatch := len(o.IgnoreCloneLabelPrefixes) == 0 && len(other.IgnoreCloneLabelPrefixes) == 0 ||
+		(sets.New[string](o.IgnoreCloneLabelPrefixes...).Equal(sets.New[string](other.IgnoreCloneLabelPrefixes...)))
+	cloneCopyFieldsMatch := len(o.CloneCopyFields) == 0 && len(other.CloneCopyFields) == 0 ||
+		(sets.New[string](o.CloneCopyFields...).Equal(sets.New[string](other.CloneCopyFields...)))
+	addCloneLabelMatch := o.AddCloneLabel == nil && other.AddCloneLabel == nil ||
+		(o.AddCloneLabel != nil && other.AddCloneLabel != nil && *o.AddCloneLabel == *other.AddCloneLabel)
+	retitleCommandMatch := o.RetitleCommand == nil && other.RetitleCommand == nil ||
+		(o.RetitleCommand != nil && other.RetitleCommand != nil && *o.RetitleCommand == *other.RetitleCommand)
+	severityLabelsMatch := maps.Equal(o.SeverityLabels, other.SeverityLabels)
+	severityOrderMatch := slices.Equal(o.SeverityOrder, other.SeverityOrder)
+	disableSeverityLabelsMatch := o.DisableSeverityLabels == nil && other.DisableSeverityLabels == nil ||
+		(o.DisableSeverityLabels != nil && other.DisableSeverityLabels != nil && *o.DisableSeverityLabels == *other.DisableSeverityLabels)
+	commentTemplatesMatch := maps.Equal(o.CommentTemplates, other.CommentTemplates)
+	remoteLinkTitleTemplateMatch := o.RemoteLinkTitleTemplate == nil && other.RemoteLinkTitleTemplate == nil ||
+		(o.RemoteLinkTitleTemplate != nil && other.RemoteLinkTitleTemplate != nil && *o.RemoteLinkTitleTemplate == *other.RemoteLinkTitleTemplate)
+	remoteLinkIconMatch := o.RemoteLinkIcon == nil && other.RemoteLinkIcon == nil ||
+		(o.RemoteLinkIcon != nil && other.RemoteLinkIcon != nil && *o.RemoteLinkIcon == *other.RemoteLinkIcon)
+	refreshHintMatch := o.RefreshHint == nil && other.RefreshHint == nil ||
+		(o.RefreshHint != nil && other.RefreshHint != nil && *o.RefreshHint == *other.RefreshHint)
+	refreshCooldownMatch := o.RefreshCooldown == nil && other.RefreshCooldown == nil ||
+		(o.RefreshCooldown != nil && other.RefreshCooldown != nil && *o.RefreshCooldown == *other.RefreshCooldown)
+	refreshCooldownReactionMatch := o.RefreshCooldownReaction == nil && other.RefreshCooldownReaction == nil ||
+		(o.RefreshCooldownReaction != nil && other.RefreshCooldownReaction != nil && *o.RefreshCooldownReaction == *other.RefreshCooldownReaction)
+	commandReactionMatch := o.CommandReaction == nil && other.CommandReaction == nil ||
+		(o.CommandReaction != nil && other.CommandReaction != nil && *o.CommandReaction == *other.CommandReaction)
+	commentFooterMatch := o.CommentFooter == nil && other.CommentFooter == nil ||
+		(o.CommentFooter != nil && other.CommentFooter != nil && *o.CommentFooter == *other.CommentFooter)
+	jiraDisplayURLMatch := o.JiraDisplayURL == nil && other.JiraDisplayURL == nil ||
+		(o.JiraDisplayURL != nil && other.JiraDisplayURL != nil && *o.JiraDisplayURL == *other.JiraDisplayURL)
+	commentOnlyOnRefreshMatch := o.CommentOnlyOnRefresh == nil && other.CommentOnlyOnRefresh == nil ||
+		(o.CommentOnlyOnRefresh != nil && other.CommentOnlyOnRefresh != nil && *o.CommentOnlyOnRefresh == *other.CommentOnlyOnRefresh)
+	verifiedCommandUsersMatch := len(o.VerifiedCommandUsers) == 0 && len(other.VerifiedCommandUsers) == 0 ||
+		(sets.New[string](o.VerifiedCommandUsers...).Equal(sets.New[string](other.VerifiedCommandUsers...)))
+	qeReviewersMatch := len(o.QEReviewers) == 0 && len(other.QEReviewers) == 0 ||
+		(sets.New[string](o.QEReviewers...).Equal(sets.New[string](other.QEReviewers...)))
+	verifiedLabelMatch := o.VerifiedLabel == nil && other.VerifiedLabel == nil ||
+		(o.VerifiedLabel != nil && other.VerifiedLabel != nil && *o.VerifiedLabel == *other.VerifiedLabel)
+	verifiedLaterLabelMatch := o.VerifiedLaterLabel == nil && other.VerifiedLaterLabel == nil ||
+		(o.VerifiedLaterLabel != nil && other.VerifiedLaterLabel != nil && *o.VerifiedLaterLabel == *other.VerifiedLaterLabel)
+	requireApprovalForVerifiedMatch := o.RequireApprovalForVerified == nil && other.RequireApprovalForVerified == nil ||
+		(o.RequireApprovalForVerified != nil && other.RequireApprovalForVerified != nil && *o.RequireApprovalForVerified == *other.RequireApprovalForVerified)
+	verifiedResetIgnorePathsMatch := len(o.VerifiedResetIgnorePaths) == 0 && len(other.VerifiedResetIgnorePaths) == 0 ||
+		(sets.New[string](o.VerifiedResetIgnorePaths...).Equal(sets.New[string](other.VerifiedResetIgnorePaths...)))
+	autoCCQAMatch := o.AutoCCQA == nil && other.AutoCCQA == nil ||
+		(o.AutoCCQA != nil && other.AutoCCQA != nil && *o.AutoCCQA == *other.AutoCCQA)
+	warnSelfReportedFixMatch := o.WarnSelfReportedFix == nil && other.WarnSelfReportedFix == nil ||
+		(o.WarnSelfReportedFix != nil && other.WarnSelfReportedFix != nil && *o.WarnSelfReportedFix == *other.WarnSelfReportedFix)
+	warnOnDefaultBranchConfigMatch := o.WarnOnDefaultBranchConfig == nil && other.WarnOnDefaultBranchConfig == nil ||
+		(o.WarnOnDefaultBranchConfig != nil && other.WarnOnDefaultBranchConfig != nil && *o.WarnOnDefaultBranchConfig == *other.WarnOnDefaultBranchConfig)
+	return validateByDefaultMatch && validateByDefaultMinimumSeverityMatch && referencesFromBodyMatch && isOpenMatch && rejectClosedBugsMatch && requiredSecurityLevelMatch && targetReleaseMatch && requireActiveSprintMatch && requireBlockedByMatch && requireBlockersResolvedMatch && requireLinkedIssueTypeMatch && autoQEApproveOnVerifiedDependentsMatch && requireAffectsVersionMatch && requireDescriptionMatch && needsInformationLabelMatch && allowedIssueTypesMatch && nonBugIssueTypesMatch && requireStoryPointsMatch && allowZeroStoryPointsMatch && requireEpicLinkMatch && epicLinkIssueTypesMatch && statusRequiredLabelsMatch && maxReferencedBugsMatch && requireSameProjectMatch && sameProjectIgnoreNonBugsMatch && strictProjectPrefixesMatch && skipDraftsMatch && ignoreAuthorsMatch && pullRequestActionsMatch && publishStatusMatch && requireSingleTargetVersionMatch && requireConsistentTargetVersionsMatch && showLinkedPRsMatch && fixVersionMatch && skipTargetVersionCheckMatch && bugStatesMatch && dependentBugStatesMatch &&
+		disableTransitionsMatch && statesAfterValidationMatch && commentFieldChangesMatch && addExternalLinkMatch && createIssueProjectMatch && createIssueAssigneeMatch && cloneDefaultAssigneeMatch && backportEligibleMatch && slackChannelMatch && privateCommentsMatch && addJiraCommentOnValidationMatch && privateCommentVisibilityMatch && statesAfterMergeMatch && preMergestatesAfterMergeMatch && recordTimeInStateMatch && closeGracePeriodMatch &&
+		releaseNotesMatch && releaseNotesTextMatch && ignoreCloneLabelsMatch && ignoreCloneLabelPrefixesMatch && cloneCopyFieldsMatch && addCloneLabelMatch && retitleCommandMatch && severityLabelsMatch && disableSeverityLabelsMatch && severityOrderMatch && commentTemplatesMatch && remoteLinkTitleTemplateMatch && remoteLinkIconMatch && refreshHintMatch && refreshCooldownMatch && refreshCooldownReactionMatch && commandReactionMatch && commentFooterMatch && jiraDisplayURLMatch && commentOnlyOnRefreshMatch && verifiedCommandUsersMatch && qeReviewersMatch && verifiedLabelMatch && verifiedLaterLabelMatch && requireApprovalForVerifiedMatch && verifiedResetIgnorePathsMatch && autoCCQAMatch && warnSelfReportedFixMatch && warnOnDefaultBranchConfigMatch
 }
 
 const JiraOptionsWildcard = `*`
 
 // OptionsForItem resolves a set of options for an item, honoring
-// the `*` wildcard and doing defaulting if it is present with the
-// item itself.
+// the `*` wildcard and regular expression keys and doing defaulting
+// if they are present with the item itself. Precedence is, from lowest
+// to highest: the `*` wildcard, then every key that matches the item
+// as a regular expression (in lexical order of the keys), and finally
+// the key that is an exact match for the item. See isItemPattern for
+// which keys are treated as regular expressions.
 func JiraOptionsForItem(item string, config map[string]JiraBranchOptions) JiraBranchOptions {
-	return ResolveJiraOptions(config[JiraOptionsWildcard], config[item])
+	options := config[JiraOptionsWildcard]
+	for _, key := range sets.List(sets.KeySet(config)) {
+		if key == JiraOptionsWildcard || key == item || !itemPatternMatches(key, item) {
+			continue
+		}
+		options = ResolveJiraOptions(options, config[key])
+	}
+	return ResolveJiraOptions(options, config[item])
+}
+
+// isItemPattern determines whether a configuration key is a regular expression rather
+// than a branch name. Only keys holding a character that git does not allow in branch
+// names are treated as regular expressions, so that a key like `release-4.1` only ever
+// applies to that branch. A regular expression without such a character can be written
+// with an explicit anchor, like `^release-(4|5)`.
+func isItemPattern(key string) bool {
+	return strings.ContainsAny(key, `\[*?^~: `)
+}
+
+// itemPatternMatches determines whether the key is a regular expression that matches
+// the whole item, so `release-4\.[0-9]+` matches `release-4.15` but not
+// `openshift-release-4.15`. Invalid regular expressions match nothing.
+func itemPatternMatches(key, item string) bool {
+	if !isItemPattern(key) {
+		return false
+	}
+	matcher, err := regexp.Compile(`^(?:` + key + `)$`)
+	return err == nil && matcher.MatchString(item)
 }
 
 // ResolveJiraOptions implements defaulting for a parent/child configuration,
@@ -229,12 +680,36 @@ func ResolveJiraOptions(parent, child JiraBranchOptions) JiraBranchOptions {
 		if parent.ValidateByDefault != nil {
 			output.ValidateByDefault = parent.ValidateByDefault
 		}
+		if parent.ValidateByDefaultMinimumSeverity != nil {
+			output.ValidateByDefaultMinimumSeverity = parent.ValidateByDefaultMinimumSeverity
+		}
+		if parent.ReferencesFromBody != nil {
+			output.ReferencesFromBody = parent.ReferencesFromBody
+		}
 		if parent.IsOpen != nil {
 			output.IsOpen = parent.IsOpen
 		}
+		if parent.RejectClosedBugs != nil {
+			output.RejectClosedBugs = parent.RejectClosedBugs
+		}
 		if parent.TargetVersion != nil {
 			output.TargetVersion = parent.TargetVersion
 		}
+		if parent.FixVersion != nil {
+			output.FixVersion = parent.FixVersion
+		}
+		if parent.RequireActiveSprint != nil {
+			output.RequireActiveSprint = parent.RequireActiveSprint
+		}
+		if parent.RequireSingleTargetVersion != nil {
+			output.RequireSingleTargetVersion = parent.RequireSingleTargetVersion
+		}
+		if parent.RequireConsistentTargetVersions != nil {
+			output.RequireConsistentTargetVersions = parent.RequireConsistentTargetVersions
+		}
+		if parent.ShowLinkedPRs != nil {
+			output.ShowLinkedPRs = parent.ShowLinkedPRs
+		}
 		if parent.SkipTargetVersionCheck != nil {
 			output.SkipTargetVersionCheck = parent.SkipTargetVersionCheck
 		}
@@ -247,39 +722,222 @@ func ResolveJiraOptions(parent, child JiraBranchOptions) JiraBranchOptions {
 		if parent.DependentBugTargetVersions != nil {
 			output.DependentBugTargetVersions = parent.DependentBugTargetVersions
 		}
+		if parent.AutoQEApproveOnVerifiedDependents != nil {
+			output.AutoQEApproveOnVerifiedDependents = parent.AutoQEApproveOnVerifiedDependents
+		}
+		if parent.RequireBlockedBy != nil {
+			output.RequireBlockedBy = parent.RequireBlockedBy
+		}
+		if parent.RequireBlockersResolved != nil {
+			output.RequireBlockersResolved = parent.RequireBlockersResolved
+		}
+		if parent.RequireLinkedIssueType != nil {
+			output.RequireLinkedIssueType = parent.RequireLinkedIssueType
+		}
+		if parent.RequireAffectsVersion != nil {
+			output.RequireAffectsVersion = parent.RequireAffectsVersion
+		}
+		if parent.RequireDescription != nil {
+			output.RequireDescription = parent.RequireDescription
+		}
+		if parent.NeedsInformationLabel != nil {
+			output.NeedsInformationLabel = parent.NeedsInformationLabel
+		}
+		if parent.AllowedIssueTypes != nil {
+			output.AllowedIssueTypes = parent.AllowedIssueTypes
+		}
+		if parent.NonBugIssueTypes != nil {
+			output.NonBugIssueTypes = parent.NonBugIssueTypes
+		}
+		if parent.RequireStoryPoints != nil {
+			output.RequireStoryPoints = parent.RequireStoryPoints
+		}
+		if parent.AllowZeroStoryPoints != nil {
+			output.AllowZeroStoryPoints = parent.AllowZeroStoryPoints
+		}
+		if parent.RequireEpicLink != nil {
+			output.RequireEpicLink = parent.RequireEpicLink
+		}
+		if parent.EpicLinkIssueTypes != nil {
+			output.EpicLinkIssueTypes = parent.EpicLinkIssueTypes
+		}
+		if parent.StatusRequiredLabels != nil {
+			output.StatusRequiredLabels = maps.Clone(parent.StatusRequiredLabels)
+		}
+		if parent.MaxReferencedBugs != nil {
+			output.MaxReferencedBugs = parent.MaxReferencedBugs
+		}
+		if parent.RequireSameProject != nil {
+			output.RequireSameProject = parent.RequireSameProject
+		}
+		if parent.SameProjectIgnoreNonBugs != nil {
+			output.SameProjectIgnoreNonBugs = parent.SameProjectIgnoreNonBugs
+		}
+		if parent.StrictProjectPrefixes != nil {
+			output.StrictProjectPrefixes = parent.StrictProjectPrefixes
+		}
+		if parent.SkipDrafts != nil {
+			output.SkipDrafts = parent.SkipDrafts
+		}
+		if parent.IgnoreAuthors != nil {
+			output.IgnoreAuthors = sets.NewString(output.IgnoreAuthors...).Insert(parent.IgnoreAuthors...).List()
+		}
+		if parent.PullRequestActions != nil {
+			output.PullRequestActions = parent.PullRequestActions
+		}
+		if parent.PublishStatus != nil {
+			output.PublishStatus = parent.PublishStatus
+		}
+		if parent.DisableTransitions != nil {
+			output.DisableTransitions = parent.DisableTransitions
+		}
 		if parent.StateAfterValidation != nil {
 			output.StateAfterValidation = parent.StateAfterValidation
 		}
 		if parent.PreMergeStateAfterValidation != nil {
 			output.PreMergeStateAfterValidation = parent.PreMergeStateAfterValidation
 		}
+		if parent.CommentFieldChanges != nil {
+			output.CommentFieldChanges = parent.CommentFieldChanges
+		}
 		if parent.AddExternalLink != nil {
 			output.AddExternalLink = parent.AddExternalLink
 		}
+		if parent.CreateIssueProject != nil {
+			output.CreateIssueProject = parent.CreateIssueProject
+		}
+		if parent.CreateIssueAssignee != nil {
+			output.CreateIssueAssignee = parent.CreateIssueAssignee
+		}
+		if parent.CloneDefaultAssignee != nil {
+			output.CloneDefaultAssignee = parent.CloneDefaultAssignee
+		}
+		if parent.BackportEligible != nil {
+			output.BackportEligible = parent.BackportEligible
+		}
+		if parent.SlackChannel != nil {
+			output.SlackChannel = parent.SlackChannel
+		}
+		if parent.PrivateComments != nil {
+			output.PrivateComments = parent.PrivateComments
+		}
+		if parent.AddJiraCommentOnValidation != nil {
+			output.AddJiraCommentOnValidation = parent.AddJiraCommentOnValidation
+		}
+		if parent.PrivateCommentVisibility != nil {
+			output.PrivateCommentVisibility = parent.PrivateCommentVisibility
+		}
 		if parent.StateAfterMerge != nil {
 			output.StateAfterMerge = parent.StateAfterMerge
 		}
 		if parent.PreMergeStateAfterMerge != nil {
 			output.PreMergeStateAfterMerge = parent.PreMergeStateAfterMerge
 		}
+		if parent.RecordTimeInState != nil {
+			output.RecordTimeInState = parent.RecordTimeInState
+		}
 		if parent.StateAfterClose != nil {
 			output.StateAfterClose = parent.StateAfterClose
 		}
 		if parent.PreMergeStateAfterClose != nil {
 			output.PreMergeStateAfterClose = parent.PreMergeStateAfterClose
 		}
+		if parent.CloseGracePeriod != nil {
+			output.CloseGracePeriod = parent.CloseGracePeriod
+		}
 		if parent.AllowedSecurityLevels != nil {
 			output.AllowedSecurityLevels = sets.NewString(output.AllowedSecurityLevels...).Insert(parent.AllowedSecurityLevels...).List()
 		}
+		if parent.RequiredSecurityLevel != nil {
+			output.RequiredSecurityLevel = parent.RequiredSecurityLevel
+		}
 		if parent.IgnoreCloneLabels != nil {
 			output.IgnoreCloneLabels = sets.NewString(output.IgnoreCloneLabels...).Insert(parent.IgnoreCloneLabels...).List()
 		}
+		if parent.IgnoreCloneLabelPrefixes != nil {
+			output.IgnoreCloneLabelPrefixes = sets.NewString(output.IgnoreCloneLabelPrefixes...).Insert(parent.IgnoreCloneLabelPrefixes...).List()
+		}
+		if parent.CloneCopyFields != nil {
+			output.CloneCopyFields = sets.NewString(output.CloneCopyFields...).Insert(parent.CloneCopyFields...).List()
+		}
+		if parent.AddCloneLabel != nil {
+			output.AddCloneLabel = parent.AddCloneLabel
+		}
+		if parent.RetitleCommand != nil {
+			output.RetitleCommand = parent.RetitleCommand
+		}
 		if parent.RequireReleaseNotes != nil {
 			output.RequireReleaseNotes = parent.RequireReleaseNotes
 		}
 		if parent.ReleaseNotesDefaultText != nil {
 			output.ReleaseNotesDefaultText = parent.ReleaseNotesDefaultText
 		}
+		if parent.SeverityLabels != nil {
+			output.SeverityLabels = maps.Clone(parent.SeverityLabels)
+		}
+		if parent.DisableSeverityLabels != nil {
+			output.DisableSeverityLabels = parent.DisableSeverityLabels
+		}
+		if parent.SeverityOrder != nil {
+			output.SeverityOrder = parent.SeverityOrder
+		}
+		if parent.CommentTemplates != nil {
+			output.CommentTemplates = maps.Clone(parent.CommentTemplates)
+		}
+		if parent.RemoteLinkTitleTemplate != nil {
+			output.RemoteLinkTitleTemplate = parent.RemoteLinkTitleTemplate
+		}
+		if parent.RemoteLinkIcon != nil {
+			output.RemoteLinkIcon = parent.RemoteLinkIcon
+		}
+		if parent.RefreshHint != nil {
+			output.RefreshHint = parent.RefreshHint
+		}
+		if parent.RefreshCooldown != nil {
+			output.RefreshCooldown = parent.RefreshCooldown
+		}
+		if parent.RefreshCooldownReaction != nil {
+			output.RefreshCooldownReaction = parent.RefreshCooldownReaction
+		}
+		if parent.CommandReaction != nil {
+			output.CommandReaction = parent.CommandReaction
+		}
+		if parent.CommentFooter != nil {
+			output.CommentFooter = parent.CommentFooter
+		}
+		if parent.JiraDisplayURL != nil {
+			output.JiraDisplayURL = parent.JiraDisplayURL
+		}
+		if parent.CommentOnlyOnRefresh != nil {
+			output.CommentOnlyOnRefresh = parent.CommentOnlyOnRefresh
+		}
+		if parent.VerifiedCommandUsers != nil {
+			output.VerifiedCommandUsers = sets.NewString(output.VerifiedCommandUsers...).Insert(parent.VerifiedCommandUsers...).List()
+		}
+		if parent.QEReviewers != nil {
+			output.QEReviewers = sets.NewString(output.QEReviewers...).Insert(parent.QEReviewers...).List()
+		}
+		if parent.VerifiedLabel != nil {
+			output.VerifiedLabel = parent.VerifiedLabel
+		}
+		if parent.VerifiedLaterLabel != nil {
+			output.VerifiedLaterLabel = parent.VerifiedLaterLabel
+		}
+		if parent.RequireApprovalForVerified != nil {
+			output.RequireApprovalForVerified = parent.RequireApprovalForVerified
+		}
+		if parent.VerifiedResetIgnorePaths != nil {
+			output.VerifiedResetIgnorePaths = sets.NewString(output.VerifiedResetIgnorePaths...).Insert(parent.VerifiedResetIgnorePaths...).List()
+		}
+		if parent.AutoCCQA != nil {
+			output.AutoCCQA = parent.AutoCCQA
+		}
+		if parent.WarnSelfReportedFix != nil {
+			output.WarnSelfReportedFix = parent.WarnSelfReportedFix
+		}
+		if parent.WarnOnDefaultBranchConfig != nil {
+			output.WarnOnDefaultBranchConfig = parent.WarnOnDefaultBranchConfig
+		}
 	}
 
 	// override with the child
@@ -289,12 +947,36 @@ func ResolveJiraOptions(parent, child JiraBranchOptions) JiraBranchOptions {
 	if child.ValidateByDefault != nil {
 		output.ValidateByDefault = child.ValidateByDefault
 	}
+	if child.ValidateByDefaultMinimumSeverity != nil {
+		output.ValidateByDefaultMinimumSeverity = child.ValidateByDefaultMinimumSeverity
+	}
+	if child.ReferencesFromBody != nil {
+		output.ReferencesFromBody = child.ReferencesFromBody
+	}
 	if child.IsOpen != nil {
 		output.IsOpen = child.IsOpen
 	}
+	if child.RejectClosedBugs != nil {
+		output.RejectClosedBugs = child.RejectClosedBugs
+	}
 	if child.TargetVersion != nil {
 		output.TargetVersion = child.TargetVersion
 	}
+	if child.FixVersion != nil {
+		output.FixVersion = child.FixVersion
+	}
+	if child.RequireActiveSprint != nil {
+		output.RequireActiveSprint = child.RequireActiveSprint
+	}
+	if child.RequireSingleTargetVersion != nil {
+		output.RequireSingleTargetVersion = child.RequireSingleTargetVersion
+	}
+	if child.RequireConsistentTargetVersions != nil {
+		output.RequireConsistentTargetVersions = child.RequireConsistentTargetVersions
+	}
+	if child.ShowLinkedPRs != nil {
+		output.ShowLinkedPRs = child.ShowLinkedPRs
+	}
 	if child.SkipTargetVersionCheck != nil {
 		output.SkipTargetVersionCheck = child.SkipTargetVersionCheck
 	}
@@ -309,47 +991,242 @@ func ResolveJiraOptions(parent, child JiraBranchOptions) JiraBranchOptions {
 	if child.DependentBugTargetVersions != nil {
 		output.DependentBugTargetVersions = child.DependentBugTargetVersions
 	}
+	if child.AutoQEApproveOnVerifiedDependents != nil {
+		output.AutoQEApproveOnVerifiedDependents = child.AutoQEApproveOnVerifiedDependents
+	}
+	if child.RequireBlockedBy != nil {
+		output.RequireBlockedBy = child.RequireBlockedBy
+	}
+	if child.RequireBlockersResolved != nil {
+		output.RequireBlockersResolved = child.RequireBlockersResolved
+	}
+	if child.RequireLinkedIssueType != nil {
+		output.RequireLinkedIssueType = child.RequireLinkedIssueType
+	}
+	if child.RequireAffectsVersion != nil {
+		output.RequireAffectsVersion = child.RequireAffectsVersion
+	}
+	if child.RequireDescription != nil {
+		output.RequireDescription = child.RequireDescription
+	}
+	if child.NeedsInformationLabel != nil {
+		output.NeedsInformationLabel = child.NeedsInformationLabel
+	}
+	if child.AllowedIssueTypes != nil {
+		output.AllowedIssueTypes = child.AllowedIssueTypes
+	}
+	if child.NonBugIssueTypes != nil {
+		output.NonBugIssueTypes = child.NonBugIssueTypes
+	}
+	if child.RequireStoryPoints != nil {
+		output.RequireStoryPoints = child.RequireStoryPoints
+	}
+	if child.AllowZeroStoryPoints != nil {
+		output.AllowZeroStoryPoints = child.AllowZeroStoryPoints
+	}
+	if child.RequireEpicLink != nil {
+		output.RequireEpicLink = child.RequireEpicLink
+	}
+	if child.EpicLinkIssueTypes != nil {
+		output.EpicLinkIssueTypes = child.EpicLinkIssueTypes
+	}
+	if child.StatusRequiredLabels != nil {
+		// labels are overridden per status so that children only need to specify the statuses they change
+		if output.StatusRequiredLabels == nil {
+			output.StatusRequiredLabels = map[string][]string{}
+		}
+		maps.Copy(output.StatusRequiredLabels, child.StatusRequiredLabels)
+	}
+	if child.MaxReferencedBugs != nil {
+		output.MaxReferencedBugs = child.MaxReferencedBugs
+	}
+	if child.RequireSameProject != nil {
+		output.RequireSameProject = child.RequireSameProject
+	}
+	if child.SameProjectIgnoreNonBugs != nil {
+		output.SameProjectIgnoreNonBugs = child.SameProjectIgnoreNonBugs
+	}
+	if child.StrictProjectPrefixes != nil {
+		output.StrictProjectPrefixes = child.StrictProjectPrefixes
+	}
+	if child.SkipDrafts != nil {
+		output.SkipDrafts = child.SkipDrafts
+	}
+	if child.IgnoreAuthors != nil {
+		output.IgnoreAuthors = sets.NewString(output.IgnoreAuthors...).Insert(child.IgnoreAuthors...).List()
+	}
+	if child.PullRequestActions != nil {
+		output.PullRequestActions = child.PullRequestActions
+	}
+	if child.PublishStatus != nil {
+		output.PublishStatus = child.PublishStatus
+	}
+	if child.DisableTransitions != nil {
+		output.DisableTransitions = child.DisableTransitions
+	}
 	if child.StateAfterValidation != nil {
 		output.StateAfterValidation = child.StateAfterValidation
 	}
 	if child.PreMergeStateAfterValidation != nil {
 		output.PreMergeStateAfterValidation = child.PreMergeStateAfterValidation
 	}
+	if child.CommentFieldChanges != nil {
+		output.CommentFieldChanges = child.CommentFieldChanges
+	}
 	if child.AddExternalLink != nil {
 		output.AddExternalLink = child.AddExternalLink
 	}
+	if child.CreateIssueProject != nil {
+		output.CreateIssueProject = child.CreateIssueProject
+	}
+	if child.CreateIssueAssignee != nil {
+		output.CreateIssueAssignee = child.CreateIssueAssignee
+	}
+	if child.CloneDefaultAssignee != nil {
+		output.CloneDefaultAssignee = child.CloneDefaultAssignee
+	}
+	if child.BackportEligible != nil {
+		output.BackportEligible = child.BackportEligible
+	}
+	if child.SlackChannel != nil {
+		output.SlackChannel = child.SlackChannel
+	}
+	if child.PrivateComments != nil {
+		output.PrivateComments = child.PrivateComments
+	}
+	if child.AddJiraCommentOnValidation != nil {
+		output.AddJiraCommentOnValidation = child.AddJiraCommentOnValidation
+	}
+	if child.PrivateCommentVisibility != nil {
+		output.PrivateCommentVisibility = child.PrivateCommentVisibility
+	}
 	if child.StateAfterMerge != nil {
 		output.StateAfterMerge = child.StateAfterMerge
 	}
 	if child.PreMergeStateAfterMerge != nil {
 		output.PreMergeStateAfterMerge = child.PreMergeStateAfterMerge
 	}
+	if child.RecordTimeInState != nil {
+		output.RecordTimeInState = child.RecordTimeInState
+	}
 	if child.StateAfterClose != nil {
 		output.StateAfterClose = child.StateAfterClose
 	}
 	if child.PreMergeStateAfterClose != nil {
 		output.PreMergeStateAfterClose = child.PreMergeStateAfterClose
 	}
+	if child.CloseGracePeriod != nil {
+		output.CloseGracePeriod = child.CloseGracePeriod
+	}
 	if child.AllowedSecurityLevels != nil {
 		output.AllowedSecurityLevels = sets.NewString(output.AllowedSecurityLevels...).Insert(child.AllowedSecurityLevels...).List()
 	}
+	if child.RequiredSecurityLevel != nil {
+		output.RequiredSecurityLevel = child.RequiredSecurityLevel
+	}
 	if child.IgnoreCloneLabels != nil {
 		output.IgnoreCloneLabels = sets.NewString(output.IgnoreCloneLabels...).Insert(child.IgnoreCloneLabels...).List()
 	}
+	if child.IgnoreCloneLabelPrefixes != nil {
+		output.IgnoreCloneLabelPrefixes = sets.NewString(output.IgnoreCloneLabelPrefixes...).Insert(child.IgnoreCloneLabelPrefixes...).List()
+	}
+	if child.CloneCopyFields != nil {
+		output.CloneCopyFields = sets.NewString(output.CloneCopyFields...).Insert(child.CloneCopyFields...).List()
+	}
+	if child.AddCloneLabel != nil {
+		output.AddCloneLabel = child.AddCloneLabel
+	}
+	if child.RetitleCommand != nil {
+		output.RetitleCommand = child.RetitleCommand
+	}
 	if child.RequireReleaseNotes != nil {
 		output.RequireReleaseNotes = child.RequireReleaseNotes
 	}
 	if child.ReleaseNotesDefaultText != nil {
 		output.ReleaseNotesDefaultText = child.ReleaseNotesDefaultText
 	}
+	if child.SeverityLabels != nil {
+		// labels are overridden per severity so that children only need to specify the labels they change
+		if output.SeverityLabels == nil {
+			output.SeverityLabels = map[string]string{}
+		}
+		maps.Copy(output.SeverityLabels, child.SeverityLabels)
+	}
+	if child.DisableSeverityLabels != nil {
+		output.DisableSeverityLabels = child.DisableSeverityLabels
+	}
+	if child.SeverityOrder != nil {
+		output.SeverityOrder = child.SeverityOrder
+	}
+	if child.CommentTemplates != nil {
+		// templates are overridden per message type so that children only need to specify the templates they change
+		if output.CommentTemplates == nil {
+			output.CommentTemplates = map[string]string{}
+		}
+		maps.Copy(output.CommentTemplates, child.CommentTemplates)
+	}
+	if child.RemoteLinkTitleTemplate != nil {
+		output.RemoteLinkTitleTemplate = child.RemoteLinkTitleTemplate
+	}
+	if child.RemoteLinkIcon != nil {
+		output.RemoteLinkIcon = child.RemoteLinkIcon
+	}
+	if child.RefreshHint != nil {
+		output.RefreshHint = child.RefreshHint
+	}
+	if child.RefreshCooldown != nil {
+		output.RefreshCooldown = child.RefreshCooldown
+	}
+	if child.RefreshCooldownReaction != nil {
+		output.RefreshCooldownReaction = child.RefreshCooldownReaction
+	}
+	if child.CommandReaction != nil {
+		output.CommandReaction = child.CommandReaction
+	}
+	if child.CommentFooter != nil {
+		output.CommentFooter = child.CommentFooter
+	}
+	if child.JiraDisplayURL != nil {
+		output.JiraDisplayURL = child.JiraDisplayURL
+	}
+	if child.CommentOnlyOnRefresh != nil {
+		output.CommentOnlyOnRefresh = child.CommentOnlyOnRefresh
+	}
+	if child.VerifiedCommandUsers != nil {
+		output.VerifiedCommandUsers = sets.NewString(output.VerifiedCommandUsers...).Insert(child.VerifiedCommandUsers...).List()
+	}
+	if child.QEReviewers != nil {
+		output.QEReviewers = sets.NewString(output.QEReviewers...).Insert(child.QEReviewers...).List()
+	}
+	if child.VerifiedLabel != nil {
+		output.VerifiedLabel = child.VerifiedLabel
+	}
+	if child.VerifiedLaterLabel != nil {
+		output.VerifiedLaterLabel = child.VerifiedLaterLabel
+	}
+	if child.RequireApprovalForVerified != nil {
+		output.RequireApprovalForVerified = child.RequireApprovalForVerified
+	}
+	if child.VerifiedResetIgnorePaths != nil {
+		output.VerifiedResetIgnorePaths = sets.NewString(output.VerifiedResetIgnorePaths...).Insert(child.VerifiedResetIgnorePaths...).List()
+	}
+	if child.AutoCCQA != nil {
+		output.AutoCCQA = child.AutoCCQA
+	}
+	if child.WarnSelfReportedFix != nil {
+		output.WarnSelfReportedFix = child.WarnSelfReportedFix
+	}
+	if child.WarnOnDefaultBranchConfig != nil {
+		output.WarnOnDefaultBranchConfig = child.WarnOnDefaultBranchConfig
+	}
 
 	return output
 }
 
 // OptionsForBranch determines the criteria for a valid Jira bug on a branch of a repo
 // by defaulting in a cascading way, in the following order (later entries override earlier
-// ones), always searching for the wildcard as well as the branch name: global, then org,
-// repo, and finally branch-specific configuration.
+// ones), always searching for the wildcard and regular expression keys as well as the branch
+// name: global, then org, repo, and finally branch-specific configuration.
 func (b *Config) OptionsForBranch(org, repo, branch string) JiraBranchOptions {
 	options := JiraOptionsForItem(branch, b.Default)
 	orgOptions, exists := b.Orgs[org]
@@ -367,6 +1244,50 @@ func (b *Config) OptionsForBranch(org, repo, branch string) JiraBranchOptions {
 	return options
 }
 
+// OptionSourcesForBranch determines which layer of the configuration (global, org, or repo)
+// supplied each of the options returned by OptionsForBranch, keyed by the name of the option.
+func (b *Config) OptionSourcesForBranch(org, repo, branch string) (map[string]string, error) {
+	type layer struct {
+		name    string
+		options JiraBranchOptions
+	}
+	layers := []layer{{name: "global", options: JiraOptionsForItem(branch, b.Default)}}
+	if orgOptions, exists := b.Orgs[org]; exists {
+		layers = append(layers, layer{name: "org", options: JiraOptionsForItem(branch, orgOptions.Default)})
+		if repoOptions, exists := orgOptions.Repos[repo]; exists {
+			layers = append(layers, layer{name: "repo", options: JiraOptionsForItem(branch, repoOptions.Branches)})
+		}
+	}
+
+	sources := map[string]string{}
+	for _, layer := range layers {
+		// options from earlier layers are dropped entirely when a layer excludes defaults
+		if layer.options.ExcludeDefaults != nil && *layer.options.ExcludeDefaults {
+			sources = map[string]string{}
+		}
+		raw, err := json.Marshal(layer.options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s options: %w", layer.name, err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s options: %w", layer.name, err)
+		}
+		for field := range fields {
+			sources[field] = layer.name
+		}
+	}
+	return sources, nil
+}
+
+// BugProjectSet returns the set of Jira projects whose issues are treated as bugs.
+func (b *Config) BugProjectSet() sets.Set[string] {
+	if len(b.BugProjects) == 0 {
+		return defaultBugProjects
+	}
+	return sets.New(b.BugProjects...)
+}
+
 // OptionsForRepo determines the criteria for a valid Jira bug on branches of a repo
 // by defaulting in a cascading way, in the following order (later entries override earlier
 // ones), always searching for the wildcard as well as the branch name: global, then org,
@@ -3,7 +3,9 @@ package main
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/diff"
 	"sigs.k8s.io/yaml"
 )
@@ -50,6 +52,69 @@ func TestJiraOptionsForItem(t *testing.T) {
 			},
 			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &two},
 		},
+		{
+			name: "regular expression config resolves to options on top of global config",
+			item: "release-4.15",
+			config: map[string]JiraBranchOptions{
+				"*":                 {IsOpen: &open, TargetVersion: &one},
+				`release-4\.[0-9]+`: {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &two},
+		},
+		{
+			name: "regular expression config must match the whole item",
+			item: "openshift-release-4.15",
+			config: map[string]JiraBranchOptions{
+				"*":                 {IsOpen: &open, TargetVersion: &one},
+				`release-4\.[0-9]+`: {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &one},
+		},
+		{
+			name: "specific config is favored over regular expression config",
+			item: "release-4.15",
+			config: map[string]JiraBranchOptions{
+				`release-4\.[0-9]+`: {IsOpen: &open, TargetVersion: &one},
+				"release-4.15":      {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &two},
+		},
+		{
+			name: "multiple matching regular expressions are resolved in lexical order",
+			item: "release-4.15",
+			config: map[string]JiraBranchOptions{
+				`release-.*`:        {IsOpen: &open, TargetVersion: &one},
+				`release-4\.[0-9]+`: {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &two},
+		},
+		{
+			name: "branch name keys are not treated as regular expressions",
+			item: "release-4x1",
+			config: map[string]JiraBranchOptions{
+				"*":           {IsOpen: &open, TargetVersion: &one},
+				"release-4.1": {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &one},
+		},
+		{
+			name: "anchored regular expression config resolves to options",
+			item: "release-5",
+			config: map[string]JiraBranchOptions{
+				"*":              {IsOpen: &open, TargetVersion: &one},
+				`^release-(4|5)`: {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &two},
+		},
+		{
+			name: "invalid regular expression is ignored",
+			item: "release-4.15",
+			config: map[string]JiraBranchOptions{
+				"*":         {IsOpen: &open, TargetVersion: &one},
+				`release-[`: {TargetVersion: &two},
+			},
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &one},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -64,7 +129,10 @@ func TestJiraOptionsForItem(t *testing.T) {
 func TestResolveJiraOptions(t *testing.T) {
 	open, closed := true, false
 	yes, no := true, false
-	one, two := "v1", "v2"
+	one, two, empty := "v1", "v2", ""
+	parentHint, childHint := "Re-run the parent job.", "Re-run the child job."
+	maxBugs, moreMaxBugs := 1, 3
+	parentLinkTitle, childLinkTitle := "{{.Org}}/{{.Repo}}#{{.Number}}", "{{.Title}}"
 	modified, verified, post, pre, post2, pre2 := "MODIFIED", "VERIFIED", "POST", "PRE", "POST2", "PRE2"
 	modifiedState := JiraBugState{Status: modified}
 	verifiedState := JiraBugState{Status: verified}
@@ -269,6 +337,360 @@ func TestResolveJiraOptions(t *testing.T) {
 			child:    JiraBranchOptions{TargetVersion: &one},
 			expected: JiraBranchOptions{DependentBugTargetVersions: &[]string{one}, TargetVersion: &one, ExcludeDefaults: &yes},
 		},
+		{
+			name:     "child overrides parent on fix version",
+			parent:   JiraBranchOptions{IsOpen: &open, FixVersion: &one},
+			child:    JiraBranchOptions{FixVersion: &two},
+			expected: JiraBranchOptions{IsOpen: &open, FixVersion: &two},
+		},
+		{
+			name:     "child overrides parent comment templates per message type",
+			parent:   JiraBranchOptions{CommentTemplates: map[string]string{"valid": "parent valid", "invalid": "parent invalid"}},
+			child:    JiraBranchOptions{CommentTemplates: map[string]string{"valid": "child valid", "merged": "child merged"}},
+			expected: JiraBranchOptions{CommentTemplates: map[string]string{"valid": "child valid", "invalid": "parent invalid", "merged": "child merged"}},
+		},
+		{
+			name:     "child overrides parent on private comments",
+			parent:   JiraBranchOptions{IsOpen: &open, PrivateComments: &yes},
+			child:    JiraBranchOptions{PrivateComments: &no},
+			expected: JiraBranchOptions{IsOpen: &open, PrivateComments: &no},
+		},
+		{
+			name:     "child overrides parent severity labels per severity",
+			parent:   JiraBranchOptions{SeverityLabels: map[string]string{"Critical": "parent/critical", "Low": "parent/low"}},
+			child:    JiraBranchOptions{SeverityLabels: map[string]string{"Critical": "child/critical"}},
+			expected: JiraBranchOptions{SeverityLabels: map[string]string{"Critical": "child/critical", "Low": "parent/low"}},
+		},
+		{
+			name:     "child overrides parent status required labels per status",
+			parent:   JiraBranchOptions{StatusRequiredLabels: map[string][]string{"POST": {"needs-qe"}, "MODIFIED": {"lgtm"}}},
+			child:    JiraBranchOptions{StatusRequiredLabels: map[string][]string{"POST": {"docs-approved"}}},
+			expected: JiraBranchOptions{StatusRequiredLabels: map[string][]string{"POST": {"docs-approved"}, "MODIFIED": {"lgtm"}}},
+		},
+		{
+			name:     "child overrides parent refresh hint",
+			parent:   JiraBranchOptions{IsOpen: &open, RefreshHint: &parentHint},
+			child:    JiraBranchOptions{RefreshHint: &childHint},
+			expected: JiraBranchOptions{IsOpen: &open, RefreshHint: &childHint},
+		},
+		{
+			name:     "child verified command users are merged with parent verified command users",
+			parent:   JiraBranchOptions{VerifiedCommandUsers: []string{"alice", "bob"}},
+			child:    JiraBranchOptions{VerifiedCommandUsers: []string{"carol"}},
+			expected: JiraBranchOptions{VerifiedCommandUsers: []string{"alice", "bob", "carol"}},
+		},
+		{
+			name:     "child qe reviewers are merged with parent qe reviewers",
+			parent:   JiraBranchOptions{QEReviewers: []string{"alice"}},
+			child:    JiraBranchOptions{QEReviewers: []string{"bob"}},
+			expected: JiraBranchOptions{QEReviewers: []string{"alice", "bob"}},
+		},
+		{
+			name:     "child overrides parent automatic QA review requests",
+			parent:   JiraBranchOptions{AutoCCQA: &yes},
+			child:    JiraBranchOptions{AutoCCQA: &no},
+			expected: JiraBranchOptions{AutoCCQA: &no},
+		},
+		{
+			name:     "child overrides parent self-reported fix warning",
+			parent:   JiraBranchOptions{WarnSelfReportedFix: &yes},
+			child:    JiraBranchOptions{WarnSelfReportedFix: &no},
+			expected: JiraBranchOptions{WarnSelfReportedFix: &no},
+		},
+		{
+			name:     "child overrides parent default branch config warning",
+			parent:   JiraBranchOptions{WarnOnDefaultBranchConfig: &yes},
+			child:    JiraBranchOptions{WarnOnDefaultBranchConfig: &no},
+			expected: JiraBranchOptions{WarnOnDefaultBranchConfig: &no},
+		},
+		{
+			name:     "child overrides parent remote link title template",
+			parent:   JiraBranchOptions{RemoteLinkTitleTemplate: &parentLinkTitle},
+			child:    JiraBranchOptions{RemoteLinkTitleTemplate: &childLinkTitle},
+			expected: JiraBranchOptions{RemoteLinkTitleTemplate: &childLinkTitle},
+		},
+		{
+			name:     "child overrides parent skipping of draft pull requests",
+			parent:   JiraBranchOptions{SkipDrafts: &yes},
+			child:    JiraBranchOptions{SkipDrafts: &no},
+			expected: JiraBranchOptions{SkipDrafts: &no},
+		},
+		{
+			name:     "child overrides parent on allowed issue types",
+			parent:   JiraBranchOptions{AllowedIssueTypes: &[]string{"Bug"}},
+			child:    JiraBranchOptions{AllowedIssueTypes: &[]string{"Story", "Bug"}},
+			expected: JiraBranchOptions{AllowedIssueTypes: &[]string{"Story", "Bug"}},
+		},
+		{
+			name:     "child overrides parent on non-bug issue types",
+			parent:   JiraBranchOptions{NonBugIssueTypes: &[]string{"Story"}},
+			child:    JiraBranchOptions{NonBugIssueTypes: &[]string{"Story", "Task"}},
+			expected: JiraBranchOptions{NonBugIssueTypes: &[]string{"Story", "Task"}},
+		},
+		{
+			name:     "child overrides parent story points requirement",
+			parent:   JiraBranchOptions{RequireStoryPoints: &yes, AllowZeroStoryPoints: &no},
+			child:    JiraBranchOptions{AllowZeroStoryPoints: &yes},
+			expected: JiraBranchOptions{RequireStoryPoints: &yes, AllowZeroStoryPoints: &yes},
+		},
+		{
+			name:     "child overrides parent publishing of commit statuses",
+			parent:   JiraBranchOptions{PublishStatus: &no},
+			child:    JiraBranchOptions{PublishStatus: &yes},
+			expected: JiraBranchOptions{PublishStatus: &yes},
+		},
+		{
+			name:     "child overrides parent disabling of severity labels",
+			parent:   JiraBranchOptions{DisableSeverityLabels: &yes, SeverityLabels: map[string]string{"Critical": "sev/critical"}},
+			child:    JiraBranchOptions{DisableSeverityLabels: &no},
+			expected: JiraBranchOptions{DisableSeverityLabels: &no, SeverityLabels: map[string]string{"Critical": "sev/critical"}},
+		},
+		{
+			name:     "child overrides parent severity order",
+			parent:   JiraBranchOptions{SeverityOrder: []string{"Urgent", "High", "Medium"}},
+			child:    JiraBranchOptions{SeverityOrder: []string{"Blocker", "Major", "Minor"}},
+			expected: JiraBranchOptions{SeverityOrder: []string{"Blocker", "Major", "Minor"}},
+		},
+		{
+			name:     "child overrides parent close grace period",
+			parent:   JiraBranchOptions{CloseGracePeriod: &metav1.Duration{Duration: time.Hour}, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			child:    JiraBranchOptions{CloseGracePeriod: &metav1.Duration{Duration: 24 * time.Hour}},
+			expected: JiraBranchOptions{CloseGracePeriod: &metav1.Duration{Duration: 24 * time.Hour}, StateAfterClose: &JiraBugState{Status: "NEW"}},
+		},
+		{
+			name:     "child inherits parent requirement for resolved blockers",
+			parent:   JiraBranchOptions{RequireBlockersResolved: &yes},
+			child:    JiraBranchOptions{RequireBlockedBy: &yes},
+			expected: JiraBranchOptions{RequireBlockersResolved: &yes, RequireBlockedBy: &yes},
+		},
+		{
+			name:     "child overrides parent required linked issue type",
+			parent:   JiraBranchOptions{RequireLinkedIssueType: &one},
+			child:    JiraBranchOptions{RequireLinkedIssueType: &two},
+			expected: JiraBranchOptions{RequireLinkedIssueType: &two},
+		},
+		{
+			name:     "child overrides parent remote link icon",
+			parent:   JiraBranchOptions{RemoteLinkIcon: &RemoteLinkIcon{URL: "https://gitlab.com/favicon.ico", Title: "GitLab"}},
+			child:    JiraBranchOptions{RemoteLinkIcon: &RemoteLinkIcon{URL: "https://example.com/icon.png", Title: "Example"}},
+			expected: JiraBranchOptions{RemoteLinkIcon: &RemoteLinkIcon{URL: "https://example.com/icon.png", Title: "Example"}},
+		},
+		{
+			name:     "child overrides parent maximum number of referenced bugs",
+			parent:   JiraBranchOptions{MaxReferencedBugs: &maxBugs, IsOpen: &yes},
+			child:    JiraBranchOptions{MaxReferencedBugs: &moreMaxBugs},
+			expected: JiraBranchOptions{MaxReferencedBugs: &moreMaxBugs, IsOpen: &yes},
+		},
+		{
+			name:     "child overrides parent description requirement",
+			parent:   JiraBranchOptions{RequireDescription: &yes, RequireAffectsVersion: &yes},
+			child:    JiraBranchOptions{RequireDescription: &no},
+			expected: JiraBranchOptions{RequireDescription: &no, RequireAffectsVersion: &yes},
+		},
+		{
+			name:     "child overrides parent project for created bugs",
+			parent:   JiraBranchOptions{CreateIssueProject: &one, CreateIssueAssignee: &two},
+			child:    JiraBranchOptions{CreateIssueProject: &two},
+			expected: JiraBranchOptions{CreateIssueProject: &two, CreateIssueAssignee: &two},
+		},
+		{
+			name:     "child overrides parent clone default assignee",
+			parent:   JiraBranchOptions{CloneDefaultAssignee: &one},
+			child:    JiraBranchOptions{CloneDefaultAssignee: &two},
+			expected: JiraBranchOptions{CloneDefaultAssignee: &two},
+		},
+		{
+			name:     "child overrides parent backport eligibility",
+			parent:   JiraBranchOptions{BackportEligible: &no},
+			child:    JiraBranchOptions{BackportEligible: &yes},
+			expected: JiraBranchOptions{BackportEligible: &yes},
+		},
+		{
+			name:     "child overrides parent disabled transitions",
+			parent:   JiraBranchOptions{DisableTransitions: &yes, StateAfterValidation: &JiraBugState{Status: "POST"}},
+			child:    JiraBranchOptions{DisableTransitions: &no},
+			expected: JiraBranchOptions{DisableTransitions: &no, StateAfterValidation: &JiraBugState{Status: "POST"}},
+		},
+		{
+			name:     "child overrides parent field change comments",
+			parent:   JiraBranchOptions{CommentFieldChanges: &yes},
+			child:    JiraBranchOptions{CommentFieldChanges: &no},
+			expected: JiraBranchOptions{CommentFieldChanges: &no},
+		},
+		{
+			name:     "child overrides parent required security level",
+			parent:   JiraBranchOptions{RequiredSecurityLevel: &one},
+			child:    JiraBranchOptions{RequiredSecurityLevel: &two},
+			expected: JiraBranchOptions{RequiredSecurityLevel: &two},
+		},
+		{
+			name:     "child overrides parent private comment visibility",
+			parent:   JiraBranchOptions{PrivateComments: &yes, PrivateCommentVisibility: &JiraCommentVisibility{Type: "group", Value: "Employees"}},
+			child:    JiraBranchOptions{PrivateCommentVisibility: &JiraCommentVisibility{Type: "role", Value: "Developers"}},
+			expected: JiraBranchOptions{PrivateComments: &yes, PrivateCommentVisibility: &JiraCommentVisibility{Type: "role", Value: "Developers"}},
+		},
+		{
+			name:     "child overrides parent auto QE approval on verified dependents",
+			parent:   JiraBranchOptions{AutoQEApproveOnVerifiedDependents: &yes},
+			child:    JiraBranchOptions{AutoQEApproveOnVerifiedDependents: &no},
+			expected: JiraBranchOptions{AutoQEApproveOnVerifiedDependents: &no},
+		},
+		{
+			name:     "child overrides parent epic link requirement",
+			parent:   JiraBranchOptions{RequireEpicLink: &yes, EpicLinkIssueTypes: &[]string{"Story"}},
+			child:    JiraBranchOptions{EpicLinkIssueTypes: &[]string{"Task"}},
+			expected: JiraBranchOptions{RequireEpicLink: &yes, EpicLinkIssueTypes: &[]string{"Task"}},
+		},
+		{
+			name:     "child overrides parent refresh cooldown reaction",
+			parent:   JiraBranchOptions{RefreshCooldownReaction: &one},
+			child:    JiraBranchOptions{RefreshCooldownReaction: &two},
+			expected: JiraBranchOptions{RefreshCooldownReaction: &two},
+		},
+		{
+			name:     "child overrides parent command reaction",
+			parent:   JiraBranchOptions{CommandReaction: &one},
+			child:    JiraBranchOptions{CommandReaction: &two},
+			expected: JiraBranchOptions{CommandReaction: &two},
+		},
+		{
+			name:     "child overrides parent comment footer",
+			parent:   JiraBranchOptions{CommentFooter: &one},
+			child:    JiraBranchOptions{CommentFooter: &two},
+			expected: JiraBranchOptions{CommentFooter: &two},
+		},
+		{
+			name:     "child overrides parent consistent target versions requirement",
+			parent:   JiraBranchOptions{RequireConsistentTargetVersions: &yes},
+			child:    JiraBranchOptions{RequireConsistentTargetVersions: &no},
+			expected: JiraBranchOptions{RequireConsistentTargetVersions: &no},
+		},
+		{
+			name:     "child overrides parent linked pull request listing",
+			parent:   JiraBranchOptions{ShowLinkedPRs: &yes},
+			child:    JiraBranchOptions{ShowLinkedPRs: &no},
+			expected: JiraBranchOptions{ShowLinkedPRs: &no},
+		},
+		{
+			name:     "child overrides parent jira comment on validation",
+			parent:   JiraBranchOptions{AddJiraCommentOnValidation: &yes},
+			child:    JiraBranchOptions{AddJiraCommentOnValidation: &no},
+			expected: JiraBranchOptions{AddJiraCommentOnValidation: &no},
+		},
+		{
+			name:     "child overrides parent jira display url",
+			parent:   JiraBranchOptions{JiraDisplayURL: &one},
+			child:    JiraBranchOptions{JiraDisplayURL: &two},
+			expected: JiraBranchOptions{JiraDisplayURL: &two},
+		},
+		{
+			name:     "child overrides parent comment only on refresh",
+			parent:   JiraBranchOptions{CommentOnlyOnRefresh: &yes},
+			child:    JiraBranchOptions{CommentOnlyOnRefresh: &no},
+			expected: JiraBranchOptions{CommentOnlyOnRefresh: &no},
+		},
+		{
+			name:     "child adds to parent ignored authors",
+			parent:   JiraBranchOptions{IgnoreAuthors: []string{"dependabot[bot]"}},
+			child:    JiraBranchOptions{IgnoreAuthors: []string{"renovate[bot]"}},
+			expected: JiraBranchOptions{IgnoreAuthors: []string{"dependabot[bot]", "renovate[bot]"}},
+		},
+		{
+			name:     "child overrides parent pull request actions",
+			parent:   JiraBranchOptions{PullRequestActions: &[]string{"opened", "edited"}},
+			child:    JiraBranchOptions{PullRequestActions: &[]string{"opened", "closed"}},
+			expected: JiraBranchOptions{PullRequestActions: &[]string{"opened", "closed"}},
+		},
+		{
+			name:     "child disables parent retitle command",
+			parent:   JiraBranchOptions{RetitleCommand: &one},
+			child:    JiraBranchOptions{RetitleCommand: &empty},
+			expected: JiraBranchOptions{RetitleCommand: &empty},
+		},
+		{
+			name:     "child overrides parent time in state recording",
+			parent:   JiraBranchOptions{RecordTimeInState: &yes},
+			child:    JiraBranchOptions{RecordTimeInState: &no},
+			expected: JiraBranchOptions{RecordTimeInState: &no},
+		},
+		{
+			name:     "child overrides parent references from body",
+			parent:   JiraBranchOptions{ReferencesFromBody: &yes},
+			child:    JiraBranchOptions{ReferencesFromBody: &no},
+			expected: JiraBranchOptions{ReferencesFromBody: &no},
+		},
+		{
+			name:     "child overrides parent minimum severity for validating by default",
+			parent:   JiraBranchOptions{ValidateByDefault: &yes, ValidateByDefaultMinimumSeverity: &one},
+			child:    JiraBranchOptions{ValidateByDefaultMinimumSeverity: &two},
+			expected: JiraBranchOptions{ValidateByDefault: &yes, ValidateByDefaultMinimumSeverity: &two},
+		},
+		{
+			name:     "child overrides parent slack channel",
+			parent:   JiraBranchOptions{SlackChannel: &one, CreateIssueProject: &two},
+			child:    JiraBranchOptions{SlackChannel: &two},
+			expected: JiraBranchOptions{SlackChannel: &two, CreateIssueProject: &two},
+		},
+		{
+			name:     "child overrides parent closed bug rejection",
+			parent:   JiraBranchOptions{RejectClosedBugs: &yes, IsOpen: &yes},
+			child:    JiraBranchOptions{RejectClosedBugs: &no},
+			expected: JiraBranchOptions{RejectClosedBugs: &no, IsOpen: &yes},
+		},
+		{
+			name:     "child overrides parent approval requirement for verified",
+			parent:   JiraBranchOptions{RequireApprovalForVerified: &yes, VerifiedLabel: &one},
+			child:    JiraBranchOptions{RequireApprovalForVerified: &no},
+			expected: JiraBranchOptions{RequireApprovalForVerified: &no, VerifiedLabel: &one},
+		},
+		{
+			name:     "child overrides parent verified label",
+			parent:   JiraBranchOptions{VerifiedLabel: &one, VerifiedLaterLabel: &two},
+			child:    JiraBranchOptions{VerifiedLabel: &two},
+			expected: JiraBranchOptions{VerifiedLabel: &two, VerifiedLaterLabel: &two},
+		},
+		{
+			name:     "child overrides parent needs information label",
+			parent:   JiraBranchOptions{NeedsInformationLabel: &yes, RequireDescription: &yes},
+			child:    JiraBranchOptions{NeedsInformationLabel: &no},
+			expected: JiraBranchOptions{NeedsInformationLabel: &no, RequireDescription: &yes},
+		},
+		{
+			name:     "child overrides parent same project requirement",
+			parent:   JiraBranchOptions{RequireSameProject: &yes, SameProjectIgnoreNonBugs: &yes},
+			child:    JiraBranchOptions{RequireSameProject: &no},
+			expected: JiraBranchOptions{RequireSameProject: &no, SameProjectIgnoreNonBugs: &yes},
+		},
+		{
+			name:     "child overrides parent strict project prefixes",
+			parent:   JiraBranchOptions{StrictProjectPrefixes: &yes},
+			child:    JiraBranchOptions{StrictProjectPrefixes: &no},
+			expected: JiraBranchOptions{StrictProjectPrefixes: &no},
+		},
+		{
+			name:     "child ignored clone label prefixes are merged with the parent's",
+			parent:   JiraBranchOptions{IgnoreCloneLabelPrefixes: []string{"sprint-"}, IgnoreCloneLabels: []string{"bad_label"}},
+			child:    JiraBranchOptions{IgnoreCloneLabelPrefixes: []string{"team-", "sprint-"}},
+			expected: JiraBranchOptions{IgnoreCloneLabelPrefixes: []string{"sprint-", "team-"}, IgnoreCloneLabels: []string{"bad_label"}},
+		},
+		{
+			name:     "child verified reset ignore paths are merged with the parent's",
+			parent:   JiraBranchOptions{VerifiedResetIgnorePaths: []string{"docs/*", "*.md"}},
+			child:    JiraBranchOptions{VerifiedResetIgnorePaths: []string{"*.md", "OWNERS"}},
+			expected: JiraBranchOptions{VerifiedResetIgnorePaths: []string{"*.md", "OWNERS", "docs/*"}},
+		},
+		{
+			name:     "child clone copy fields are merged with the parent's",
+			parent:   JiraBranchOptions{CloneCopyFields: []string{"customfield_1", "customfield_2"}},
+			child:    JiraBranchOptions{CloneCopyFields: []string{"customfield_2", "customfield_3"}},
+			expected: JiraBranchOptions{CloneCopyFields: []string{"customfield_1", "customfield_2", "customfield_3"}},
+		},
+		{
+			name:     "child overrides parent clone label",
+			parent:   JiraBranchOptions{AddCloneLabel: &yes},
+			child:    JiraBranchOptions{AddCloneLabel: &no},
+			expected: JiraBranchOptions{AddCloneLabel: &no},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -283,6 +705,7 @@ func TestJiraOptionsForBranch(t *testing.T) {
 	open, closed := true, false
 	yes, no := true, false
 	globalDefault, globalBranchDefault, orgDefault, orgBranchDefault, repoDefault, repoBranch := "global-default", "global-branch-default", "my-org-default", "my-org-branch-default", "my-repo-default", "my-repo-branch"
+	releaseBranch := "my-release-branch"
 	post, pre, release, notabug, new, reset := "POST", "PRE", "RELEASE_PENDING", "NOTABUG", "NEW", "RESET"
 	verifiedState, modifiedState := JiraBugState{Status: "VERIFIED"}, JiraBugState{Status: "MODIFIED"}
 	postState, preState, releaseState, notabugState, newState, resetState := JiraBugState{Status: post}, JiraBugState{Status: pre}, JiraBugState{Status: release}, JiraBugState{Status: notabug}, JiraBugState{Status: new}, JiraBugState{Status: reset}
@@ -338,6 +761,10 @@ orgs:
           "my-special-branch":
             exclude_defaults: true
             validate_by_default: false
+      release-repo:
+        branches:
+          "release-4\\.[0-9]+":
+            target_version: my-release-branch
       another-repo:
         branches:
           "*":
@@ -403,6 +830,13 @@ orgs:
 			branch:   "my-special-branch",
 			expected: JiraBranchOptions{ValidateByDefault: &no, ExcludeDefaults: &yes},
 		},
+		{
+			name:     "branch matching a regular expression on configured org and repo gets regex config on top of defaults",
+			org:      "my-org",
+			repo:     "release-repo",
+			branch:   "release-4.15",
+			expected: JiraBranchOptions{IsOpen: &open, TargetVersion: &releaseBranch, StateAfterValidation: &preState, AllowedSecurityLevels: orgAllowedSecurityLevels, StateAfterClose: &newState},
+		},
 		{
 			name:     "exclude branch on repo cascades to branch config",
 			org:      "my-org",
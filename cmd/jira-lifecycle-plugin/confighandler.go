@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// configResponse describes the effective configuration of a repo, or of a single branch of a repo
+type configResponse struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Branch string `json:"branch,omitempty"`
+	// Options are the resolved options for the branch, if one was requested
+	Options *JiraBranchOptions `json:"options,omitempty"`
+	// Sources maps the name of each of the resolved options to the layer of the configuration that supplied it
+	Sources map[string]string `json:"sources,omitempty"`
+	// Branches are the resolved options for all configured branches of the repo, if no branch was requested
+	Branches map[string]JiraBranchOptions `json:"branches,omitempty"`
+}
+
+// serveConfig reports the effective configuration for the repo named by the `org` and `repo` query
+// parameters as JSON. If the `branch` query parameter is set, only the options for that branch are
+// reported, along with the layer of the configuration that supplied each option.
+func (s *server) serveConfig(w http.ResponseWriter, r *http.Request) {
+	org, repo, branch := r.URL.Query().Get("org"), r.URL.Query().Get("repo"), r.URL.Query().Get("branch")
+	if org == "" || repo == "" {
+		http.Error(w, "the org and repo query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	config := s.config()
+	response := configResponse{Org: org, Repo: repo}
+	if branch == "" {
+		response.Branches = config.OptionsForRepo(org, repo)
+	} else {
+		options := config.OptionsForBranch(org, repo, branch)
+		sources, err := config.OptionSourcesForBranch(org, repo, branch)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response.Branch = branch
+		response.Options = &options
+		response.Sources = sources
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logrus.WithError(err).Warn("Failed to write config response.")
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+)
+
+func TestServeConfig(t *testing.T) {
+	yes, no := true, false
+	myRepoBranch, globalBranchDefault := "my-repo-branch", "global-branch-default"
+	var config Config
+	if err := yaml.Unmarshal([]byte(multiLayerConfig), &config); err != nil {
+		t.Fatalf("couldn't unmarshal config: %v", err)
+	}
+	var testCases = []struct {
+		name           string
+		query          string
+		expectedCode   int
+		expectedConfig configResponse
+	}{
+		{
+			name:         "branch configured in all layers reports options and their sources",
+			query:        "org=my-org&repo=my-repo&branch=my-repo-branch",
+			expectedCode: http.StatusOK,
+			expectedConfig: configResponse{
+				Org:    "my-org",
+				Repo:   "my-repo",
+				Branch: "my-repo-branch",
+				Options: &JiraBranchOptions{
+					IsOpen:                &no,
+					TargetVersion:         &myRepoBranch,
+					ValidStates:           &[]JiraBugState{{Status: "MODIFIED"}},
+					StateAfterValidation:  &JiraBugState{Status: "PRE"},
+					AddExternalLink:       &yes,
+					StateAfterMerge:       &JiraBugState{Status: "MODIFIED"},
+					AllowedSecurityLevels: []string{"default"},
+				},
+				Sources: map[string]string{
+					"is_open":                 "repo",
+					"target_version":          "repo",
+					"valid_states":            "repo",
+					"state_after_validation":  "org",
+					"add_external_link":       "repo",
+					"state_after_merge":       "repo",
+					"allowed_security_levels": "repo",
+				},
+			},
+		},
+		{
+			name:         "branch of an unconfigured repo reports the global options",
+			query:        "org=some-org&repo=some-repo&branch=global-branch",
+			expectedCode: http.StatusOK,
+			expectedConfig: configResponse{
+				Org:    "some-org",
+				Repo:   "some-repo",
+				Branch: "global-branch",
+				Options: &JiraBranchOptions{
+					IsOpen:        &no,
+					TargetVersion: &globalBranchDefault,
+				},
+				Sources: map[string]string{
+					"is_open":        "global",
+					"target_version": "global",
+				},
+			},
+		},
+		{
+			name:         "repo without a branch reports the options for all configured branches",
+			query:        "org=my-org&repo=my-repo",
+			expectedCode: http.StatusOK,
+			expectedConfig: configResponse{
+				Org:      "my-org",
+				Repo:     "my-repo",
+				Branches: config.OptionsForRepo("my-org", "my-repo"),
+			},
+		},
+		{
+			name:         "missing repo is rejected",
+			query:        "org=my-org",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	s := &server{config: func() *Config { return &config }}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			s.serveConfig(recorder, httptest.NewRequest(http.MethodGet, "/config?"+tc.query, nil))
+			if recorder.Code != tc.expectedCode {
+				t.Fatalf("expected status code %d, got %d: %s", tc.expectedCode, recorder.Code, recorder.Body.String())
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			var actual configResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedConfig, actual); diff != "" {
+				t.Errorf("config differs from expected: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// refreshCooldown remembers until when each pull request is in the cooldown of its last refresh, so that
+// repeated `/jira refresh` commands arriving within the configured window are not processed again. It is
+// kept in memory, so the cooldown is reset when the plugin restarts.
+type refreshCooldown struct {
+	lock    sync.Mutex
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+func newRefreshCooldown() *refreshCooldown {
+	return &refreshCooldown{expires: map[string]time.Time{}, now: time.Now}
+}
+
+// allow determines whether a refresh of the pull request is outside the window of the previous one, recording
+// it if it is. Throttled refreshes do not extend the window. As the window may differ between branches, each
+// refresh is remembered until its own window expires, so that the pull requests remembered do not grow without
+// bound.
+func (c *refreshCooldown) allow(org, repo string, number int, window time.Duration) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	for key, expires := range c.expires {
+		if !now.Before(expires) {
+			delete(c.expires, key)
+		}
+	}
+	key := fmt.Sprintf("%s/%s#%d", org, repo, number)
+	if _, ok := c.expires[key]; ok {
+		return false
+	}
+	c.expires[key] = now.Add(window)
+	return true
+}
+
+// throttleRefresh determines whether the `/jira refresh` command arrived within the cooldown of the previous
+// one on the pull request and should be skipped. Skipped commands are reacted to, if a reaction is configured.
+func (s *server) throttleRefresh(ghc githubClient, e event, options JiraBranchOptions, log *logrus.Entry) bool {
+	if !e.refresh || s.refreshCooldown == nil || options.RefreshCooldown == nil {
+		return false
+	}
+	if s.refreshCooldown.allow(e.org, e.repo, e.number, options.RefreshCooldown.Duration) {
+		return false
+	}
+	log.Debug("Skipping refresh within the cooldown of the previous one.")
+	if options.RefreshCooldownReaction != nil && *options.RefreshCooldownReaction != "" {
+		if err := ghc.CreateCommentReaction(e.org, e.repo, e.commentID, *options.RefreshCooldownReaction); err != nil {
+			log.WithError(err).Warn("Failed to react to the throttled refresh.")
+		}
+	}
+	return true
+}
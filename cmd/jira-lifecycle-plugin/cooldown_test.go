@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+)
+
+func TestRefreshCooldown(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	now := start
+	cooldown := newRefreshCooldown()
+	cooldown.now = func() time.Time { return now }
+
+	if !cooldown.allow("org", "repo", 1, time.Minute) {
+		t.Error("expected the first refresh to be allowed")
+	}
+	now = start.Add(10 * time.Second)
+	if cooldown.allow("org", "repo", 1, time.Minute) {
+		t.Error("expected a refresh within the window to be throttled")
+	}
+	if !cooldown.allow("org", "repo", 2, time.Minute) {
+		t.Error("expected a refresh of another pull request to be allowed")
+	}
+	now = start.Add(time.Minute)
+	if !cooldown.allow("org", "repo", 1, time.Minute) {
+		t.Error("expected a refresh after the window to be allowed, as throttled refreshes do not extend it")
+	}
+	now = start.Add(2 * time.Minute)
+	if !cooldown.allow("org", "repo", 3, time.Minute) {
+		t.Error("expected a refresh of another pull request to be allowed")
+	}
+	if diff := cmp.Diff(map[string]time.Time{"org/repo#3": now.Add(time.Minute)}, cooldown.expires); diff != "" {
+		t.Errorf("expected refreshes outside the window to be forgotten: %s", diff)
+	}
+	// a refresh with a longer window is not forgotten when a refresh with a shorter one is recorded
+	if !cooldown.allow("org", "repo", 4, time.Hour) {
+		t.Error("expected a refresh of another pull request to be allowed")
+	}
+	now = start.Add(10 * time.Minute)
+	if !cooldown.allow("org", "repo", 5, time.Minute) {
+		t.Error("expected a refresh of another pull request to be allowed")
+	}
+	if cooldown.allow("org", "repo", 4, time.Hour) {
+		t.Error("expected a refresh within its longer window to be throttled")
+	}
+}
+
+func TestThrottleRefresh(t *testing.T) {
+	eyes := "eyes"
+	var testCases = []struct {
+		name              string
+		options           JiraBranchOptions
+		refresh           bool
+		expectedThrottled []bool
+		expectedReactions []string
+	}{
+		{
+			name:              "second refresh within the cooldown is throttled and reacted to",
+			options:           JiraBranchOptions{RefreshCooldown: &metav1.Duration{Duration: time.Minute}, RefreshCooldownReaction: &eyes},
+			refresh:           true,
+			expectedThrottled: []bool{false, true},
+			expectedReactions: []string{"org/repo#5:eyes"},
+		},
+		{
+			name:              "second refresh within the cooldown is throttled silently without a reaction",
+			options:           JiraBranchOptions{RefreshCooldown: &metav1.Duration{Duration: time.Minute}},
+			refresh:           true,
+			expectedThrottled: []bool{false, true},
+		},
+		{
+			name:              "refreshes are not throttled without a cooldown",
+			refresh:           true,
+			expectedThrottled: []bool{false, false},
+		},
+		{
+			name:              "other commands are not throttled",
+			options:           JiraBranchOptions{RefreshCooldown: &metav1.Duration{Duration: time.Minute}, RefreshCooldownReaction: &eyes},
+			expectedThrottled: []bool{false, false},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			s := &server{refreshCooldown: newRefreshCooldown()}
+			e := event{org: "org", repo: "repo", number: 1, refresh: tc.refresh, commentID: 5}
+			var throttled []bool
+			for range tc.expectedThrottled {
+				throttled = append(throttled, s.throttleRefresh(fakeGHClient{gc}, e, tc.options, logrus.WithField("testCase", tc.name)))
+			}
+			if diff := cmp.Diff(tc.expectedThrottled, throttled); diff != "" {
+				t.Errorf("throttled refreshes differ from expected: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedReactions, gc.CommentReactionsAdded); diff != "" {
+				t.Errorf("reactions differ from expected: %s", diff)
+			}
+		})
+	}
+}
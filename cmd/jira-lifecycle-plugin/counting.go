@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/andygrunwald/go-jira"
+	"sigs.k8s.io/prow/pkg/github"
+	jiraclient "sigs.k8s.io/prow/pkg/jira"
+)
+
+// callCounts tracks the number of API calls made while handling a single event. The counts are
+// atomic as some calls, like fetching linked pull requests on merge, are made concurrently.
+type callCounts struct {
+	jiraGetIssue atomic.Int64
+	jiraUpdate   atomic.Int64
+	github       atomic.Int64
+}
+
+// countingJiraClient wraps a Jira client, counting the calls made to fetch and update issues.
+type countingJiraClient struct {
+	jiraclient.Client
+	counts *callCounts
+}
+
+func (c *countingJiraClient) GetIssue(id string) (*jira.Issue, error) {
+	c.counts.jiraGetIssue.Add(1)
+	return c.Client.GetIssue(id)
+}
+
+func (c *countingJiraClient) SearchWithContext(ctx context.Context, jql string, options *jira.SearchOptions) ([]jira.Issue, *jira.Response, error) {
+	c.counts.jiraGetIssue.Add(1)
+	return c.Client.SearchWithContext(ctx, jql, options)
+}
+
+func (c *countingJiraClient) UpdateIssue(issue *jira.Issue) (*jira.Issue, error) {
+	c.counts.jiraUpdate.Add(1)
+	return c.Client.UpdateIssue(issue)
+}
+
+func (c *countingJiraClient) UpdateStatus(issueID, statusName string) error {
+	c.counts.jiraUpdate.Add(1)
+	return c.Client.UpdateStatus(issueID, statusName)
+}
+
+// countingGitHubClient wraps a GitHub client, counting all calls made through it.
+type countingGitHubClient struct {
+	ghc    githubClient
+	counts *callCounts
+}
+
+func (c *countingGitHubClient) IsCollaborator(owner, repo, login string) (bool, error) {
+	c.counts.github.Add(1)
+	return c.ghc.IsCollaborator(owner, repo, login)
+}
+
+func (c *countingGitHubClient) EditComment(org, repo string, id int, comment string) error {
+	c.counts.github.Add(1)
+	return c.ghc.EditComment(org, repo, id, comment)
+}
+
+func (c *countingGitHubClient) DeleteComment(org, repo string, id int) error {
+	c.counts.github.Add(1)
+	return c.ghc.DeleteComment(org, repo, id)
+}
+
+func (c *countingGitHubClient) CreateCommentReaction(org, repo string, id int, reaction string) error {
+	c.counts.github.Add(1)
+	return c.ghc.CreateCommentReaction(org, repo, id, reaction)
+}
+
+func (c *countingGitHubClient) GetIssue(org, repo string, number int) (*github.Issue, error) {
+	c.counts.github.Add(1)
+	return c.ghc.GetIssue(org, repo, number)
+}
+
+func (c *countingGitHubClient) EditIssue(org, repo string, number int, issue *github.Issue) (*github.Issue, error) {
+	c.counts.github.Add(1)
+	return c.ghc.EditIssue(org, repo, number, issue)
+}
+
+func (c *countingGitHubClient) ListIssueComments(org, repo string, number int) ([]github.IssueComment, error) {
+	c.counts.github.Add(1)
+	return c.ghc.ListIssueComments(org, repo, number)
+}
+
+func (c *countingGitHubClient) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	c.counts.github.Add(1)
+	return c.ghc.GetPullRequest(org, repo, number)
+}
+
+func (c *countingGitHubClient) GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error) {
+	c.counts.github.Add(1)
+	return c.ghc.GetPullRequestChanges(org, repo, number)
+}
+
+func (c *countingGitHubClient) ListPullRequestCommits(org, repo string, number int) ([]github.RepositoryCommit, error) {
+	c.counts.github.Add(1)
+	return c.ghc.ListPullRequestCommits(org, repo, number)
+}
+
+func (c *countingGitHubClient) GetSingleCommit(org, repo, SHA string) (github.RepositoryCommit, error) {
+	c.counts.github.Add(1)
+	return c.ghc.GetSingleCommit(org, repo, SHA)
+}
+
+func (c *countingGitHubClient) GetPullRequests(org, repo string) ([]github.PullRequest, error) {
+	c.counts.github.Add(1)
+	return c.ghc.GetPullRequests(org, repo)
+}
+
+func (c *countingGitHubClient) CreateComment(owner, repo string, number int, comment string) error {
+	c.counts.github.Add(1)
+	return c.ghc.CreateComment(owner, repo, number, comment)
+}
+
+func (c *countingGitHubClient) GetIssueLabels(org, repo string, number int) ([]github.Label, error) {
+	c.counts.github.Add(1)
+	return c.ghc.GetIssueLabels(org, repo, number)
+}
+
+func (c *countingGitHubClient) AddLabel(owner, repo string, number int, label string) error {
+	c.counts.github.Add(1)
+	return c.ghc.AddLabel(owner, repo, number, label)
+}
+
+func (c *countingGitHubClient) RemoveLabel(owner, repo string, number int, label string) error {
+	c.counts.github.Add(1)
+	return c.ghc.RemoveLabel(owner, repo, number, label)
+}
+
+func (c *countingGitHubClient) WasLabelAddedByHuman(org, repo string, num int, label string) (bool, error) {
+	c.counts.github.Add(1)
+	return c.ghc.WasLabelAddedByHuman(org, repo, num, label)
+}
+
+func (c *countingGitHubClient) CreateStatus(org, repo, ref string, s github.Status) error {
+	c.counts.github.Add(1)
+	return c.ghc.CreateStatus(org, repo, ref, s)
+}
+
+func (c *countingGitHubClient) QueryWithGitHubAppsSupport(ctx context.Context, q any, vars map[string]any, org string) error {
+	c.counts.github.Add(1)
+	return c.ghc.QueryWithGitHubAppsSupport(ctx, q, vars, org)
+}
+
+// BotUserChecker is not counted, as the bot identity is cached by the client after the first lookup.
+func (c *countingGitHubClient) BotUserChecker() (func(candidate string) bool, error) {
+	return c.ghc.BotUserChecker()
+}
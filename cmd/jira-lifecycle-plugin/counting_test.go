@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+)
+
+func TestHandleMetricsUpload(t *testing.T) {
+	modified := JiraBugState{Status: "MODIFIED"}
+	yes := true
+	var testCases = []struct {
+		name        string
+		nilBigQuery bool
+		options     JiraBranchOptions
+		expected    []HandleMetrics
+	}{
+		{
+			name:    "valid bug records Jira and GitHub calls",
+			options: JiraBranchOptions{StateAfterValidation: &modified},
+			expected: []HandleMetrics{{
+				Org:               "org",
+				Repo:              "repo",
+				PRNum:             1,
+				Branch:            "branch",
+				EventType:         "validation",
+				JiraGetIssueCalls: 2,
+				JiraUpdateCalls:   1,
+				GitHubCalls:       5,
+			}},
+		},
+		{
+			name:    "bugs fetched to compare target versions are not fetched again",
+			options: JiraBranchOptions{StateAfterValidation: &modified, RequireConsistentTargetVersions: &yes},
+			expected: []HandleMetrics{{
+				Org:               "org",
+				Repo:              "repo",
+				PRNum:             1,
+				Branch:            "branch",
+				EventType:         "validation",
+				JiraGetIssueCalls: 2,
+				JiraUpdateCalls:   1,
+				GitHubCalls:       5,
+			}},
+		},
+		{
+			name:        "nil inserter does not record metrics",
+			nilBigQuery: true,
+			options:     JiraBranchOptions{StateAfterValidation: &modified},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jc := &fakejira.FakeClient{
+				Issues:      []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+				Transitions: []jira.Transition{{ID: "1", Name: "MODIFIED", To: jira.Status{Name: "MODIFIED"}}},
+			}
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			e := event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This PR fixes OCPBUGS-123", title: "OCPBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+			}
+			var inserter BigQueryInserter
+			fakeInserter := fakeBigQueryInserter{}
+			if !tc.nilBigQuery {
+				inserter = &fakeInserter
+			}
+			if err := handle(jc, fakeGHClient{gc}, inserter, nil, nil, tc.options, logrus.WithField("testCase", tc.name), e, sets.New("org/repo"), defaultBugProjects, false); err != nil {
+				t.Fatalf("handle failed: %v", err)
+			}
+			for i := range fakeInserter.insertedMetrics {
+				if fakeInserter.insertedMetrics[i].Duration <= 0 {
+					t.Errorf("expected a positive duration, got %v", fakeInserter.insertedMetrics[i].Duration)
+				}
+				// the duration is not deterministic, so it is only checked for being set
+				fakeInserter.insertedMetrics[i].Duration = 0
+			}
+			if diff := cmp.Diff(tc.expected, fakeInserter.insertedMetrics); diff != "" {
+				t.Errorf("incorrect handle metrics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/prow/pkg/github"
+	jiraclient "sigs.k8s.io/prow/pkg/jira"
+)
+
+// dryRunJiraClient wraps a Jira client, logging all mutating calls instead of executing them.
+// Read-only calls are passed through to the wrapped client.
+type dryRunJiraClient struct {
+	jiraclient.Client
+	log *logrus.Entry
+}
+
+func newDryRunJiraClient(jc jiraclient.Client, log *logrus.Entry) jiraclient.Client {
+	return &dryRunJiraClient{Client: jc, log: log.WithField("dry-run", true)}
+}
+
+func (d *dryRunJiraClient) UpdateIssue(issue *jira.Issue) (*jira.Issue, error) {
+	d.log.WithField("issue", issue.Key).Infof("Would update issue fields: %+v", issue.Fields)
+	return issue, nil
+}
+
+func (d *dryRunJiraClient) CreateIssue(issue *jira.Issue) (*jira.Issue, error) {
+	d.log.Infof("Would create issue: %+v", issue.Fields)
+	return nil, errors.New("issues cannot be created in dry-run mode")
+}
+
+func (d *dryRunJiraClient) CloneIssue(issue *jira.Issue) (*jira.Issue, error) {
+	d.log.WithField("issue", issue.Key).Info("Would clone issue")
+	return nil, errors.New("issues cannot be cloned in dry-run mode")
+}
+
+func (d *dryRunJiraClient) CreateIssueLink(link *jira.IssueLink) error {
+	d.log.Infof("Would create %q issue link", link.Type.Name)
+	return nil
+}
+
+func (d *dryRunJiraClient) DoTransition(issueID, transitionID string) error {
+	d.log.WithField("issue", issueID).Infof("Would perform transition %s", transitionID)
+	return nil
+}
+
+func (d *dryRunJiraClient) UpdateStatus(issueID, statusName string) error {
+	d.log.WithField("issue", issueID).Infof("Would update status to %s", statusName)
+	return nil
+}
+
+func (d *dryRunJiraClient) AddRemoteLink(id string, link *jira.RemoteLink) (*jira.RemoteLink, error) {
+	d.log.WithField("issue", id).Infof("Would add remote link to %s", link.Object.URL)
+	return link, nil
+}
+
+func (d *dryRunJiraClient) UpdateRemoteLink(id string, link *jira.RemoteLink) error {
+	d.log.WithField("issue", id).Infof("Would update remote link to %s", link.Object.URL)
+	return nil
+}
+
+func (d *dryRunJiraClient) DeleteLink(id string) error {
+	d.log.Infof("Would delete issue link %s", id)
+	return nil
+}
+
+func (d *dryRunJiraClient) DeleteRemoteLink(issueID string, linkID int) error {
+	d.log.WithField("issue", issueID).Infof("Would delete remote link %d", linkID)
+	return nil
+}
+
+func (d *dryRunJiraClient) DeleteRemoteLinkViaURL(issueID, url string) (bool, error) {
+	d.log.WithField("issue", issueID).Infof("Would delete remote link to %s", url)
+	return true, nil
+}
+
+func (d *dryRunJiraClient) AddComment(issueID string, comment *jira.Comment) (*jira.Comment, error) {
+	d.log.WithField("issue", issueID).Infof("Would add comment: %s", comment.Body)
+	return comment, nil
+}
+
+// dryRunGitHubClient wraps a GitHub client, logging all mutating calls instead of executing them.
+// Read-only calls are passed through to the wrapped client.
+type dryRunGitHubClient struct {
+	githubClient
+	log *logrus.Entry
+}
+
+func newDryRunGitHubClient(ghc githubClient, log *logrus.Entry) githubClient {
+	return &dryRunGitHubClient{githubClient: ghc, log: log.WithField("dry-run", true)}
+}
+
+func (d *dryRunGitHubClient) EditComment(org, repo string, id int, comment string) error {
+	d.log.Infof("Would edit comment %d on %s/%s: %s", id, org, repo, comment)
+	return nil
+}
+
+func (d *dryRunGitHubClient) DeleteComment(org, repo string, id int) error {
+	d.log.Infof("Would delete comment %d on %s/%s", id, org, repo)
+	return nil
+}
+
+func (d *dryRunGitHubClient) CreateCommentReaction(org, repo string, id int, reaction string) error {
+	d.log.Infof("Would react with %s to comment %d on %s/%s", reaction, id, org, repo)
+	return nil
+}
+
+func (d *dryRunGitHubClient) EditIssue(org, repo string, number int, issue *github.Issue) (*github.Issue, error) {
+	d.log.Infof("Would edit %s/%s#%d: %+v", org, repo, number, issue)
+	return issue, nil
+}
+
+func (d *dryRunGitHubClient) CreateComment(owner, repo string, number int, comment string) error {
+	d.log.Infof("Would comment on %s/%s#%d: %s", owner, repo, number, comment)
+	return nil
+}
+
+func (d *dryRunGitHubClient) AddLabel(owner, repo string, number int, label string) error {
+	d.log.Infof("Would add label %s to %s/%s#%d", label, owner, repo, number)
+	return nil
+}
+
+func (d *dryRunGitHubClient) CreateStatus(org, repo, ref string, s github.Status) error {
+	d.log.Infof("Would set status %s to %s on %s/%s@%s: %s", s.Context, s.State, org, repo, ref, s.Description)
+	return nil
+}
+
+func (d *dryRunGitHubClient) RemoveLabel(owner, repo string, number int, label string) error {
+	d.log.Infof("Would remove label %s from %s/%s#%d", label, owner, repo, number)
+	return nil
+}
+
+// dryRunSlackNotifier logs Slack messages instead of posting them.
+type dryRunSlackNotifier struct {
+	log *logrus.Entry
+}
+
+func newDryRunSlackNotifier(log *logrus.Entry) SlackNotifier {
+	return &dryRunSlackNotifier{log: log.WithField("dry-run", true)}
+}
+
+func (d *dryRunSlackNotifier) WriteMessage(text, channel string) error {
+	d.log.Infof("Would post Slack message to %s: %s", channel, text)
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// pullRequestHeads remembers the head commit last seen on each open pull request, so that the commits added by
+// a `synchronize` event can be told apart from the ones already on the pull request. GitHub sends the previous
+// head in the webhook, but the event server drops it. It is kept in memory, so the previous head is not known
+// for the first push to a pull request after the plugin restarts.
+type pullRequestHeads struct {
+	lock  sync.Mutex
+	heads map[string]string
+}
+
+func newPullRequestHeads() *pullRequestHeads {
+	return &pullRequestHeads{heads: map[string]string{}}
+}
+
+// record remembers the head of the pull request and returns the one seen before it, if any. Closed pull requests
+// are forgotten, so that the pull requests remembered do not grow without bound.
+func (h *pullRequestHeads) record(org, repo string, number int, sha string, closed bool) string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	key := fmt.Sprintf("%s/%s#%d", org, repo, number)
+	previous := h.heads[key]
+	if closed || sha == "" {
+		delete(h.heads, key)
+	} else {
+		h.heads[key] = sha
+	}
+	return previous
+}
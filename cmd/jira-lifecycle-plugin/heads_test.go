@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestPullRequestHeads(t *testing.T) {
+	heads := newPullRequestHeads()
+	if previous := heads.record("org", "repo", 1, "abc", false); previous != "" {
+		t.Errorf("expected no previous head for a new pull request, got %q", previous)
+	}
+	if previous := heads.record("org", "repo", 1, "def", false); previous != "abc" {
+		t.Errorf("expected the previous head to be abc, got %q", previous)
+	}
+	if previous := heads.record("org", "repo", 2, "ghi", false); previous != "" {
+		t.Errorf("expected no previous head for another pull request, got %q", previous)
+	}
+	if previous := heads.record("org", "repo", 1, "def", true); previous != "def" {
+		t.Errorf("expected the previous head to be def, got %q", previous)
+	}
+	if _, ok := heads.heads["org/repo#1"]; ok {
+		t.Error("expected the closed pull request to be forgotten")
+	}
+}
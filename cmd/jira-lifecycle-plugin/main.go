@@ -20,7 +20,9 @@ import (
 	"sigs.k8s.io/prow/pkg/githubeventserver"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
 	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/slack"
 	"sigs.k8s.io/yaml"
 )
 
@@ -35,14 +37,22 @@ type options struct {
 	bigqueryProjectID  string
 	bigqueryDatasetID  string
 
+	slackTokenFile string
+
 	config *Config
 
 	prowConfig               configflagutil.ConfigOptions
 	githubEventServerOptions githubeventserver.Options
 	github                   prowflagutil.GitHubOptions
 	jira                     prowflagutil.JiraOptions
+	instrumentationOptions   prowflagutil.InstrumentationOptions
 
 	validateConfig string
+
+	dryRun bool
+
+	jiraRetryAttempts int
+	jiraRetryInterval time.Duration
 }
 
 func gatherOptions() options {
@@ -54,16 +64,22 @@ func gatherOptions() options {
 	fs.StringVar(&o.configPath, "config-path", "", "Path to jira lifecycle configuration.")
 	fs.StringVar(&o.validateConfig, "validate-config", "", "Validate config at specified directory and exit without running operator")
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "", "Path to the file containing the GitHub HMAC secret.")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "Log the actions the plugin would take instead of mutating Jira and GitHub.")
+	fs.IntVar(&o.jiraRetryAttempts, "jira-retry-attempts", defaultJiraRetryAttempts, "Number of attempts made for Jira calls that fail with transient errors.")
+	fs.DurationVar(&o.jiraRetryInterval, "jira-retry-interval", defaultJiraRetryInterval, "Time to wait before retrying a Jira call that failed with a transient error. Doubles after each retry.")
 
 	fs.BoolVar(&o.bigqueryEnable, "enable-bigquery", false, "Enable Big Query verification data uploading.")
 	fs.StringVar(&o.bigquerySecretFile, "bigquery-secret-file", "", "Path to credentials file for BigQuery service account.")
 	fs.StringVar(&o.bigqueryProjectID, "bigquery-project-id", "", "Name of BigQuery project to operate in.")
 	fs.StringVar(&o.bigqueryDatasetID, "bigquery-dataset-id", "", "Name of BigQuery dataset to operate on.")
 
+	fs.StringVar(&o.slackTokenFile, "slack-token-file", "", "Path to the file containing the Slack token used to post notifications to the channels configured per repo.")
+
 	o.github.AddFlags(fs)
 	o.githubEventServerOptions.Bind(fs)
 
 	o.jira.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
 
 	// change config flag name so it doesn't conflict with the plugin's config flah name
 	o.prowConfig.ConfigPathFlagName = "prow-config-path"
@@ -93,12 +109,19 @@ func (o *options) Validate() error {
 	if err := yaml.Unmarshal(bytes, &config); err != nil {
 		return fmt.Errorf("couldn't unmarshal configuration: %w", err)
 	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
 	o.config = &config
 
 	if err := o.githubEventServerOptions.DefaultAndValidate(); err != nil {
 		return err
 	}
 
+	if o.jiraRetryAttempts < 1 {
+		return errors.New("--jira-retry-attempts must be at least 1")
+	}
+
 	if o.bigqueryEnable &&
 		(o.bigquerySecretFile == "" || o.bigqueryProjectID == "" || o.bigqueryDatasetID == "") {
 		return errors.New("All BigQuery flags must be set to enable Big Query uploading.")
@@ -122,6 +145,9 @@ func (o *options) getConfigWatchAndUpdate() (func(ctx context.Context), error) {
 		if err := yaml.Unmarshal(bytes, &c); err != nil {
 			return fmt.Errorf("couldn't unmarshal configuration: %w", err)
 		}
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
 
 		o.mut.Lock()
 		defer o.mut.Unlock()
@@ -180,6 +206,9 @@ func main() {
 		tokens = append(tokens, o.github.AppPrivateKeyPath)
 	}
 	tokens = append(tokens, o.webhookSecretFile)
+	if o.slackTokenFile != "" {
+		tokens = append(tokens, o.slackTokenFile)
+	}
 
 	if err := secret.Add(tokens...); err != nil {
 		logrus.WithError(err).Fatal("Error starting secrets agent.")
@@ -204,7 +233,17 @@ func main() {
 		if err != nil {
 			logrus.WithError(err).Fatal("Failed to create Big Query client")
 		}
-		bigqueryInserter = bigqueryClient.Dataset(o.bigqueryDatasetID).Table(bigqueryTableName).Inserter()
+		dataset := bigqueryClient.Dataset(o.bigqueryDatasetID)
+		bigqueryInserter = &tableInserter{
+			verified:      dataset.Table(bigqueryTableName).Inserter(),
+			handleMetrics: dataset.Table(bigqueryHandleMetricsTableName).Inserter(),
+			timeInState:   dataset.Table(bigqueryTimeInStateTableName).Inserter(),
+		}
+	}
+
+	var slackNotifier SlackNotifier
+	if o.slackTokenFile != "" {
+		slackNotifier = slack.NewClient(secret.GetTokenGenerator(o.slackTokenFile))
 	}
 
 	serv := &server{
@@ -214,18 +253,28 @@ func main() {
 			return o.config
 		},
 		ghc:             githubClient.WithFields(logger.Data).ForPlugin(PluginName),
-		jc:              jiraClient.WithFields(logger.Data).ForPlugin(PluginName),
+		jc:              newRetryingJiraClient(jiraClient.WithFields(logger.Data).ForPlugin(PluginName), o.jiraRetryAttempts, o.jiraRetryInterval, logger),
 		prowConfigAgent: configAgent,
 
 		bigqueryInserter: bigqueryInserter,
+		slackNotifier:    slackNotifier,
+
+		dryRun: o.dryRun,
+
+		refreshCooldown:  newRefreshCooldown(),
+		pullRequestHeads: newPullRequestHeads(),
 	}
 
 	eventServer := githubeventserver.New(o.githubEventServerOptions, secret.GetTokenGenerator(o.webhookSecretFile), logger)
 	eventServer.RegisterHandleIssueCommentEvent(serv.handleIssueComment)
 	eventServer.RegisterHandlePullRequestEvent(serv.handlePullRequest)
+	eventServer.RegisterReviewEventHandler(serv.handlePullRequestReview)
 	eventServer.RegisterHelpProvider(serv.helpProvider, logger)
+	eventServer.RegisterCustomFuncHandle("/config", serv.serveConfig)
+
+	metrics.ExposeMetrics(PluginName, configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
 
-	health := pjutil.NewHealth()
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
 	health.ServeReady()
 
 	interrupts.ListenAndServe(eventServer, time.Second*30)
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	validationResultValid   = "valid"
+	validationResultInvalid = "invalid"
+)
+
+var (
+	// bugValidationsCounter counts the number of times a bug was validated, by result
+	bugValidationsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jira_lifecycle_bug_validations_total",
+		Help: "Number of Jira bug validations performed by the jira-lifecycle plugin, by validation result.",
+	}, []string{"org", "repo", "result"})
+	// stateTransitionsCounter counts the number of times a bug was moved to a new status
+	stateTransitionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jira_lifecycle_state_transitions_total",
+		Help: "Number of Jira bug state transitions performed by the jira-lifecycle plugin, by target status.",
+	}, []string{"org", "repo", "status"})
+	// commentsCounter counts the number of comments posted on pull requests, by the type of event that caused the comment
+	commentsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jira_lifecycle_comments_total",
+		Help: "Number of GitHub comments posted by the jira-lifecycle plugin, by comment type.",
+	}, []string{"org", "repo", "type"})
+)
+
+func init() {
+	prometheus.MustRegister(bugValidationsCounter)
+	prometheus.MustRegister(stateTransitionsCounter)
+	prometheus.MustRegister(commentsCounter)
+}
+
+func recordValidation(e event, valid bool) {
+	result := validationResultInvalid
+	if valid {
+		result = validationResultValid
+	}
+	bugValidationsCounter.WithLabelValues(e.org, e.repo, result).Inc()
+}
+
+func recordTransition(e event, status string) {
+	stateTransitionsCounter.WithLabelValues(e.org, e.repo, status).Inc()
+}
+
+func recordComment(e event) {
+	commentsCounter.WithLabelValues(e.org, e.repo, e.commentType()).Inc()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+)
+
+func TestHandleMetrics(t *testing.T) {
+	modified := JiraBugState{Status: "MODIFIED"}
+	var testCases = []struct {
+		name                string
+		repo                string
+		issue               jira.Issue
+		options             JiraBranchOptions
+		expectedValid       float64
+		expectedInvalid     float64
+		expectedTransitions float64
+		expectedComments    float64
+	}{
+		{
+			name:                "valid bug increments valid validations, transitions, and comments",
+			repo:                "metrics-valid",
+			issue:               jira.Issue{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}},
+			options:             JiraBranchOptions{StateAfterValidation: &modified},
+			expectedValid:       1,
+			expectedTransitions: 1,
+			expectedComments:    1,
+		},
+		{
+			name:             "invalid bug increments invalid validations and comments",
+			repo:             "metrics-invalid",
+			issue:            jira.Issue{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}},
+			options:          JiraBranchOptions{ValidStates: &[]JiraBugState{{Status: "POST"}}},
+			expectedInvalid:  1,
+			expectedComments: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jc := &fakejira.FakeClient{
+				Issues:      []*jira.Issue{&tc.issue},
+				Transitions: []jira.Transition{{ID: "1", Name: "MODIFIED", To: jira.Status{Name: "MODIFIED"}}},
+			}
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			e := event{
+				org: "org", repo: tc.repo, baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This PR fixes OCPBUGS-123", title: "OCPBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+			}
+			if err := handle(jc, fakeGHClient{gc}, &fakeBigQueryInserter{}, nil, nil, tc.options, logrus.WithField("testCase", tc.name), e, sets.New("org/"+tc.repo), defaultBugProjects, false); err != nil {
+				t.Fatalf("handle failed: %v", err)
+			}
+			if actual := testutil.ToFloat64(bugValidationsCounter.WithLabelValues("org", tc.repo, validationResultValid)); actual != tc.expectedValid {
+				t.Errorf("expected %v valid validations, got %v", tc.expectedValid, actual)
+			}
+			if actual := testutil.ToFloat64(bugValidationsCounter.WithLabelValues("org", tc.repo, validationResultInvalid)); actual != tc.expectedInvalid {
+				t.Errorf("expected %v invalid validations, got %v", tc.expectedInvalid, actual)
+			}
+			if actual := testutil.ToFloat64(stateTransitionsCounter.WithLabelValues("org", tc.repo, "MODIFIED")); actual != tc.expectedTransitions {
+				t.Errorf("expected %v transitions, got %v", tc.expectedTransitions, actual)
+			}
+			if actual := testutil.ToFloat64(commentsCounter.WithLabelValues("org", tc.repo, "validation")); actual != tc.expectedComments {
+				t.Errorf("expected %v comments, got %v", tc.expectedComments, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	jiraclient "sigs.k8s.io/prow/pkg/jira"
+)
+
+const (
+	defaultJiraRetryAttempts = 3
+	defaultJiraRetryInterval = time.Second
+)
+
+// retryingJiraClient wraps a Jira client, retrying the calls used to fetch, update and transition issues
+// with an exponential backoff when they fail with transient errors.
+type retryingJiraClient struct {
+	jiraclient.Client
+	backoff wait.Backoff
+	log     *logrus.Entry
+}
+
+// newRetryingJiraClient returns a client that makes up to the given number of attempts for each call,
+// waiting for the given interval after the first failure and doubling the wait after each further one.
+func newRetryingJiraClient(jc jiraclient.Client, attempts int, interval time.Duration, log *logrus.Entry) jiraclient.Client {
+	return &retryingJiraClient{
+		Client:  jc,
+		backoff: wait.Backoff{Duration: interval, Factor: 2, Steps: attempts},
+		log:     log,
+	}
+}
+
+// isTransientJiraError determines whether an error returned by Jira may succeed when retried. Server-side
+// errors, rate limiting and network errors are transient, while all other errors (e.g. a missing issue or
+// a rejected update) are permanent.
+func isTransientJiraError(err error) bool {
+	if code := jiraclient.JiraErrorStatusCode(err); code != -1 {
+		return code >= http.StatusInternalServerError || code == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// jiraPermissionError is returned by the retrying client when Jira rejects a call because the bot is not
+// authorized to access the issue
+type jiraPermissionError struct {
+	error
+}
+
+func (e jiraPermissionError) Unwrap() error {
+	return e.error
+}
+
+// jiraTransientError is returned by the retrying client when a call still fails with a transient error
+// once all attempts are exhausted
+type jiraTransientError struct {
+	error
+}
+
+func (e jiraTransientError) Unwrap() error {
+	return e.error
+}
+
+// classifyJiraError wraps an error returned by Jira in a typed error, so that callers can tailor how it is
+// reported: missing issues are reported as jiraclient.NotFoundError, rejected credentials or permissions as
+// jiraPermissionError and transient failures as jiraTransientError. All other errors are returned unchanged.
+func classifyJiraError(err error) error {
+	if err == nil || jiraclient.IsNotFound(err) {
+		return err
+	}
+	switch code := jiraclient.JiraErrorStatusCode(err); {
+	case code == http.StatusNotFound:
+		return jiraclient.NewNotFoundError(err)
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return jiraPermissionError{err}
+	case isTransientJiraError(err):
+		return jiraTransientError{err}
+	}
+	return err
+}
+
+// retry calls the provided function until it succeeds, fails with a permanent error, or runs out of
+// attempts, returning the error from the last call
+func (r *retryingJiraClient) retry(call func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(r.backoff, func() (bool, error) {
+		lastErr = call()
+		if lastErr == nil {
+			return true, nil
+		}
+		if !isTransientJiraError(lastErr) {
+			return false, lastErr
+		}
+		r.log.WithError(lastErr).Info("Retrying Jira call after transient error.")
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return classifyJiraError(lastErr)
+	}
+	return classifyJiraError(err)
+}
+
+func (r *retryingJiraClient) GetIssue(id string) (*jira.Issue, error) {
+	var issue *jira.Issue
+	err := r.retry(func() error {
+		var err error
+		issue, err = r.Client.GetIssue(id)
+		return err
+	})
+	return issue, err
+}
+
+func (r *retryingJiraClient) SearchWithContext(ctx context.Context, jql string, options *jira.SearchOptions) ([]jira.Issue, *jira.Response, error) {
+	var issues []jira.Issue
+	var response *jira.Response
+	err := r.retry(func() error {
+		var err error
+		issues, response, err = r.Client.SearchWithContext(ctx, jql, options)
+		return err
+	})
+	return issues, response, err
+}
+
+func (r *retryingJiraClient) UpdateIssue(issue *jira.Issue) (*jira.Issue, error) {
+	var updated *jira.Issue
+	err := r.retry(func() error {
+		var err error
+		updated, err = r.Client.UpdateIssue(issue)
+		return err
+	})
+	return updated, err
+}
+
+func (r *retryingJiraClient) DoTransition(issueID, transitionID string) error {
+	return r.retry(func() error {
+		return r.Client.DoTransition(issueID, transitionID)
+	})
+}
+
+func (r *retryingJiraClient) UpdateStatus(issueID, statusName string) error {
+	return r.retry(func() error {
+		return r.Client.UpdateStatus(issueID, statusName)
+	})
+}
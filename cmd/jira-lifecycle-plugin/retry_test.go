@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	jiraclient "sigs.k8s.io/prow/pkg/jira"
+	"sigs.k8s.io/prow/pkg/jira/fakejira"
+)
+
+// flakyJiraClient fails the first calls to fetch an issue with the provided errors before passing through
+type flakyJiraClient struct {
+	*fakejira.FakeClient
+	errs  []error
+	calls int
+}
+
+func (f *flakyJiraClient) GetIssue(id string) (*jira.Issue, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return nil, f.errs[f.calls-1]
+	}
+	return f.FakeClient.GetIssue(id)
+}
+
+func TestRetryingJiraClient(t *testing.T) {
+	unavailable := &jiraclient.JiraError{StatusCode: http.StatusServiceUnavailable, OriginalError: errors.New("service unavailable")}
+	badRequest := &jiraclient.JiraError{StatusCode: http.StatusBadRequest, OriginalError: errors.New("bad request")}
+	forbidden := &jiraclient.JiraError{StatusCode: http.StatusForbidden, OriginalError: errors.New("forbidden")}
+	notFound := &jiraclient.JiraError{StatusCode: http.StatusNotFound, OriginalError: errors.New("not found")}
+	var testCases = []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		expectedError string
+	}{
+		{
+			name: "transient errors are retried until the call succeeds",
+			errs: []error{unavailable, unavailable},
+			// the bug is fetched once to check its security level and once more to validate it
+			expectedCalls: 4,
+		},
+		{
+			name:          "transient errors are surfaced once retries are exhausted",
+			errs:          []error{unavailable, unavailable, unavailable},
+			expectedCalls: 3,
+			expectedError: "An error was encountered searching for bug OCPBUGS-123",
+		},
+		{
+			name:          "permanent errors are not retried",
+			errs:          []error{badRequest},
+			expectedCalls: 1,
+			expectedError: "An error was encountered searching for bug OCPBUGS-123",
+		},
+		{
+			name:          "permanent errors are reported without a known cause",
+			errs:          []error{badRequest},
+			expectedCalls: 1,
+			expectedError: "No known errors were detected, please see the full error message for details.",
+		},
+		{
+			name:          "transient errors suggest waiting before refreshing",
+			errs:          []error{unavailable, unavailable, unavailable},
+			expectedCalls: 3,
+			expectedError: "The Jira server is temporarily unavailable.",
+		},
+		{
+			name:          "permission errors suggest contacting a Jira administrator",
+			errs:          []error{forbidden},
+			expectedCalls: 1,
+			expectedError: "The bot is not permitted to access the bug.",
+		},
+		{
+			name:          "missing issues suggest fixing the title",
+			errs:          []error{notFound},
+			expectedCalls: 1,
+			expectedError: "No Jira issue with key OCPBUGS-123 exists in the tracker",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flaky := &flakyJiraClient{
+				FakeClient: &fakejira.FakeClient{
+					Issues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+				},
+				errs: tc.errs,
+			}
+			log := logrus.WithField("testCase", tc.name)
+			jc := newRetryingJiraClient(flaky, 3, 0, log)
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{}
+			e := event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This PR fixes OCPBUGS-123", title: "OCPBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+			}
+			if err := handle(jc, fakeGHClient{gc}, nil, nil, nil, JiraBranchOptions{}, log, e, sets.New("org/repo"), defaultBugProjects, false); err != nil {
+				t.Fatalf("handle failed: %v", err)
+			}
+			if flaky.calls != tc.expectedCalls {
+				t.Errorf("expected %d calls to fetch the issue, got %d", tc.expectedCalls, flaky.calls)
+			}
+			comments := gc.IssueComments[1]
+			if len(comments) != 1 {
+				t.Fatalf("expected one comment, got %d", len(comments))
+			}
+			if tc.expectedError == "" {
+				if !strings.Contains(comments[0].Body, "which is valid") {
+					t.Errorf("expected the bug to be reported as valid, got comment: %s", comments[0].Body)
+				}
+			} else if !strings.Contains(comments[0].Body, tc.expectedError) {
+				t.Errorf("expected comment to contain %q, got: %s", tc.expectedError, comments[0].Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// The interfaces below group the calls the plugin makes against the code hosting service by
+// concern. Apart from user lookups for QA contacts, nothing in them is specific to GitHub beyond
+// the data types, so other hosting services (e.g. GitLab) can be supported by implementing them
+// and translating their merge requests, comments and labels into the same types.
+
+// commentClient manages the comments posted on pull requests.
+type commentClient interface {
+	CreateComment(owner, repo string, number int, comment string) error
+	EditComment(org, repo string, id int, comment string) error
+	DeleteComment(org, repo string, id int) error
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	CreateCommentReaction(org, repo string, id int, reaction string) error
+	BotUserChecker() (func(candidate string) bool, error)
+}
+
+// labelClient manages the labels applied to pull requests.
+type labelClient interface {
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	AddLabel(owner, repo string, number int, label string) error
+	RemoveLabel(owner, repo string, number int, label string) error
+	WasLabelAddedByHuman(org, repo string, num int, label string) (bool, error)
+}
+
+// pullRequestClient fetches and updates pull requests.
+type pullRequestClient interface {
+	GetIssue(org, repo string, number int) (*github.Issue, error)
+	EditIssue(org, repo string, number int, issue *github.Issue) (*github.Issue, error)
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetPullRequests(org, repo string) ([]github.PullRequest, error)
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	ListPullRequestCommits(org, repo string, number int) ([]github.RepositoryCommit, error)
+	GetSingleCommit(org, repo, SHA string) (github.RepositoryCommit, error)
+}
+
+// permissionClient determines what users are allowed to do in a repo.
+type permissionClient interface {
+	IsCollaborator(owner, repo, login string) (bool, error)
+}
+
+// statusClient reports results as commit statuses on pull requests.
+type statusClient interface {
+	CreateStatus(org, repo, ref string, s github.Status) error
+}
+
+// githubClient is the full set of calls the plugin makes against GitHub.
+type githubClient interface {
+	commentClient
+	labelClient
+	pullRequestClient
+	permissionClient
+	statusClient
+	// QueryWithGitHubAppsSupport is used to look up GitHub users by the email of the Jira QA contact
+	QueryWithGitHubAppsSupport(ctx context.Context, q any, vars map[string]any, org string) error
+}
@@ -1,23 +1,29 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
 	"net/url"
+	"path"
 	"regexp"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
+	"unicode"
 
 	"github.com/andygrunwald/go-jira"
 	githubql "github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
 	"github.com/trivago/tgo/tcontainer"
+	"golang.org/x/time/rate"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/pkg/config"
@@ -34,6 +40,8 @@ import (
 const (
 	PluginName            = "jira-lifecycle"
 	issueLink             = `[Jira Issue %s](%s/browse/%s)`
+	invalidBugComment     = `This pull request references ` + issueLink + `, which is invalid:`
+	validBugStatusContext = "jira/valid-bug"
 	criticalSeverity      = "Critical"
 	importantSeverity     = "Important"
 	moderateSeverity      = "Moderate"
@@ -42,19 +50,34 @@ const (
 )
 
 var (
-	jiraIssueRegexPart       = `[[:alnum:]]+-[[:digit:]]+`
-	titleMatchJiraIssue      = regexp.MustCompile(`(?i)(` + jiraIssueRegexPart + `,?[[:space:]]*)*(NO-JIRA|NO-ISSUE|` + jiraIssueRegexPart + `)+:`)
-	verifyCommandMatch       = regexp.MustCompile(`(?mi)^/verified by\s+(([^\s]+,)*([^\s]+))*$`)
-	verifyRemoveCommandMatch = regexp.MustCompile(`(?mi)^/verified remove$`)
-	verifyLaterCommandMatch  = regexp.MustCompile(`(?mi)^/verified later\s+(([^\s]+,)*([^\s]+))*$`)
-	refreshCommandMatch      = regexp.MustCompile(`(?mi)^/jira refresh\s*$`)
-	qaReviewCommandMatch     = regexp.MustCompile(`(?mi)^/jira cc-qa\s*$`)
-	cherrypickCommandMatch   = regexp.MustCompile(`(?mi)^/jira cherry-?pick (` + jiraIssueRegexPart + `,?[[:space:]]*)*(` + jiraIssueRegexPart + `)+\s*$`)
-	backportCommandMatch     = regexp.MustCompile(`(?mi)^/jira backport\s+(([^\s]+,)*([^\s]+))$`)
-	existingBackportMatch    = regexp.MustCompile(`jlp-[^:]+:[^:]+`)
-	cherrypickPRMatch        = regexp.MustCompile(`This is an automated cherry-pick of #([0-9]+)`)
-	jiraIssueReferenceMatch  = regexp.MustCompile(`([[:alnum:]]+)-([[:digit:]]+)`)
-	bugProjects              = sets.New("OCPBUGS", "DFBUGS")
+	jiraIssueRegexPart        = `[[:alnum:]]+-[[:digit:]]+`
+	titleMatchJiraIssue       = regexp.MustCompile(`(?i)(` + jiraIssueRegexPart + `,?[[:space:]]*)*(NO-JIRA|NO-ISSUE|` + jiraIssueRegexPart + `)+:`)
+	bodyMatchJiraIssue        = regexp.MustCompile(`(?i)\b(?:fix|fixes|fixed|resolve|resolves|resolved|close|closes|closed):?[[:space:]]+(` + jiraIssueRegexPart + `)\b`)
+	verifyCommandMatch        = regexp.MustCompile(`(?mi)^/verified by\s+(.+?)\s*$`)
+	verifyRemoveCommandMatch  = regexp.MustCompile(`(?mi)^/verified remove$`)
+	verifyLaterCommandMatch   = regexp.MustCompile(`(?mi)^/verified later\s+(([^\s]+,)*([^\s]+))*$`)
+	refreshCommandMatch       = regexp.MustCompile(`(?mi)^/jira refresh\s*$`)
+	refreshAllCommandMatch    = regexp.MustCompile(`(?mi)^/jira refresh-all\s*$`)
+	qaReviewCommandMatch      = regexp.MustCompile(`(?mi)^/jira cc-qa\s*$`)
+	cherrypickCommandMatch    = regexp.MustCompile(`(?mi)^/jira cherry-?pick (` + jiraIssueRegexPart + `,?[[:space:]]*)*(` + jiraIssueRegexPart + `)+\s*$`)
+	uncherrypickCommandMatch  = regexp.MustCompile(`(?mi)^/jira uncherry-?pick\s*$`)
+	unlinkCommandMatch        = regexp.MustCompile(`(?mi)^/jira unlink\s*$`)
+	linkCloneCommandMatch     = regexp.MustCompile(`(?mi)^/jira link-clone\s+(` + jiraIssueRegexPart + `)\s*$`)
+	createCommandMatch        = regexp.MustCompile(`(?mi)^/jira create\s*$`)
+	backportCommandMatch      = regexp.MustCompile(`(?mi)^/jira backport\s+(([^\s]+,)*([^\s]+))$`)
+	backportCheckCommandMatch = regexp.MustCompile(`(?mi)^/jira backport-check\s+(([^\s]+,)*([^\s]+))$`)
+	setPriorityCommandMatch   = regexp.MustCompile(`(?mi)^/jira set-priority\s+(.+?)\s*$`)
+	setVersionCommandMatch    = regexp.MustCompile(`(?mi)^/jira set-version\s+(\S+)\s*$`)
+	setFixVersionCommandMatch = regexp.MustCompile(`(?mi)^/jira set-fixversion\s+(\S+)\s*$`)
+	statusCommandMatch        = regexp.MustCompile(`(?mi)^/jira status\s*$`)
+	finalizeCommandMatch      = regexp.MustCompile(`(?mi)^/jira finalize\s*$`)
+	moveCommandMatch          = regexp.MustCompile(`(?mi)^/jira move\s+([^:\r\n]+?)(?::([^\r\n]+?))?\s*$`)
+	jiraCommentCommandMatch   = regexp.MustCompile(`(?mi)^/jira comment\s+([^/\s].*(?:\r?\n(?:[^/\r\n].*)?)*)`)
+	existingBackportMatch     = regexp.MustCompile(`jlp-[^:]+:[^:]+`)
+	cherrypickPRMatch         = regexp.MustCompile(`This is an automated cherry-pick of #([0-9]+)`)
+	jiraIssueReferenceMatch   = regexp.MustCompile(`([[:alnum:]]+)-([[:digit:]]+)`)
+	// validPriorities are the priorities that can be set on bugs via the `/jira set-priority` command
+	validPriorities = []string{"Blocker", "Critical", "Major", "Normal", "Minor", "Undefined"}
 )
 
 type referencedIssue struct {
@@ -74,6 +97,12 @@ type dependent struct {
 	bugState         JiraBugState
 }
 
+// project returns the key of the project of the dependent bug, which prefixes its key
+func (d dependent) project() string {
+	project, _, _ := strings.Cut(d.key, "-")
+	return project
+}
+
 type server struct {
 	config func() *Config
 
@@ -82,6 +111,13 @@ type server struct {
 	jc              jiraclient.Client
 
 	bigqueryInserter BigQueryInserter
+	slackNotifier    SlackNotifier
+
+	// dryRun determines whether mutating calls to Jira and GitHub are logged instead of executed
+	dryRun bool
+
+	refreshCooldown  *refreshCooldown
+	pullRequestHeads *pullRequestHeads
 }
 
 func (s *server) helpProvider(enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
@@ -120,6 +156,15 @@ func (s *server) helpProvider(enabledRepos []config.OrgRepo) (*pluginhelp.Plugin
 					conditions = append(conditions, fmt.Sprintf("target the %q version", *opts[branch].TargetVersion))
 				}
 			}
+			if opts[branch].RequireSingleTargetVersion != nil && *opts[branch].RequireSingleTargetVersion {
+				conditions = append(conditions, "target no more than one version")
+			}
+			if opts[branch].RequireActiveSprint != nil && *opts[branch].RequireActiveSprint {
+				conditions = append(conditions, "be assigned to an active sprint")
+			}
+			if opts[branch].FixVersion != nil {
+				conditions = append(conditions, fmt.Sprintf("have a fix version matching the %q version", *opts[branch].FixVersion))
+			}
 			if opts[branch].ValidStates != nil && len(*opts[branch].ValidStates) > 0 {
 				pretty := strings.Join(prettyStates(*opts[branch].ValidStates), ", ")
 				conditions = append(conditions, fmt.Sprintf("be in one of the following states: %s", pretty))
@@ -134,6 +179,21 @@ func (s *server) helpProvider(enabledRepos []config.OrgRepo) (*pluginhelp.Plugin
 			if opts[branch].DependentBugTargetVersions != nil {
 				conditions = append(conditions, fmt.Sprintf("have all dependent bugs in one of the following target versions: %s", strings.Join(*opts[branch].DependentBugTargetVersions, ", ")))
 			}
+			if opts[branch].RequireBlockedBy != nil && *opts[branch].RequireBlockedBy {
+				conditions = append(conditions, "be blocked by at least one other bug")
+			}
+			if opts[branch].RequireBlockersResolved != nil && *opts[branch].RequireBlockersResolved {
+				conditions = append(conditions, "have all blocking bugs resolved")
+			}
+			if opts[branch].RequireAffectsVersion != nil && *opts[branch].RequireAffectsVersion {
+				conditions = append(conditions, "have at least one affects version set")
+			}
+			if opts[branch].RequireDescription != nil && *opts[branch].RequireDescription {
+				conditions = append(conditions, "have a description")
+			}
+			if opts[branch].AllowedIssueTypes != nil {
+				conditions = append(conditions, fmt.Sprintf("be of one of the following issue types: %s", strings.Join(*opts[branch].AllowedIssueTypes, ", ")))
+			}
 			switch len(conditions) {
 			case 0:
 				message += "exist"
@@ -310,6 +370,13 @@ func (s *server) helpProvider(enabledRepos []config.OrgRepo) (*pluginhelp.Plugin
 		WhoCanUse:   "Anyone",
 		Examples:    []string{"/jira refresh"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira refresh-all",
+		Description: "Re-evaluate every open PR in the repo that references a Jira bug in its title",
+		Featured:    false,
+		WhoCanUse:   "Collaborators on the repository",
+		Examples:    []string{"/jira refresh-all"},
+	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/jira cc-qa",
 		Description: "Request PR review from QA contact specified in Jira",
@@ -324,46 +391,242 @@ func (s *server) helpProvider(enabledRepos []config.OrgRepo) (*pluginhelp.Plugin
 		WhoCanUse:   "Anyone",
 		Examples:    []string{"/jira cherrypick OCPBUGS-1234"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira uncherrypick",
+		Description: "Undo a cherrypick of the jira bugs referenced in the PR title by removing the links to the bugs they were cloned from",
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira uncherrypick"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira unlink",
+		Description: "Remove the external link to the current PR from the jira bugs referenced in the PR title without changing their state",
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira unlink"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira link-clone jiraBugKey",
+		Description: "Link an existing jira bug as a clone of the jira bug referenced in the PR title, the same way a cherrypick would, and retitle the PR to reference it",
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira link-clone OCPBUGS-1234"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira create",
+		Description: "Create a jira bug for a PR that does not reference a jira issue, using the PR title and description, and retitle the PR to reference it",
+		Featured:    false,
+		WhoCanUse:   "Collaborators on the repository",
+		Examples:    []string{"/jira create"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira set-priority priority",
+		Description: fmt.Sprintf("Set the priority of the jira bugs referenced in the PR title. Valid priorities are: %s", strings.Join(validPriorities, ", ")),
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira set-priority Critical"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira set-version version",
+		Description: "Set the target version of the jira bugs referenced in the PR title",
+		Featured:    false,
+		WhoCanUse:   "Collaborators on the repository",
+		Examples:    []string{"/jira set-version 4.16.0"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira set-fixversion version",
+		Description: "Set the fix version of the jira bugs referenced in the PR title",
+		Featured:    false,
+		WhoCanUse:   "Collaborators on the repository",
+		Examples:    []string{"/jira set-fixversion 4.16.0"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira move state[:resolution]",
+		Description: "Transition the jira bugs referenced in the PR title to the provided state, optionally setting the provided resolution",
+		Featured:    false,
+		WhoCanUse:   "Collaborators on the repository",
+		Examples:    []string{"/jira move VERIFIED", "/jira move CLOSED:DONE"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira status",
+		Description: "Show the current status, resolution, target version, and assignee of the jira bugs referenced in the PR title",
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira status"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira comment text",
+		Description: "Add a comment containing the provided text to the jira issues referenced in the PR title. All text following the command, including further lines, is included in the comment",
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira comment This was verified on the nightly build."},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/jira finalize",
+		Description: "Re-run the handling of the merge of the PR, moving the jira bugs referenced in the PR title to the state after merge once all linked PRs have merged. Useful when the merge event was missed",
+		Featured:    false,
+		WhoCanUse:   "Anyone",
+		Examples:    []string{"/jira finalize"},
+	})
 	return pluginHelp, nil
 }
 
-type githubClient interface {
-	IsCollaborator(owner, repo, login string) (bool, error)
-	EditComment(org, repo string, id int, comment string) error
-	GetIssue(org, repo string, number int) (*github.Issue, error)
-	EditIssue(org, repo string, number int, issue *github.Issue) (*github.Issue, error)
-	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
-	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
-	CreateComment(owner, repo string, number int, comment string) error
-	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
-	AddLabel(owner, repo string, number int, label string) error
-	RemoveLabel(owner, repo string, number int, label string) error
-	WasLabelAddedByHuman(org, repo string, num int, label string) (bool, error)
-	QueryWithGitHubAppsSupport(ctx context.Context, q any, vars map[string]any, org string) error
-	BotUserChecker() (func(candidate string) bool, error)
-}
-
 func (s *server) handleIssueComment(l *logrus.Entry, e github.IssueCommentEvent) {
 	cfg := s.config()
-	event, err := digestComment(s.ghc, l, e)
+	ghc := s.ghc
+	if s.dryRun {
+		ghc = newDryRunGitHubClient(ghc, l)
+	}
+	event, err := digestComment(ghc, l, e, cfg)
 	if err != nil {
 		l.Errorf("failed to digest comment: %v", err)
 	}
+	if event != nil && cfg.MaintenanceMode {
+		postMaintenanceNotice(ghc, *event, cfg.MaintenanceNotice, l)
+		return
+	}
+	if event != nil && event.refreshAll {
+		if err := refreshAll(s.jc, s.ghc, s.bigqueryInserter, s.slackNotifier, cfg, l, *event, s.prowConfigAgent.Config().AllRepos, s.dryRun); err != nil {
+			l.Errorf("failed to refresh all pull requests: %v", err)
+		}
+		return
+	}
 	if event != nil {
 		branchOptions := cfg.OptionsForBranch(event.org, event.repo, event.baseRef)
+		if s.throttleRefresh(ghc, *event, branchOptions, l) {
+			return
+		}
+		acknowledgeCommand(ghc, *event, branchOptions, l)
 		repoOptions := cfg.OptionsForRepo(event.org, event.repo)
-		if err := handle(s.jc, s.ghc, s.bigqueryInserter, repoOptions, branchOptions, l, *event, s.prowConfigAgent.Config().AllRepos); err != nil {
+		if err := handle(s.jc, s.ghc, s.bigqueryInserter, s.slackNotifier, repoOptions, branchOptions, l, *event, s.prowConfigAgent.Config().AllRepos, cfg.BugProjectSet(), s.dryRun); err != nil {
 			l.Errorf("failed to handle comment: %v", err)
 		}
 	}
 }
 
-func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, repoOptions map[string]JiraBranchOptions, branchOptions JiraBranchOptions, log *logrus.Entry, e event, allRepos sets.Set[string]) error {
+// postMaintenanceNotice comments the maintenance notice on the pull request unless the bot has already
+// done so. No other changes are made while the plugin is in maintenance mode, so errors are only logged.
+func postMaintenanceNotice(ghc githubClient, e event, notice string, log *logrus.Entry) {
+	log.Info("Skipping event as the plugin is in maintenance mode.")
+	if notice == "" {
+		return
+	}
+	comments, err := ghc.ListIssueComments(e.org, e.repo, e.number)
+	if err != nil {
+		log.WithError(err).Error("Failed to list issue comments.")
+		return
+	}
+	isBot, err := ghc.BotUserChecker()
+	if err != nil {
+		log.WithError(err).Error("Failed to create bot user checker.")
+		return
+	}
+	for _, comment := range comments {
+		if isBot(comment.User.Login) && strings.Contains(comment.Body, notice) {
+			return
+		}
+	}
+	if err := e.comment(ghc)(notice); err != nil {
+		log.WithError(err).Error("Failed to comment the maintenance notice.")
+	}
+}
+
+// acknowledgeCommand reacts to `/jira refresh` and `/verified` comments before they are processed, if a reaction is configured
+func acknowledgeCommand(ghc githubClient, e event, options JiraBranchOptions, log *logrus.Entry) {
+	if options.CommandReaction == nil || *options.CommandReaction == "" {
+		return
+	}
+	if !e.refresh && len(e.verify) == 0 && len(e.verifyLater) == 0 && !e.verifiedRemove {
+		return
+	}
+	if err := ghc.CreateCommentReaction(e.org, e.repo, e.commentID, *options.CommandReaction); err != nil {
+		log.WithError(err).Warn("Failed to react to the command.")
+	}
+}
+
+// refreshAllInterval and refreshAllBurst limit how quickly pull requests are re-evaluated by `/jira refresh-all`
+const (
+	refreshAllInterval = time.Second
+	refreshAllBurst    = 5
+)
+
+// refreshAll re-evaluates every open pull request in the repo that references a bug and posts a summary
+// of the outcome on the pull request or issue where the command was issued.
+func refreshAll(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, notifier SlackNotifier, cfg *Config, log *logrus.Entry, e event, allRepos sets.Set[string], dryRun bool) error {
+	comment := e.comment(ghc)
+	if dryRun {
+		comment = e.comment(newDryRunGitHubClient(ghc, log))
+	}
+	if ok, err := checkCollaborator(ghc, e, "/jira refresh-all", comment, log); !ok {
+		return err
+	}
+
+	events, err := OpenBugPullRequestEvents(ghc, e.org, e.repo, cfg)
+	if err != nil {
+		log.WithError(err).Warn("Failed to list open pull requests")
+		return comment(fmt.Sprintf("Failed to list the open pull requests in %s/%s: %v. Please try again.", e.org, e.repo, err))
+	}
+	if len(events) == 0 {
+		return comment(fmt.Sprintf("No open pull requests in %s/%s reference a Jira bug; nothing was refreshed.", e.org, e.repo))
+	}
+
+	repoOptions := cfg.OptionsForRepo(e.org, e.repo)
+	limiter := rate.NewLimiter(rate.Every(refreshAllInterval), refreshAllBurst)
+	var failed []string
+	for _, prEvent := range events {
+		if err := limiter.Wait(context.Background()); err != nil {
+			return fmt.Errorf("failed to wait for rate limiter: %w", err)
+		}
+		prLog := log.WithField("pr", fmt.Sprintf("%s/%s#%d", prEvent.org, prEvent.repo, prEvent.number))
+		branchOptions := cfg.OptionsForBranch(prEvent.org, prEvent.repo, prEvent.baseRef)
+		if err := handle(jc, ghc, inserter, notifier, repoOptions, branchOptions, prLog, prEvent, allRepos, cfg.BugProjectSet(), dryRun); err != nil {
+			prLog.WithError(err).Warn("Failed to refresh pull request")
+			failed = append(failed, fmt.Sprintf(" * #%d: %v", prEvent.number, err))
+		}
+	}
+
+	message := fmt.Sprintf("Refreshed %d of %d open pull requests in %s/%s that reference a Jira bug.", len(events)-len(failed), len(events), e.org, e.repo)
+	if len(failed) > 0 {
+		message += fmt.Sprintf("\n\nThe following pull requests could not be refreshed:\n%s", strings.Join(failed, "\n"))
+	}
+	return comment(message)
+}
+
+func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, notifier SlackNotifier, repoOptions map[string]JiraBranchOptions, branchOptions JiraBranchOptions, log *logrus.Entry, e event, allRepos, bugProjects sets.Set[string], dryRun bool) (handleErr error) {
+	if slices.ContainsFunc(branchOptions.IgnoreAuthors, func(author string) bool { return strings.EqualFold(author, e.author) }) {
+		log.Debugf("Ignoring pull request authored by %s.", e.author)
+		return nil
+	}
+	if dryRun {
+		// all responses are still computed, but mutations are only logged
+		jc = newDryRunJiraClient(jc, log)
+		ghc = newDryRunGitHubClient(ghc, log)
+		if notifier != nil {
+			notifier = newDryRunSlackNotifier(log)
+		}
+		// audit entries are still recorded, but marked as not having been executed
+		log = log.WithField("dry-run", true)
+	}
+	if branchOptions.JiraDisplayURL != nil && *branchOptions.JiraDisplayURL != "" {
+		jc = &displayURLJiraClient{Client: jc, url: strings.TrimSuffix(*branchOptions.JiraDisplayURL, "/")}
+	}
+	counts := &callCounts{}
+	jc = &countingJiraClient{Client: jc, counts: counts}
+	ghc = &countingGitHubClient{ghc: ghc, counts: counts}
+	defer recordHandleMetrics(inserter, e, counts, time.Now(), log)
+	defer func() {
+		if handleErr != nil {
+			notifySlack(notifier, branchOptions, errorSlackMessage(e, handleErr), log)
+		}
+	}()
+	if branchOptions.CommentFooter != nil {
+		e.footer = *branchOptions.CommentFooter
+	}
 	comment := e.comment(ghc)
 	if !e.missing {
 		for _, refIssue := range e.issues {
 			if refIssue.IsBug && refIssue.Key() != "" {
-				issue, err := getJira(jc, refIssue.Key(), log, comment)
+				issue, err := getJira(jc, branchOptions, refIssue.Key(), log, comment)
 				if err != nil || issue == nil {
 					return err
 				}
@@ -373,7 +636,7 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 				}
 				if !bugAllowed {
 					// ignore bugs that are in non-allowed security levels for this repo
-					if e.opened || e.refresh {
+					if e.opened || e.reopened || e.refresh {
 						response := fmt.Sprintf(issueLink+" is in a security level that is not in the allowed security levels for this repo.", refIssue.Key(), jc.JiraURL(), refIssue.Key())
 						if len(branchOptions.AllowedSecurityLevels) > 0 {
 							response += "\nAllowed security levels for this repo are:"
@@ -392,13 +655,22 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 	}
 	// just remove verified label if files were changed
 	if e.fileChanged {
+		if len(branchOptions.VerifiedResetIgnorePaths) > 0 {
+			changes, err := pushedFiles(ghc, e)
+			if err != nil {
+				log.WithError(err).Warn("Could not list files changed by the push to the PR")
+			} else if onlyIgnoredPathsChanged(changes, branchOptions.VerifiedResetIgnorePaths) {
+				log.Debug("Only files ignored for verification were changed, retaining verified labels.")
+				return nil
+			}
+		}
 		currentLabels, err := ghc.GetIssueLabels(e.org, e.repo, e.number)
 		if err != nil {
 			log.WithError(err).Warn("Could not list labels on PR")
 		}
 		for _, label := range currentLabels {
-			if label.Name == labels.Verified {
-				if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.Verified); err != nil {
+			if label.Name == verifiedLabel(branchOptions) {
+				if err := ghc.RemoveLabel(e.org, e.repo, e.number, verifiedLabel(branchOptions)); err != nil {
 					log.WithError(err).Error("Failed to remove verified label.")
 				}
 				info := VerificationInfo{
@@ -414,8 +686,8 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 				if err := inserter.Put(context.TODO(), info); err != nil {
 					log.WithError(err).Error("Failed to upload info to Big Query")
 				}
-			} else if label.Name == labels.VerifiedLater {
-				if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.VerifiedLater); err != nil {
+			} else if label.Name == verifiedLaterLabel(branchOptions) {
+				if err := ghc.RemoveLabel(e.org, e.repo, e.number, verifiedLaterLabel(branchOptions)); err != nil {
 					log.WithError(err).Error("Failed to remove verified-later label.")
 				}
 				info := VerificationInfo{
@@ -437,14 +709,50 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 	}
 	// cherrypicks follow a different pattern than normal validation
 	if e.cherrypick {
-		return handleCherrypick(e, ghc, jc, branchOptions, log)
+		return handleCherrypick(e, ghc, jc, branchOptions, bugProjects, log)
+	}
+	if e.uncherrypick {
+		return handleUncherrypick(e, ghc, jc, branchOptions, log)
+	}
+	if e.unlink {
+		return handleUnlink(e, ghc, jc, branchOptions, log)
+	}
+	if e.linkClone != "" {
+		return handleLinkClone(e, ghc, jc, branchOptions, log)
+	}
+	if e.create {
+		return handleCreate(e, ghc, jc, branchOptions, log)
 	}
 	if e.backport {
 		return handleBackport(e, ghc, jc, repoOptions, log)
 	}
+	if e.backportCheck {
+		return handleBackportCheck(e, ghc, repoOptions)
+	}
+	if e.priority != "" {
+		return handleSetPriority(e, ghc, jc, branchOptions, log)
+	}
+	if e.targetVersion != "" {
+		return handleSetVersion(e, ghc, jc, branchOptions, log)
+	}
+	if e.fixVersion != "" {
+		return handleSetFixVersion(e, ghc, jc, branchOptions, log)
+	}
+	if e.bugStatus {
+		return handleStatus(e, ghc, jc, branchOptions, log)
+	}
+	if e.move != nil {
+		return handleMove(e, ghc, jc, branchOptions, log)
+	}
+	if e.jiraComment != "" {
+		return handleJiraComment(e, ghc, jc, branchOptions, log)
+	}
+	if e.finalize {
+		return handleFinalize(e, ghc, jc, inserter, branchOptions, log, allRepos)
+	}
 	// merges follow a different pattern from the normal validation
 	if e.merged {
-		return handleMerge(e, ghc, jc, branchOptions, log, allRepos)
+		return handleMerge(e, ghc, jc, inserter, branchOptions, log, allRepos)
 	}
 	// close events follow a different pattern from the normal validation
 	if e.closed && !e.merged {
@@ -452,12 +760,61 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 	}
 	// verification commands follow a different pattern than normal validation
 	if len(e.verify) > 0 || len(e.verifyLater) > 0 || e.verifiedRemove {
-		return handleVerification(e, ghc, inserter, log)
+		return handleVerification(e, ghc, inserter, branchOptions, log)
+	}
+	// titles referencing too many bugs are rejected before any of the bugs are validated
+	if branchOptions.MaxReferencedBugs != nil && !e.noJira {
+		var bugKeys []string
+		for _, refIssue := range e.issues {
+			if refIssue.IsBug {
+				bugKeys = append(bugKeys, refIssue.Key())
+			}
+		}
+		if len(bugKeys) > *branchOptions.MaxReferencedBugs {
+			return comment(fmt.Sprintf("This pull request references %d Jira bugs (%s), which is more than the %d allowed for this branch. None of the bugs have been validated; edit the title of this pull request to reference fewer bugs.", len(bugKeys), strings.Join(bugKeys, ", "), *branchOptions.MaxReferencedBugs))
+		}
+	}
+	// titles referencing issues from multiple projects are rejected before any of the issues are validated
+	if branchOptions.RequireSameProject != nil && *branchOptions.RequireSameProject && !e.noJira {
+		ignoreNonBugs := branchOptions.SameProjectIgnoreNonBugs != nil && *branchOptions.SameProjectIgnoreNonBugs
+		projects := sets.New[string]()
+		for _, refIssue := range e.issues {
+			if refIssue.IsBug || !ignoreNonBugs {
+				projects.Insert(refIssue.Project)
+			}
+		}
+		if projects.Len() > 1 {
+			return comment(fmt.Sprintf("This pull request references Jira issues from multiple projects (%s), but all referenced issues must belong to the same project on this branch. None of the issues have been validated; edit the title of this pull request to reference issues from a single project.", strings.Join(sets.List(projects), ", ")))
+		}
 	}
 
-	var needsJiraValidRefLabel, needsJiraValidBugLabel, needsJiraInvalidBugLabel bool
-	var response, severityLabel string
+	var needsJiraValidRefLabel, needsJiraValidBugLabel, needsJiraInvalidBugLabel, needsInformationLabel bool
+	// dependentsVerified tracks whether every dependent of the referenced bugs is VERIFIED, and
+	// dependentsRegressed whether any of them is not, for automatically managing the QE approved label
+	var dependentsVerified, dependentsRegressed bool
+	var response, highestSeverity string
+	// responseHasErrors tracks whether errors were reported in the response, which is then always posted
+	var responseHasErrors bool
 	var invalidIssues []string
+	// invalidReasons collects why each referenced bug is invalid, for Slack notifications
+	var invalidReasons []string
+	// warnings are non-blocking problems found while handling the referenced issues, reported together at the end of the response
+	var warnings []string
+	if branchOptions.WarnOnDefaultBranchConfig != nil && *branchOptions.WarnOnDefaultBranchConfig && !branchConfigured(e.baseRef, repoOptions) {
+		warnings = append(warnings, fmt.Sprintf("No configuration matches the %s branch this PR targets, so the default configuration for all branches is used. If this branch should be configured, check the configuration of the plugin for this repository.", e.baseRef))
+	}
+	// bugs referenced by draft pull requests are validated, but not moved to a new state until the pull request is ready
+	skipTransitions := e.draft && branchOptions.SkipDrafts != nil && *branchOptions.SkipDrafts
+	disableSeverityLabels := branchOptions.DisableSeverityLabels != nil && *branchOptions.DisableSeverityLabels
+	autoQEApprove := branchOptions.AutoQEApproveOnVerifiedDependents != nil && *branchOptions.AutoQEApproveOnVerifiedDependents
+	var divergentTargetVersions string
+	// bugs fetched to compare their target versions are reused rather than fetched again when they are validated
+	var fetchedBugs map[string]*jira.Issue
+	if branchOptions.RequireConsistentTargetVersions != nil && *branchOptions.RequireConsistentTargetVersions && !e.noJira && !e.missing {
+		divergentTargetVersions, fetchedBugs = inconsistentTargetVersions(jc, e.issues, log)
+	}
+	// the projects in Jira are only needed to explain references to unknown projects, so they are listed at most once
+	listProjects := sync.OnceValues(jc.ListProjects)
 	if !e.noJira {
 		for _, refIssue := range e.issues {
 			// separate responses for different bugs
@@ -467,10 +824,15 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 			var issue *jira.Issue
 			var err error
 			if !e.missing {
-				issue, err = getJira(jc, refIssue.Key(), log, comment)
-				if err != nil {
+				if fetched, ok := fetchedBugs[refIssue.Key()]; ok {
+					issue = fetched
+				} else if issue, err = getJira(jc, branchOptions, refIssue.Key(), log, comment); err != nil {
 					return err
 				}
+				refIssue.IsBug = resolveIsBug(refIssue, issue, bugProjects, log)
+				if issue == nil {
+					response += unknownProjectHint(jc, listProjects, refIssue, bugProjects, branchOptions, log)
+				}
 			}
 
 			if issue == nil {
@@ -478,28 +840,40 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 			} else {
 				needsJiraValidRefLabel = true
 				premergeUpdated := false
+				var premergeFieldChanges []fieldChange
+				// references that are bugs may be treated as non-bug references below, but their type is not restricted
+				nonBugReference := !refIssue.IsBug
 				// check labels for premerge verification
 				if refIssue.IsBug {
 					if labels, err := ghc.GetIssueLabels(e.org, e.repo, e.number); err != nil {
 						log.WithError(err).Warn("Could not list labels on PR")
 					} else {
 						premergeVerified := isPreMergeVerified(issue, labels)
-						if premergeVerified && branchOptions.PreMergeStateAfterValidation != nil {
+						if premergeVerified && branchOptions.PreMergeStateAfterValidation != nil && !skipTransitions && !transitionsDisabled(branchOptions) {
 							if branchOptions.PreMergeStateAfterValidation.Status != "" && (issue.Fields.Status == nil || !strings.EqualFold(issue.Fields.Status.Name, branchOptions.PreMergeStateAfterValidation.Status)) {
+								oldStatus := issueStatus(issue)
 								if err := jc.UpdateStatus(issue.Key, branchOptions.PreMergeStateAfterValidation.Status); err != nil {
 									log.WithError(err).Warn("Unexpected error updating jira issue.")
-									response += formatError(fmt.Sprintf("updating to the %s state", branchOptions.PreMergeStateAfterValidation.Status), jc.JiraURL(), refIssue.Key(), err)
+									response += formatError(branchOptions, fmt.Sprintf("updating to the %s state", branchOptions.PreMergeStateAfterValidation.Status), jc.JiraURL(), refIssue.Key(), err)
+									responseHasErrors = true
 									continue
 								}
+								recordTransition(e, branchOptions.PreMergeStateAfterValidation.Status)
+								recordAudit(log, e, auditActionTransition, issue.Key, oldStatus, branchOptions.PreMergeStateAfterValidation.Status)
+								premergeFieldChanges = append(premergeFieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: branchOptions.PreMergeStateAfterValidation.Status})
 								premergeUpdated = true
 							}
 							if branchOptions.PreMergeStateAfterValidation.Resolution != "" && (issue.Fields.Resolution == nil || !strings.EqualFold(issue.Fields.Status.Name, branchOptions.PreMergeStateAfterValidation.Resolution)) {
+								oldResolution := issueResolution(issue)
 								updateIssue := jira.Issue{Key: issue.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: branchOptions.PreMergeStateAfterValidation.Resolution}}}
 								if _, err := jc.UpdateIssue(&updateIssue); err != nil {
 									log.WithError(err).Warn("Unexpected error updating jira issue.")
-									response += formatError(fmt.Sprintf("updating to the %s resolution", branchOptions.PreMergeStateAfterMerge.Resolution), jc.JiraURL(), refIssue.Key(), err)
+									response += formatError(branchOptions, fmt.Sprintf("updating to the %s resolution", branchOptions.PreMergeStateAfterMerge.Resolution), jc.JiraURL(), refIssue.Key(), err)
+									responseHasErrors = true
 									continue
 								}
+								recordAudit(log, e, auditActionResolution, issue.Key, oldResolution, branchOptions.PreMergeStateAfterValidation.Resolution)
+								premergeFieldChanges = append(premergeFieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: branchOptions.PreMergeStateAfterValidation.Resolution})
 								premergeUpdated = true
 							}
 						}
@@ -507,18 +881,70 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 						refIssue.IsBug = !premergeVerified
 					}
 				}
+				// below the minimum severity, bugs are only validated when explicitly requested
+				var belowMinimumSeverity bool
+				if refIssue.IsBug && !e.refresh && validatesByDefault(branchOptions) && branchOptions.ValidateByDefaultMinimumSeverity != nil {
+					below, err := isBelowSeverity(issue, *branchOptions.ValidateByDefaultMinimumSeverity, severityRankingFor(branchOptions))
+					if err != nil {
+						log.WithError(err).Warn("Could not determine the severity of the bug, validating it.")
+					}
+					belowMinimumSeverity = below
+					refIssue.IsBug = !below
+				}
+				if nonBugReference {
+					var nonBugFails []string
+					if branchOptions.NonBugIssueTypes != nil {
+						if err := validateIssueType(issue, *branchOptions.NonBugIssueTypes); err != nil {
+							nonBugFails = append(nonBugFails, err.Error())
+						}
+					}
+					if branchOptions.RequireStoryPoints != nil && *branchOptions.RequireStoryPoints {
+						allowZero := branchOptions.AllowZeroStoryPoints != nil && *branchOptions.AllowZeroStoryPoints
+						if err := validateStoryPoints(issue, allowZero); err != nil {
+							nonBugFails = append(nonBugFails, err.Error())
+							needsInformationLabel = needsInformationLabel || isMissingField(err)
+						}
+					}
+					if len(nonBugFails) > 0 {
+						log.Debug("Invalid non-bug issue found.")
+						needsJiraValidBugLabel, needsJiraInvalidBugLabel = false, true
+						for _, fail := range nonBugFails {
+							invalidReasons = append(invalidReasons, fmt.Sprintf("%s: %s", refIssue.Key(), fail))
+						}
+						// as for valid references, the jira ref is left for the prow-jira plugin to linkify
+						response += fmt.Sprintf(`This pull request references %s, which is invalid:
+ - %s
+
+%s`, refIssue.Key(), strings.Join(nonBugFails, "\n - "), refreshHint(branchOptions, "Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira issue are made, or edit the title of this pull request to link to a different issue."))
+						continue
+					}
+				}
 				if !refIssue.IsBug {
 					// don't linkify the jira ref in this case because the prow-jira plugin will do so and we don't want it to
 					// end up double-linkified.  The prow-jira plugin should be configured to not linkify bugProjects refs, but it will
 					// linkify refs to other projects.
 					response += fmt.Sprintf("This pull request references %s which is a valid jira issue.", refIssue.Key())
+					if belowMinimumSeverity {
+						response += fmt.Sprintf(" Bugs with a severity below %s are not validated by default; comment <code>/jira refresh</code> to validate it.", *branchOptions.ValidateByDefaultMinimumSeverity)
+					}
 					if premergeUpdated {
 						response += fmt.Sprintf(" The bug has been moved to the %s state.", PrettyStatus(branchOptions.PreMergeStateAfterValidation.Status, branchOptions.PreMergeStateAfterValidation.Resolution))
+						response += fieldChangesComment(branchOptions, refIssue.Key(), premergeFieldChanges)
 					}
 					// We still want to notify if the pull request branch and bug target version mismatch
 					if checkTargetVersion(branchOptions) {
 						if err := validateTargetVersion(issue, *branchOptions.TargetVersion); err != nil {
-							response += fmt.Sprintf("\n\nWarning: The referenced jira issue has an invalid target version for the target branch this PR targets: %v.", err)
+							warnings = append(warnings, fmt.Sprintf("The referenced jira issue %s has an invalid target version for the target branch this PR targets: %v.", refIssue.Key(), err))
+						}
+					}
+					if branchOptions.AllowedIssueTypes != nil {
+						if err := validateIssueType(issue, *branchOptions.AllowedIssueTypes); err != nil {
+							warnings = append(warnings, fmt.Sprintf("The referenced jira issue %s has an invalid type for the target branch this PR targets: %v.", refIssue.Key(), err))
+						}
+					}
+					if epicLinkRequired(issue, branchOptions) {
+						if _, err := validateEpicLink(issue); err != nil {
+							warnings = append(warnings, fmt.Sprintf("The referenced jira issue %s is not linked to an epic as required for the target branch this PR targets: %v.", refIssue.Key(), err))
 						}
 					}
 				}
@@ -526,26 +952,35 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 			if refIssue.IsBug && issue != nil {
 				log = log.WithField("refKey", refIssue.Key())
 
-				severity, err := getSimplifiedSeverity(issue)
-				if err != nil {
-					return err
+				if !disableSeverityLabels {
+					severity, err := getSimplifiedSeverity(issue)
+					if err != nil {
+						return err
+					}
+
+					// the highest severity of all referenced bugs determines the severity label
+					ranking := severityRankingFor(branchOptions)
+					if rank := slices.Index(ranking, severity); rank != -1 && (highestSeverity == "" || rank < slices.Index(ranking, highestSeverity)) {
+						highestSeverity = severity
+					}
 				}
 
-				newSeverityLabel := getSeverityLabel(severity)
-				if newSeverityLabel == labels.SeverityCritical {
-					severityLabel = newSeverityLabel
-				} else if newSeverityLabel == labels.SeverityImportant && severityLabel != labels.SeverityCritical {
-					severityLabel = newSeverityLabel
-				} else if newSeverityLabel == labels.SeverityModerate && severityLabel != labels.SeverityCritical && severityLabel != labels.SeverityImportant {
-					severityLabel = newSeverityLabel
-				} else if newSeverityLabel == labels.SeverityLow && severityLabel != labels.SeverityCritical && severityLabel != labels.SeverityImportant && severityLabel != labels.SeverityModerate {
-					severityLabel = newSeverityLabel
-				} else if newSeverityLabel == informationalSeverity && severityLabel == "" {
-					severityLabel = newSeverityLabel
+				// the same issue may be both a dependent and a blocker of the bug, so only fetch each linked issue once
+				linkedIssues := map[string]*jira.Issue{}
+				getLinkedIssue := func(key string) (*jira.Issue, error) {
+					if linked, ok := linkedIssues[key]; ok {
+						return linked, nil
+					}
+					linked, err := jc.GetIssue(key)
+					if err != nil {
+						return nil, err
+					}
+					linkedIssues[key] = linked
+					return linked, nil
 				}
 
 				var dependents []dependent
-				if branchOptions.DependentBugStates != nil || branchOptions.DependentBugTargetVersions != nil {
+				if branchOptions.DependentBugStates != nil || branchOptions.DependentBugTargetVersions != nil || autoQEApprove {
 					for _, link := range issue.Fields.IssueLinks {
 						// identify if bug depends on this link; multiple different types of links may be blocker types; more can be added as they are identified
 						dependsOn := false
@@ -560,13 +995,13 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 							linkIssue = link.OutwardIssue
 						}
 						// the issue in the link is very trimmed down; get full link for dependentIssue list
-						dependentIssue, err := jc.GetIssue(linkIssue.Key)
+						dependentIssue, err := getLinkedIssue(linkIssue.Key)
 						if err != nil {
-							return comment(formatError(fmt.Sprintf("searching for dependent bug %s", linkIssue.Key), jc.JiraURL(), refIssue.Key(), err))
+							return comment(formatError(branchOptions, fmt.Sprintf("searching for dependent bug %s", linkIssue.Key), jc.JiraURL(), refIssue.Key(), err))
 						}
 						targetVersion, err := helpers.GetIssueTargetVersion(dependentIssue)
 						if err != nil {
-							return comment(formatError(fmt.Sprintf("failed to get target version for %s", dependentIssue.Key), jc.JiraURL(), refIssue.Key(), err))
+							return comment(formatError(branchOptions, fmt.Sprintf("failed to get target version for %s", dependentIssue.Key), jc.JiraURL(), refIssue.Key(), err))
 						}
 						var targetVersionString *string
 						if len(targetVersion) != 0 {
@@ -588,90 +1023,238 @@ func handle(jc jiraclient.Client, ghc githubClient, inserter BigQueryInserter, r
 					}
 				}
 
-				valid, passes, fails := validateBug(issue, dependents, branchOptions, jc.JiraURL())
+				var blockers []dependent
+				if branchOptions.RequireBlockersResolved != nil && *branchOptions.RequireBlockersResolved {
+					for _, link := range issue.Fields.IssueLinks {
+						// only inward links of the Blocks type point at bugs blocking this one
+						if link.InwardIssue == nil || link.Type.Name != "Blocks" || link.Type.Inward != "is blocked by" {
+							continue
+						}
+						// the issue in the link is very trimmed down; get the full issue to determine its resolution
+						blockerIssue, err := getLinkedIssue(link.InwardIssue.Key)
+						if err != nil {
+							return comment(formatError(branchOptions, fmt.Sprintf("searching for blocker bug %s", link.InwardIssue.Key), jc.JiraURL(), refIssue.Key(), err))
+						}
+						blockers = append(blockers, dependent{
+							key:      blockerIssue.Key,
+							bugState: JiraBugState{Status: issueStatus(blockerIssue), Resolution: issueResolution(blockerIssue)},
+						})
+					}
+				}
+
+				var linkedIssue string
+				if branchOptions.RequireLinkedIssueType != nil {
+					linkedIssue, err = findLinkedIssue(jc, issue, *branchOptions.RequireLinkedIssueType)
+					if err != nil {
+						return comment(formatError(branchOptions, "searching for linked issues", jc.JiraURL(), refIssue.Key(), err))
+					}
+				}
+
+				if autoQEApprove {
+					for _, dep := range dependents {
+						if strings.EqualFold(dep.bugState.Status, status.Verified) {
+							dependentsVerified = true
+						} else {
+							dependentsRegressed = true
+						}
+					}
+				}
+
+				valid, incomplete, passes, fails := validateBug(issue, dependents, blockers, branchOptions, jc.JiraURL())
+				if requiredLabels := statusRequiredLabels(issue, branchOptions); len(requiredLabels) > 0 {
+					if prLabels, err := ghc.GetIssueLabels(e.org, e.repo, e.number); err != nil {
+						log.WithError(err).Warn("Could not list labels on PR")
+					} else if err := validateStatusRequiredLabels(issue, requiredLabels, prLabels); err != nil {
+						valid = false
+						fails = append(fails, err.Error())
+					} else {
+						passes = append(passes, fmt.Sprintf("pull request has the label(s) `%s` required for bugs in the %s status", strings.Join(requiredLabels, "`, `"), issueStatus(issue)))
+					}
+				}
+				if divergentTargetVersions != "" {
+					valid = false
+					fails = append(fails, "expected all bugs referenced by this pull request to target the same version, but they target: "+divergentTargetVersions)
+				}
+				if branchOptions.WarnSelfReportedFix != nil && *branchOptions.WarnSelfReportedFix && reportedByAuthor(ghc, e, issue, log) {
+					warnings = append(warnings, fmt.Sprintf(issueLink+" was reported by the author of this pull request, @%s. Self-reported fixes may need additional review.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), e.author))
+				}
+				if branchOptions.RequireLinkedIssueType != nil {
+					if linkedIssue == "" {
+						valid = false
+						fails = append(fails, fmt.Sprintf("expected the bug to be linked to an issue of type %s, but no such issue is linked", *branchOptions.RequireLinkedIssueType))
+					} else {
+						passes = append(passes, fmt.Sprintf("bug is linked to the %s issue "+issueLink, *branchOptions.RequireLinkedIssueType, linkedIssue, jc.JiraURL(), linkedIssue))
+					}
+				}
+				recordValidation(e, valid)
+				if branchOptions.NeedsInformationLabel != nil && *branchOptions.NeedsInformationLabel {
+					severity, err := helpers.GetIssueSeverity(issue)
+					if err != nil {
+						return err
+					}
+					needsInformationLabel = needsInformationLabel || incomplete || severity == nil
+				}
 				if !needsJiraInvalidBugLabel {
 					needsJiraValidBugLabel, needsJiraInvalidBugLabel = valid, !valid
 				}
 				if valid {
 					log.Debug("Valid bug found.")
-					response += fmt.Sprintf(`This pull request references `+issueLink+`, which is valid.`, refIssue.Key(), jc.JiraURL(), refIssue.Key())
+					templated, hasTemplate := renderCommentTemplate(branchOptions, commentTemplateValid, commentTemplateData{Key: refIssue.Key(), URL: issueURL(jc.JiraURL(), refIssue.Key()), Validations: passes}, log)
+					if hasTemplate {
+						response += templated
+					} else {
+						response += fmt.Sprintf(`This pull request references `+issueLink+`, which is valid.`, refIssue.Key(), jc.JiraURL(), refIssue.Key())
+					}
 					// if configured, move the bug to the new state
-					if branchOptions.StateAfterValidation != nil {
+					moveAfterValidation := branchOptions.StateAfterValidation != nil && !transitionsDisabled(branchOptions)
+					if moveAfterValidation && skipTransitions && branchOptions.StateAfterValidation.Status != "" && (issue.Fields.Status == nil || !strings.EqualFold(branchOptions.StateAfterValidation.Status, issue.Fields.Status.Name)) {
+						response += fmt.Sprintf(" The bug will be moved to the %s state once this pull request is no longer a draft.", branchOptions.StateAfterValidation)
+					} else if moveAfterValidation {
 						if branchOptions.StateAfterValidation.Status != "" && (issue.Fields.Status == nil || !strings.EqualFold(branchOptions.StateAfterValidation.Status, issue.Fields.Status.Name)) {
+							var fieldChanges []fieldChange
+							oldStatus := issueStatus(issue)
 							if err := jc.UpdateStatus(issue.ID, branchOptions.StateAfterValidation.Status); err != nil {
 								log.WithError(err).Warn("Unexpected error updating jira issue.")
-								return comment(formatError(fmt.Sprintf("updating to the %s state", branchOptions.StateAfterValidation.Status), jc.JiraURL(), refIssue.Key(), err))
+								return comment(formatError(branchOptions, fmt.Sprintf("updating to the %s state", branchOptions.StateAfterValidation.Status), jc.JiraURL(), refIssue.Key(), err))
 							}
+							recordTransition(e, branchOptions.StateAfterValidation.Status)
+							recordAudit(log, e, auditActionTransition, issue.Key, oldStatus, branchOptions.StateAfterValidation.Status)
+							fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: branchOptions.StateAfterValidation.Status})
 							if branchOptions.StateAfterValidation.Resolution != "" && (issue.Fields.Resolution == nil || !strings.EqualFold(branchOptions.StateAfterValidation.Resolution, issue.Fields.Resolution.Name)) {
+								oldResolution := issueResolution(issue)
 								updateIssue := jira.Issue{Key: issue.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: branchOptions.StateAfterValidation.Resolution}}}
 								if _, err := jc.UpdateIssue(&updateIssue); err != nil {
 									log.WithError(err).Warn("Unexpected error updating jira issue.")
-									return comment(formatError(fmt.Sprintf("updating to the %s resolution", branchOptions.StateAfterValidation.Resolution), jc.JiraURL(), refIssue.Key(), err))
+									return comment(formatError(branchOptions, fmt.Sprintf("updating to the %s resolution", branchOptions.StateAfterValidation.Resolution), jc.JiraURL(), refIssue.Key(), err))
 								}
+								recordAudit(log, e, auditActionResolution, issue.Key, oldResolution, branchOptions.StateAfterValidation.Resolution)
+								fieldChanges = append(fieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: branchOptions.StateAfterValidation.Resolution})
 							}
 							response += fmt.Sprintf(" The bug has been moved to the %s state.", branchOptions.StateAfterValidation)
+							response += fieldChangesComment(branchOptions, refIssue.Key(), fieldChanges)
 						}
 					}
 
-					response += "\n\n<details>"
-					if len(passes) == 0 {
-						response += "<summary>No validations were run on this bug</summary>"
-					} else {
-						response += fmt.Sprintf("<summary>%d validation(s) were run on this bug</summary>\n", len(passes))
+					if branchOptions.AddJiraCommentOnValidation != nil && *branchOptions.AddJiraCommentOnValidation {
+						if err := addValidationComment(jc, issue, e, branchOptions, log); err != nil {
+							log.WithError(err).Warn("Failed to comment on the Jira bug.")
+							warnings = append(warnings, fmt.Sprintf("the comment linking "+issueLink+" to this pull request could not be added to the bug.", refIssue.Key(), jc.JiraURL(), refIssue.Key()))
+						}
 					}
-					for _, validation := range passes {
-						response += fmt.Sprint("\n* ", validation)
+
+					if !hasTemplate {
+						response += "\n\n<details>"
+						if len(passes) == 0 {
+							response += "<summary>No validations were run on this bug</summary>"
+						} else {
+							response += fmt.Sprintf("<summary>%d validation(s) were run on this bug</summary>\n", len(passes))
+						}
+						for _, validation := range passes {
+							response += fmt.Sprint("\n* ", validation)
+						}
+						response += "</details>"
 					}
-					response += "</details>"
 
-					qaContactDetail, err := helpers.GetIssueQaContact(issue)
-					if err != nil {
-						return comment(formatError("processing qa contact information for the bug", jc.JiraURL(), refIssue.Key(), err))
+					if branchOptions.ShowLinkedPRs != nil && *branchOptions.ShowLinkedPRs {
+						if message, err := linkedPullRequestsMessage(jc, issue); err != nil {
+							log.WithError(err).Warn("Failed to list the pull requests linked to the bug.")
+							warnings = append(warnings, fmt.Sprintf("the pull requests linked to "+issueLink+" could not be listed.", refIssue.Key(), jc.JiraURL(), refIssue.Key()))
+						} else {
+							response += "\n\n" + message
+						}
 					}
-					if qaContactDetail == nil {
-						if e.cc {
-							response += fmt.Sprintf(issueLink+" does not have a QA contact, skipping assignment", refIssue.Key(), jc.JiraURL(), refIssue.Key())
+
+					// when the QA contact is requested automatically, problems finding them are only warnings
+					autoCCQA := !e.cc && branchOptions.AutoCCQA != nil && *branchOptions.AutoCCQA
+					qaContacts, err := helpers.GetIssueQaContacts(issue)
+					if err != nil {
+						if !autoCCQA {
+							return comment(formatError(branchOptions, "processing qa contact information for the bug", jc.JiraURL(), refIssue.Key(), err))
 						}
-					} else if qaContactDetail.EmailAddress == "" {
+						log.WithError(err).Warn("Failed to process QA contact information for the bug.")
+						warnings = append(warnings, fmt.Sprintf("the QA contact for "+issueLink+" could not be processed, skipping review request.", refIssue.Key(), jc.JiraURL(), refIssue.Key()))
+					} else if len(qaContacts) == 0 {
 						if e.cc {
-							response += fmt.Sprintf("QA contact for "+issueLink+" does not have a listed email, skipping assignment", refIssue.Key(), jc.JiraURL(), refIssue.Key())
+							response += fmt.Sprintf("\n\nNo QA contact is set on "+issueLink+", skipping review request.", refIssue.Key(), jc.JiraURL(), refIssue.Key())
+						} else if autoCCQA {
+							warnings = append(warnings, fmt.Sprintf(issueLink+" does not have a QA contact, skipping review request.", refIssue.Key(), jc.JiraURL(), refIssue.Key()))
 						}
 					} else {
-						query := &emailToLoginQuery{}
-						email := qaContactDetail.EmailAddress
-						queryVars := map[string]any{
-							"email": githubql.String(email),
+						// request review from every QA contact that maps to exactly one GitHub user and report the rest
+						logins := sets.New[string]()
+						var unmapped []string
+						for _, qaContact := range qaContacts {
+							if qaContact.EmailAddress == "" {
+								// name the contact when there are several, so it is clear which one is missing an email
+								var name string
+								if len(qaContacts) > 1 {
+									name = " " + qaContact.DisplayName
+								}
+								if e.cc {
+									response += fmt.Sprintf("\n\nThe QA contact%s for "+issueLink+" does not have a listed email, skipping review request.", name, refIssue.Key(), jc.JiraURL(), refIssue.Key())
+								} else if autoCCQA {
+									warnings = append(warnings, fmt.Sprintf("the QA contact%s for "+issueLink+" does not have a listed email, skipping review request.", name, refIssue.Key(), jc.JiraURL(), refIssue.Key()))
+								}
+								continue
+							}
+							query := &emailToLoginQuery{}
+							email := qaContact.EmailAddress
+							queryVars := map[string]any{
+								"email": githubql.String(email),
+							}
+							err := ghc.QueryWithGitHubAppsSupport(context.Background(), query, queryVars, e.org)
+							if err != nil {
+								log.WithError(err).Error("Failed to run graphql github query")
+								if !autoCCQA {
+									return comment(formatError(branchOptions, fmt.Sprintf("querying GitHub for users with public email (%s)", email), jc.JiraURL(), refIssue.Key(), err))
+								}
+								warnings = append(warnings, fmt.Sprintf("GitHub could not be queried for users with the public email listed for the QA contact in Jira (%s), skipping review request.", email))
+							} else if len(query.Search.Edges) == 1 {
+								logins.Insert(string(query.Search.Edges[0].Node.User.Login))
+							} else {
+								unmapped = append(unmapped, processQuery(query, email))
+							}
 						}
-						err := ghc.QueryWithGitHubAppsSupport(context.Background(), query, queryVars, e.org)
-						if err != nil {
-							log.WithError(err).Error("Failed to run graphql github query")
-							return comment(formatError(fmt.Sprintf("querying GitHub for users with public email (%s)", email), jc.JiraURL(), refIssue.Key(), err))
+						if logins.Len() > 0 {
+							response += fmt.Sprint("\n\n", requestQAReview(sets.List(logins)))
+						}
+						for _, message := range unmapped {
+							response += fmt.Sprint("\n\n", message)
 						}
-						response += fmt.Sprint("\n\n", processQuery(query, email))
 					}
 				} else {
 					log.Debug("Invalid bug found.")
-					var formattedReasons string
 					for _, reason := range fails {
-						formattedReasons += fmt.Sprintf(" - %s\n", reason)
+						invalidReasons = append(invalidReasons, fmt.Sprintf("%s: %s", refIssue.Key(), reason))
 					}
-					response += fmt.Sprintf(`This pull request references `+issueLink+`, which is invalid:
+					if templated, hasTemplate := renderCommentTemplate(branchOptions, commentTemplateInvalid, commentTemplateData{Key: refIssue.Key(), URL: issueURL(jc.JiraURL(), refIssue.Key()), Validations: fails}, log); hasTemplate {
+						response += templated
+					} else {
+						var formattedReasons string
+						for _, reason := range fails {
+							formattedReasons += fmt.Sprintf(" - %s\n", reason)
+						}
+						response += fmt.Sprintf(invalidBugComment+`
 %s
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.`, refIssue.Key(), jc.JiraURL(), refIssue.Key(), formattedReasons)
+%s`, refIssue.Key(), jc.JiraURL(), refIssue.Key(), formattedReasons, refreshHint(branchOptions, "Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug."))
+					}
 				}
 
 				if branchOptions.AddExternalLink != nil && *branchOptions.AddExternalLink {
-					changed, err := upsertGitHubLinkToIssue(log, issue.ID, jc, e)
+					changed, retitled, err := upsertGitHubLinkToIssue(log, issue, jc, branchOptions, e)
 					if err != nil {
 						log.WithError(err).Warn("Unexpected error adding external tracker bug to Jira bug.")
-						return comment(formatError("adding this pull request to the external tracker bugs", jc.JiraURL(), refIssue.Key(), err))
+						return comment(formatError(branchOptions, "adding this pull request to the external tracker bugs", jc.JiraURL(), refIssue.Key(), err))
 					}
-					if changed {
+					if retitled {
+						response += "\n\nThe title of the external bug tracker link on the bug has been updated to match the title of this pull request."
+					} else if changed {
 						response += "\n\nThe bug has been updated to refer to the pull request using the external bug tracker."
 					}
 				}
 			}
 		}
+		response += formatWarnings(warnings)
 	} else {
 		needsJiraValidRefLabel = true
 		response = "This pull request explicitly references no jira issue."
@@ -684,8 +1267,13 @@ Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jir
 	if err != nil {
 		log.WithError(err).Warn("Could not list labels on PR")
 	}
-	var hasJiraValidBugLabel, hasJiraValidRefLabel, hasJiraInvalidBugLabel bool
-	var severityLabelToRemove string
+	var hasJiraValidBugLabel, hasJiraValidRefLabel, hasJiraInvalidBugLabel, hasNeedsInformationLabel, hasQEApprovedLabel bool
+	var severityLabel, severityLabelToRemove string
+	knownSeverityLabels := sets.New[string]()
+	if !disableSeverityLabels {
+		severityLabel = getSeverityLabel(highestSeverity, branchOptions.SeverityLabels)
+		knownSeverityLabels = severityLabels(branchOptions.SeverityLabels)
+	}
 	for _, l := range currentLabels {
 		if l.Name == labels.JiraValidBug {
 			hasJiraValidBugLabel = true
@@ -696,20 +1284,22 @@ Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jir
 		if l.Name == labels.JiraValidRef {
 			hasJiraValidRefLabel = true
 		}
+		if l.Name == labels.NeedsInformation {
+			hasNeedsInformationLabel = true
+		}
+		if l.Name == labels.QEApproved {
+			hasQEApprovedLabel = true
+		}
 
-		if l.Name == labels.SeverityCritical ||
-			l.Name == labels.SeverityImportant ||
-			l.Name == labels.SeverityModerate ||
-			l.Name == labels.SeverityLow ||
-			l.Name == labels.SeverityInformational {
+		if knownSeverityLabels.Has(l.Name) {
 			severityLabelToRemove = l.Name
 		}
 	}
 
 	// on missing issue, comment only on explicit commands and on label removal.
 	if e.missing && (e.refresh || e.cc || hasJiraInvalidBugLabel || hasJiraValidBugLabel || hasJiraValidRefLabel) {
-		response = `No Jira issue is referenced in the title of this pull request.
-To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and request another refresh with <code>/jira refresh</code>.`
+		response = "No Jira issue is referenced in the title of this pull request.\n" +
+			refreshHint(branchOptions, "To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and request another refresh with <code>/jira refresh</code>.")
 	} else if !e.noJira && len(invalidIssues) != 0 && (e.refresh || e.cc || hasJiraInvalidBugLabel || hasJiraValidBugLabel) {
 		// if the user attempted to reference a jira key, but we couldn't find the key in jira, give feedback to the user.
 		response = fmt.Sprintf("The referenced Jira(s) %v could not be located, all automatically applied jira labels will be removed.", invalidIssues)
@@ -795,6 +1385,9 @@ To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and
 			log.WithError(err).Error("Failed to add invalid bug label.")
 		}
 		labelsChanged = true
+		if len(invalidReasons) > 0 {
+			notifySlack(notifier, branchOptions, invalidBugSlackMessage(e, invalidReasons), log)
+		}
 	} else if !needsJiraInvalidBugLabel && hasJiraInvalidBugLabel {
 		if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.JiraInvalidBug); err != nil {
 			log.WithError(err).Error("Failed to remove invalid bug label.")
@@ -802,6 +1395,59 @@ To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and
 		labelsChanged = true
 	}
 
+	manageNeedsInformationLabel := branchOptions.NeedsInformationLabel != nil && *branchOptions.NeedsInformationLabel
+	if manageNeedsInformationLabel && needsInformationLabel && !hasNeedsInformationLabel {
+		if err := ghc.AddLabel(e.org, e.repo, e.number, labels.NeedsInformation); err != nil {
+			log.WithError(err).Error("Failed to add needs information label.")
+		}
+		labelsChanged = true
+	} else if manageNeedsInformationLabel && !needsInformationLabel && hasNeedsInformationLabel {
+		if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.NeedsInformation); err != nil {
+			log.WithError(err).Error("Failed to remove needs information label.")
+		}
+		labelsChanged = true
+	}
+
+	if autoQEApprove && dependentsVerified && !dependentsRegressed && !hasQEApprovedLabel {
+		if err := ghc.AddLabel(e.org, e.repo, e.number, labels.QEApproved); err != nil {
+			log.WithError(err).Error("Failed to add QE approved label.")
+		}
+		labelsChanged = true
+		response += fmt.Sprintf("\n\nAdding the %s label as all dependent bugs are VERIFIED.", labels.QEApproved)
+	} else if autoQEApprove && dependentsRegressed && hasQEApprovedLabel {
+		humanLabelled, err := ghc.WasLabelAddedByHuman(e.org, e.repo, e.number, labels.QEApproved)
+		if err != nil {
+			// Return rather than potentially doing the wrong thing. The user can re-trigger us.
+			return fmt.Errorf("failed to check if %s label was added by a human: %w", labels.QEApproved, err)
+		}
+		if humanLabelled {
+			response += fmt.Sprintf("\n\nRetaining the %s label as it was manually added.", labels.QEApproved)
+		} else {
+			if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.QEApproved); err != nil {
+				log.WithError(err).Error("Failed to remove QE approved label.")
+			}
+			labelsChanged = true
+			response += fmt.Sprintf("\n\nRemoving the %s label as not all dependent bugs are VERIFIED.", labels.QEApproved)
+		}
+	}
+
+	if branchOptions.PublishStatus != nil && *branchOptions.PublishStatus {
+		publishValidationStatus(ghc, e, needsJiraInvalidBugLabel, needsJiraValidBugLabel, log)
+	}
+
+	// once the bug is valid, the earlier comments explaining why it was invalid only add noise
+	if hasJiraInvalidBugLabel && !needsJiraInvalidBugLabel && needsJiraValidBugLabel {
+		deleteInvalidBugComments(ghc, e, log)
+	}
+
+	// when configured, automatic events only update the labels and the validity comment waits for an explicit command,
+	// unless errors, warnings or issues that could not be found need to be reported
+	reportsProblems := responseHasErrors || len(warnings) != 0 || len(invalidIssues) != 0
+	if branchOptions.CommentOnlyOnRefresh != nil && *branchOptions.CommentOnlyOnRefresh && !e.refresh && !e.cc && !reportsProblems {
+		log.Debug("Skipping validity comment as comments are only posted on refresh.")
+		return nil
+	}
+
 	var duplicateComment bool
 	// we always want to comment if the labels changed or a refresh was manually triggered
 	if !labelsChanged && !e.refresh {
@@ -809,28 +1455,11 @@ To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and
 		if err != nil {
 			log.WithError(err).Error("Failed to list issue comments.")
 		} else {
-			// comments are returned in order of ID, which is oldest first. Reverse it
-			for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
-				comments[i], comments[j] = comments[j], comments[i]
-			}
 			isBot, err := ghc.BotUserChecker()
 			if err != nil {
 				log.WithError(err).Error("Failed to create bot user checker.")
 			} else {
-				var lastBotComment *github.IssueComment
-				for _, comment := range comments {
-					if isBot(comment.User.Login) {
-						lastBotComment = &comment
-						break
-					}
-				}
-				if lastBotComment != nil {
-					// the comment function prepends the user and appends details (which may be different for different events),
-					// so we can't do an exact match. A `strings.Contains` should be good enough
-					if strings.Contains(lastBotComment.Body, response) {
-						duplicateComment = true
-					}
-				}
+				duplicateComment = !shouldPostComment(comments, isBot, response)
 			}
 		}
 	}
@@ -841,15 +1470,75 @@ To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and
 	return nil
 }
 
-// getSimplifiedSeverity retrieves the severity of the issue and trims the image tags that precede
-// the name of the severity, which are a nuisance for automation
-func getSimplifiedSeverity(issue *jira.Issue) (string, error) {
-	severity, err := helpers.GetIssueSeverity(issue)
+// shouldPostComment determines whether the body should be posted given the existing comments on the pull
+// request, which are ordered oldest first. Only the latest comment by the bot is considered, so a response
+// that was posted earlier but has since been superseded by a different bot comment is posted again.
+func shouldPostComment(comments []github.IssueComment, isBot func(string) bool, body string) bool {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if !isBot(comments[i].User.Login) {
+			continue
+		}
+		// the comment function prepends the user and appends details (which may be different for different events),
+		// so we can't do an exact match. A `strings.Contains` should be good enough
+		return !strings.Contains(comments[i].Body, body)
+	}
+	return true
+}
+
+// publishValidationStatus sets a commit status on the head of the pull request that mirrors the bug validity labels.
+// Errors are only logged, as the labels and comment already report the result.
+func publishValidationStatus(ghc githubClient, e event, invalid, valid bool, log *logrus.Entry) {
+	status := github.Status{State: github.StatusSuccess, Context: validBugStatusContext, Description: "No Jira bugs are referenced in the title of this pull request."}
+	switch {
+	case invalid:
+		status.State = github.StatusFailure
+		status.Description = "A Jira bug referenced in the title of this pull request is invalid."
+	case valid:
+		status.Description = "All Jira bugs referenced in the title of this pull request are valid."
+	}
+	pr, err := ghc.GetPullRequest(e.org, e.repo, e.number)
 	if err != nil {
-		return "", fmt.Errorf("failed to get severity of issue %s", issue.Key)
+		log.WithError(err).Error("Failed to get pull request to publish validation status.")
+		return
 	}
-	if severity == nil {
-		return "unset", nil
+	if err := ghc.CreateStatus(e.org, e.repo, pr.Head.SHA, status); err != nil {
+		log.WithError(err).Error("Failed to publish validation status.")
+	}
+}
+
+// deleteInvalidBugComments removes the comments previously posted by the bot that explained why the
+// referenced bug was invalid. Errors are only logged, as the stale comments do not affect validity.
+func deleteInvalidBugComments(ghc commentClient, e event, log *logrus.Entry) {
+	comments, err := ghc.ListIssueComments(e.org, e.repo, e.number)
+	if err != nil {
+		log.WithError(err).Error("Failed to list issue comments.")
+		return
+	}
+	isBot, err := ghc.BotUserChecker()
+	if err != nil {
+		log.WithError(err).Error("Failed to create bot user checker.")
+		return
+	}
+	invalidPrefix, invalidSuffix, _ := strings.Cut(invalidBugComment, issueLink)
+	for _, comment := range comments {
+		if !isBot(comment.User.Login) || !strings.Contains(comment.Body, invalidPrefix) || !strings.Contains(comment.Body, invalidSuffix) {
+			continue
+		}
+		if err := ghc.DeleteComment(e.org, e.repo, comment.ID); err != nil {
+			log.WithError(err).WithField("comment", comment.ID).Error("Failed to delete stale invalid bug comment.")
+		}
+	}
+}
+
+// getSimplifiedSeverity retrieves the severity of the issue and trims the image tags that precede
+// the name of the severity, which are a nuisance for automation
+func getSimplifiedSeverity(issue *jira.Issue) (string, error) {
+	severity, err := helpers.GetIssueSeverity(issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to get severity of issue %s", issue.Key)
+	}
+	if severity == nil {
+		return "unset", nil
 	}
 	// the values of the severity fields in redhat jira have an image before them
 	// (ex: <img alt=\"\" src=\"/images/icons/priorities/medium.svg\" width=\"16\" height=\"16\"> Medium)
@@ -859,6 +1548,30 @@ func getSimplifiedSeverity(issue *jira.Issue) (string, error) {
 	return splitSeverity[len(splitSeverity)-1], nil
 }
 
+// transitionsDisabled determines whether the plugin must never move bugs to a new state on the branch
+func transitionsDisabled(options JiraBranchOptions) bool {
+	return options.DisableTransitions != nil && *options.DisableTransitions
+}
+
+// validatesByDefault determines whether pull requests are validated by default on the branch
+func validatesByDefault(options JiraBranchOptions) bool {
+	return options.ValidateByDefault != nil && *options.ValidateByDefault
+}
+
+// isBelowSeverity determines whether the severity of the issue ranks below the minimum severity in the
+// given ranking. Issues with an unset or unknown severity are not considered to be below it.
+func isBelowSeverity(issue *jira.Issue, minimum string, ranking []string) (bool, error) {
+	severity, err := getSimplifiedSeverity(issue)
+	if err != nil {
+		return false, err
+	}
+	rank, minimumRank := slices.Index(ranking, severity), slices.Index(ranking, minimum)
+	if rank == -1 || minimumRank == -1 {
+		return false, nil
+	}
+	return rank > minimumRank, nil
+}
+
 func isPreMergeVerified(issue *jira.Issue, prLabels []github.Label) bool {
 	var hasLabel, hasFixVersions, hasAffectsVersions bool
 	for _, label := range prLabels {
@@ -884,15 +1597,96 @@ func isPreMergeVerified(issue *jira.Issue, prLabels []github.Label) bool {
 	return hasLabel && hasFixVersions && hasAffectsVersions
 }
 
-func isCommentVerified(prLabels []github.Label) bool {
+// verifiedLabel returns the label that marks pull requests as verified on the branch
+func verifiedLabel(options JiraBranchOptions) string {
+	if options.VerifiedLabel != nil {
+		return *options.VerifiedLabel
+	}
+	return labels.Verified
+}
+
+// verifiedLaterLabel returns the label that marks pull requests as to be verified later on the branch
+func verifiedLaterLabel(options JiraBranchOptions) string {
+	if options.VerifiedLaterLabel != nil {
+		return *options.VerifiedLaterLabel
+	}
+	return labels.VerifiedLater
+}
+
+func isCommentVerified(prLabels []github.Label, verified string) bool {
 	for _, label := range prLabels {
-		if label.Name == labels.Verified {
+		if label.Name == verified {
 			return true
 		}
 	}
 	return false
 }
 
+// pushedFiles lists the files changed by the commits pushed to the pull request in the event. When the head of
+// the pull request before the push is not known or is no longer one of its commits, as after a force push, every
+// file changed by the pull request is listed instead.
+func pushedFiles(ghc githubClient, e event) ([]string, error) {
+	if e.beforeSHA != "" && e.afterSHA != "" {
+		commits, err := ghc.ListPullRequestCommits(e.org, e.repo, e.number)
+		if err != nil {
+			return nil, err
+		}
+		if start := slices.IndexFunc(commits, func(commit github.RepositoryCommit) bool { return commit.SHA == e.beforeSHA }); start != -1 {
+			var files []string
+			for _, commit := range commits[start+1:] {
+				details, err := ghc.GetSingleCommit(e.org, e.repo, commit.SHA)
+				if err != nil {
+					return nil, err
+				}
+				for _, file := range details.Files {
+					files = append(files, file.Filename)
+				}
+				if commit.SHA == e.afterSHA {
+					break
+				}
+			}
+			return files, nil
+		}
+	}
+	changes, err := ghc.GetPullRequestChanges(e.org, e.repo, e.number)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, change := range changes {
+		files = append(files, change.Filename)
+	}
+	return files, nil
+}
+
+// onlyIgnoredPathsChanged determines whether every changed file matches one of the ignored path globs
+func onlyIgnoredPathsChanged(changes []string, ignoredPaths []string) bool {
+	if len(changes) == 0 {
+		return false
+	}
+	for _, change := range changes {
+		ignored := slices.ContainsFunc(ignoredPaths, func(pattern string) bool {
+			matched, err := path.Match(pattern, change)
+			return err == nil && matched
+		})
+		if !ignored {
+			return false
+		}
+	}
+	return true
+}
+
+// isPRVerified determines whether a pull request has all labels required to move bugs to VERIFIED on merge
+func isPRVerified(prLabels []github.Label, options JiraBranchOptions) bool {
+	if !isCommentVerified(prLabels, verifiedLabel(options)) {
+		return false
+	}
+	if options.RequireApprovalForVerified != nil && *options.RequireApprovalForVerified {
+		return isCommentVerified(prLabels, labels.LGTM) && isCommentVerified(prLabels, labels.Approved)
+	}
+	return true
+}
+
 type line struct {
 	content   string
 	replacing bool
@@ -935,7 +1729,7 @@ func insertLinksIntoComment(body string, issueNames []string, jiraBaseURL string
 
 func insertLinksIntoLine(line string, issueNames []string, jiraBaseURL string) string {
 	for _, issue := range issueNames {
-		replacement := fmt.Sprintf("[%s](%s/browse/%s)", issue, jiraBaseURL, issue)
+		replacement := fmt.Sprintf("[%s](%s)", issue, issueURL(jiraBaseURL, issue))
 		line = replaceStringIfNeeded(line, issue, replacement)
 	}
 	return line
@@ -995,23 +1789,26 @@ func prURLFromCommentURL(url string) string {
 
 // upsertGitHubLinkToIssue adds a remote link to the github issue on the jira issue. It returns a bool indicating whether or not the
 // remote link changed or was created, and an error.
-func upsertGitHubLinkToIssue(log *logrus.Entry, issueID string, jc jiraclient.Client, e event) (bool, error) {
-	links, err := jc.GetRemoteLinks(issueID)
+// upsertGitHubLinkToIssue ensures the issue links to the pull request, returning whether the link was changed
+// and whether the change was to update the title of a stale existing link.
+func upsertGitHubLinkToIssue(log *logrus.Entry, issue *jira.Issue, jc jiraclient.Client, options JiraBranchOptions, e event) (bool, bool, error) {
+	links, err := jc.GetRemoteLinks(issue.ID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get remote links: %w", err)
+		return false, false, fmt.Errorf("failed to get remote links: %w", err)
 	}
 
 	url := prURLFromCommentURL(e.htmlUrl)
-	title := fmt.Sprintf("%s/%s#%d: %s", e.org, e.repo, e.number, e.title)
+	title := remoteLinkTitle(options, e, log)
 	var existingLink *jira.RemoteLink
 
 	// Check if the same link exists already. We consider two links to be the same if the have the same URL.
 	// Once it is found we have two possibilities: either it is really equal (just skip the upsert) or it
-	// has to be updated (perform an upsert)
+	// has to be updated (perform an upsert). Differences in the icon are ignored, so that changing the
+	// configured icon does not update every existing link.
 	for _, link := range links {
 		if link.Object.URL == url {
 			if title == link.Object.Title {
-				return false, nil
+				return false, false, nil
 			}
 			link := link
 			existingLink = &link
@@ -1023,44 +1820,110 @@ func upsertGitHubLinkToIssue(log *logrus.Entry, issueID string, jc jiraclient.Cl
 		Object: &jira.RemoteLinkObject{
 			URL:   url,
 			Title: title,
-			Icon: &jira.RemoteLinkIcon{
-				Url16x16: "https://github.com/favicon.ico",
-				Title:    "GitHub",
-			},
+			Icon:  remoteLinkIcon(options),
 		},
 	}
 
 	if existingLink != nil {
+		oldTitle := existingLink.Object.Title
 		existingLink.Object = link.Object
-		if err := jc.UpdateRemoteLink(issueID, existingLink); err != nil {
-			return false, fmt.Errorf("failed to update remote link: %w", err)
+		if err := jc.UpdateRemoteLink(issue.ID, existingLink); err != nil {
+			return false, false, fmt.Errorf("failed to update remote link: %w", err)
 		}
 		log.Info("Updated jira link")
-	} else {
-		if _, err := jc.AddRemoteLink(issueID, link); err != nil {
-			return false, fmt.Errorf("failed to add remote link: %w", err)
-		}
-		log.Info("Created jira link")
+		recordAudit(log, e, auditActionRemoteLinkUpdate, issue.Key, oldTitle, title)
+		return true, true, nil
+	}
+	if _, err := jc.AddRemoteLink(issue.ID, link); err != nil {
+		return false, false, fmt.Errorf("failed to add remote link: %w", err)
 	}
+	log.Info("Created jira link")
+	recordAudit(log, e, auditActionRemoteLinkAdd, issue.Key, nil, url)
 
-	return true, nil
+	return true, false, nil
 }
 
 func (s *server) handlePullRequest(l *logrus.Entry, pre github.PullRequestEvent) {
 	cfg := s.config()
 	branchOptions := cfg.OptionsForBranch(pre.PullRequest.Base.Repo.Owner.Login, pre.PullRequest.Base.Repo.Name, pre.PullRequest.Base.Ref)
-	event, err := digestPR(l, pre, branchOptions.ValidateByDefault)
+	event, err := digestPR(l, pre, branchOptions, cfg.BugProjectSet())
 	if err != nil {
 		l.Errorf("failed to digest PR: %v", err)
 	}
+	if s.pullRequestHeads != nil {
+		before := s.pullRequestHeads.record(pre.Repo.Owner.Login, pre.Repo.Name, pre.Number, pre.PullRequest.Head.SHA, pre.Action == github.PullRequestActionClosed)
+		if event != nil && event.fileChanged {
+			event.beforeSHA = before
+		}
+	}
+	if event != nil && cfg.MaintenanceMode {
+		ghc := s.ghc
+		if s.dryRun {
+			ghc = newDryRunGitHubClient(ghc, l)
+		}
+		postMaintenanceNotice(ghc, *event, cfg.MaintenanceNotice, l)
+		return
+	}
 	if event != nil {
 		repoOptions := cfg.OptionsForRepo(event.org, event.repo)
-		if err := handle(s.jc, s.ghc, s.bigqueryInserter, repoOptions, branchOptions, l, *event, s.prowConfigAgent.Config().AllRepos); err != nil {
+		if err := handle(s.jc, s.ghc, s.bigqueryInserter, s.slackNotifier, repoOptions, branchOptions, l, *event, s.prowConfigAgent.Config().AllRepos, cfg.BugProjectSet(), s.dryRun); err != nil {
 			l.Errorf("failed to handle PR: %v", err)
 		}
 	}
 }
 
+func (s *server) handlePullRequestReview(l *logrus.Entry, re github.ReviewEvent) {
+	cfg := s.config()
+	branchOptions := cfg.OptionsForBranch(re.Repo.Owner.Login, re.Repo.Name, re.PullRequest.Base.Ref)
+	event := digestReview(l, re, branchOptions, cfg.BugProjectSet())
+	if event == nil {
+		return
+	}
+	if cfg.MaintenanceMode {
+		ghc := s.ghc
+		if s.dryRun {
+			ghc = newDryRunGitHubClient(ghc, l)
+		}
+		postMaintenanceNotice(ghc, *event, cfg.MaintenanceNotice, l)
+		return
+	}
+	repoOptions := cfg.OptionsForRepo(event.org, event.repo)
+	if err := handle(s.jc, s.ghc, s.bigqueryInserter, s.slackNotifier, repoOptions, branchOptions, l, *event, s.prowConfigAgent.Config().AllRepos, cfg.BugProjectSet(), s.dryRun); err != nil {
+		l.Errorf("failed to handle review: %v", err)
+	}
+}
+
+// digestReview creates the event for handle() when an approving review from one of the QE reviewers configured
+// for the branch marks the pull request as verified, and returns nil for all other reviews
+func digestReview(log *logrus.Entry, re github.ReviewEvent, options JiraBranchOptions, bugProjects sets.Set[string]) *event {
+	if re.Action != github.ReviewActionSubmitted || re.Review.State != github.ReviewStateApproved {
+		return nil
+	}
+	reviewer := re.Review.User.Login
+	if !verifiedCommandUserAllowed(options.QEReviewers, reviewer) {
+		log.Debugf("Ignoring approving review from %s, who is not a QE reviewer.", reviewer)
+		return nil
+	}
+	e := &event{
+		org:              re.Repo.Owner.Login,
+		repo:             re.Repo.Name,
+		baseRef:          re.PullRequest.Base.Ref,
+		number:           re.PullRequest.Number,
+		merged:           re.PullRequest.Merged,
+		draft:            re.PullRequest.Draft,
+		state:            re.PullRequest.State,
+		body:             re.Review.Body,
+		title:            re.PullRequest.Title,
+		htmlUrl:          re.Review.HTMLURL,
+		login:            reviewer,
+		author:           re.PullRequest.User.Login,
+		verify:           []string{"@" + reviewer},
+		verifiedByReview: true,
+	}
+	e.issues, e.missing, e.noJira = jiraKeysFromPullRequest(re.PullRequest.Title, re.PullRequest.Body, options, bugProjects)
+	return e
+}
+
 func getCherryPickMatch(pre github.PullRequestEvent) (bool, int, error) {
 	cherrypickMatch := cherrypickPRMatch.FindStringSubmatch(pre.PullRequest.Body)
 	if cherrypickMatch != nil {
@@ -1075,7 +1938,7 @@ func getCherryPickMatch(pre github.PullRequestEvent) (bool, int, error) {
 }
 
 // digestPR determines if any action is necessary and creates the objects for handle() if it is
-func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault *bool) (*event, error) {
+func digestPR(log *logrus.Entry, pre github.PullRequestEvent, options JiraBranchOptions, bugProjects sets.Set[string]) (*event, error) {
 	// These are the only actions indicating the PR title may have changed or that the PR merged or was closed
 	if pre.Action != github.PullRequestActionOpened &&
 		pre.Action != github.PullRequestActionReopened &&
@@ -1083,7 +1946,8 @@ func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault
 		pre.Action != github.PullRequestActionClosed &&
 		pre.Action != github.PullRequestActionLabeled &&
 		pre.Action != github.PullRequestActionUnlabeled &&
-		pre.Action != github.PullRequestActionSynchronize {
+		pre.Action != github.PullRequestActionSynchronize &&
+		pre.Action != github.PullRequestActionReadyForReview {
 		return nil, nil
 	}
 
@@ -1091,6 +1955,16 @@ func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault
 		return nil, nil
 	}
 
+	// a draft being marked ready for review only needs handling when the transitions of its bugs were skipped
+	if pre.Action == github.PullRequestActionReadyForReview && (options.SkipDrafts == nil || !*options.SkipDrafts) {
+		return nil, nil
+	}
+
+	if options.PullRequestActions != nil && !slices.Contains(*options.PullRequestActions, string(pre.Action)) {
+		log.Debugf("Ignoring the %s action as it is not one of the configured pull request actions.", pre.Action)
+		return nil, nil
+	}
+
 	var (
 		org     = pre.PullRequest.Base.Repo.Owner.Login
 		repo    = pre.PullRequest.Base.Repo.Name
@@ -1100,10 +1974,13 @@ func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault
 		body    = pre.PullRequest.Body
 	)
 
-	e := &event{org: org, repo: repo, baseRef: baseRef, number: number, merged: pre.PullRequest.Merged, closed: pre.Action == github.PullRequestActionClosed, opened: pre.Action == github.PullRequestActionOpened, state: pre.PullRequest.State, body: body, title: title, htmlUrl: pre.PullRequest.HTMLURL, login: pre.PullRequest.User.Login, fileChanged: pre.Action == github.PullRequestActionSynchronize}
+	e := &event{org: org, repo: repo, baseRef: baseRef, number: number, merged: pre.PullRequest.Merged, closed: pre.Action == github.PullRequestActionClosed, opened: pre.Action == github.PullRequestActionOpened, reopened: pre.Action == github.PullRequestActionReopened, state: pre.PullRequest.State, body: body, title: title, htmlUrl: pre.PullRequest.HTMLURL, login: pre.PullRequest.User.Login, author: pre.PullRequest.User.Login, fileChanged: pre.Action == github.PullRequestActionSynchronize, draft: pre.PullRequest.Draft}
+	if e.fileChanged {
+		e.afterSHA = pre.PullRequest.Head.SHA
+	}
 	// Make sure the PR title is referencing a bug
 	var err error
-	e.issues, e.missing, e.noJira = jiraKeyFromTitle(title)
+	e.issues, e.missing, e.noJira = jiraKeysFromPullRequest(title, body, options, bugProjects)
 
 	// Check if PR is a cherrypick
 	cherrypick, cherrypickFromPRNum, err := getCherryPickMatch(pre)
@@ -1128,12 +2005,19 @@ func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault
 
 	// when exiting early from errors trying to find out if the PR previously referenced a bug,
 	// we want to handle the event only if a bug is currently referenced or we are validating by
-	// default
+	// default. When validation by default depends on the severity of the bug, that decision is
+	// made in handle once the bug has been fetched, so pull requests without a bug are skipped.
 	var intermediate *event
-	if !e.missing || (validateByDefault != nil && *validateByDefault) {
+	if !e.missing || (validatesByDefault(options) && options.ValidateByDefaultMinimumSeverity == nil) {
 		intermediate = e
 	}
 
+	// the external links to the PR were removed from the referenced bugs when it was closed, so
+	// a reopened PR needs to be handled to restore them even though its title did not change
+	if e.reopened {
+		return intermediate, nil
+	}
+
 	// Check if the previous version of the title referenced a bug.
 	var changes struct {
 		Title struct {
@@ -1144,7 +2028,7 @@ func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault
 		// we're detecting this best-effort so we can handle it anyway
 		return intermediate, nil
 	}
-	prevIds, missing, _ := jiraKeyFromTitle(changes.Title.From)
+	prevIds, missing, _ := jiraKeyFromTitle(changes.Title.From, bugProjects)
 	if missing {
 		// title did not previously reference a bug
 		return intermediate, nil
@@ -1170,24 +2054,111 @@ func digestPR(log *logrus.Entry, pre github.PullRequestEvent, validateByDefault
 	return e, nil
 }
 
+// OpenBugPullRequestEvents lists the open pull requests in a repo that reference a bug and
+// creates the objects for handle() to re-evaluate each of them, as digestPR would for an edited PR.
+func OpenBugPullRequestEvents(gc pullRequestClient, org, repo string, cfg *Config) ([]event, error) {
+	prs, err := gc.GetPullRequests(org, repo)
+	if err != nil {
+		return nil, err
+	}
+	var events []event
+	for _, pr := range prs {
+		if pr.State != github.PullRequestStateOpen {
+			continue
+		}
+		options := cfg.OptionsForBranch(org, repo, pr.Base.Ref)
+		issues, missing, noJira := jiraKeysFromPullRequest(pr.Title, pr.Body, options, cfg.BugProjectSet())
+		if missing || noJira || !slices.ContainsFunc(issues, func(issue referencedIssue) bool { return issue.IsBug }) {
+			continue
+		}
+		events = append(events, event{
+			org:     org,
+			repo:    repo,
+			baseRef: pr.Base.Ref,
+			number:  pr.Number,
+			issues:  issues,
+			draft:   pr.Draft,
+			state:   pr.State,
+			body:    pr.Body,
+			title:   pr.Title,
+			htmlUrl: pr.HTMLURL,
+			login:   pr.User.Login,
+			author:  pr.User.Login,
+		})
+	}
+	return events, nil
+}
+
 // digestComment determines if any action is necessary and creates the objects for handle() if it is
-func digestComment(gc githubClient, log *logrus.Entry, ice github.IssueCommentEvent) (*event, error) {
+func digestComment(gc githubClient, log *logrus.Entry, ice github.IssueCommentEvent, cfg *Config) (*event, error) {
 	// Only consider new comments.
 	if ice.Action != github.IssueCommentActionCreated {
 		return nil, nil
 	}
 	// Make sure they are requesting a valid command
-	var refresh, cc, cherrypick, backport, verifiedRemove bool
+	var refresh, refreshAll, cc, cherrypick, uncherrypick, unlink, create, backport, backportCheck, bugStatus, finalize, verifiedRemove bool
 	var verified, verifyLater []string
+	var priority, targetVersion, fixVersion, jiraComment, linkClone string
+	var move *JiraBugState
 	switch {
 	case refreshCommandMatch.MatchString(ice.Comment.Body):
 		refresh = true
+	case refreshAllCommandMatch.MatchString(ice.Comment.Body):
+		refreshAll = true
 	case qaReviewCommandMatch.MatchString(ice.Comment.Body):
 		cc = true
 	case cherrypickCommandMatch.MatchString(ice.Comment.Body):
 		cherrypick = true
+	case uncherrypickCommandMatch.MatchString(ice.Comment.Body):
+		uncherrypick = true
+	case unlinkCommandMatch.MatchString(ice.Comment.Body):
+		unlink = true
+	case linkCloneCommandMatch.MatchString(ice.Comment.Body):
+		var err error
+		linkClone, err = linkCloneCommandMatches(ice.Comment.Body)
+		if err != nil {
+			return nil, err
+		}
+	case createCommandMatch.MatchString(ice.Comment.Body):
+		create = true
 	case backportCommandMatch.MatchString(ice.Comment.Body):
 		backport = true
+	case backportCheckCommandMatch.MatchString(ice.Comment.Body):
+		backportCheck = true
+	case setPriorityCommandMatch.MatchString(ice.Comment.Body):
+		var err error
+		priority, err = setPriorityCommandMatches(ice.Comment.Body)
+		if err != nil {
+			return nil, err
+		}
+	case setVersionCommandMatch.MatchString(ice.Comment.Body):
+		var err error
+		targetVersion, err = setVersionCommandMatches(ice.Comment.Body)
+		if err != nil {
+			return nil, err
+		}
+	case setFixVersionCommandMatch.MatchString(ice.Comment.Body):
+		var err error
+		fixVersion, err = setFixVersionCommandMatches(ice.Comment.Body)
+		if err != nil {
+			return nil, err
+		}
+	case statusCommandMatch.MatchString(ice.Comment.Body):
+		bugStatus = true
+	case finalizeCommandMatch.MatchString(ice.Comment.Body):
+		finalize = true
+	case moveCommandMatch.MatchString(ice.Comment.Body):
+		var err error
+		move, err = moveCommandMatches(ice.Comment.Body)
+		if err != nil {
+			return nil, err
+		}
+	case jiraCommentCommandMatch.MatchString(ice.Comment.Body):
+		var err error
+		jiraComment, err = jiraCommentCommandMatches(ice.Comment.Body)
+		if err != nil {
+			return nil, err
+		}
 	case verifyCommandMatch.MatchString(ice.Comment.Body):
 		var err error
 		verified, err = verifyCommandMatches(ice.Comment.Body)
@@ -1211,10 +2182,15 @@ func digestComment(gc githubClient, log *logrus.Entry, ice github.IssueCommentEv
 		number = ice.Issue.Number
 	)
 
+	// refreshing all pull requests is a repo-wide operation, so it may be requested from issues as well
+	if refreshAll {
+		return &event{org: org, repo: repo, number: number, body: ice.Comment.Body, htmlUrl: ice.Comment.HTMLURL, login: ice.Comment.User.Login, refreshAll: true}, nil
+	}
+
 	// We don't support linking issues to bugProjects
 	if !ice.Issue.IsPullRequest() {
 		log.Debug("Jira bug command requested on an issue, ignoring")
-		return nil, gc.CreateComment(org, repo, number, formatResponseRaw(ice.Comment.Body, ice.Comment.HTMLURL, ice.Comment.User.Login, `Jira bug referencing is only supported for Pull Requests, not issues.`, fmt.Sprintf("%s/%s", ice.Repo.Owner.Login, ice.Repo.Name)))
+		return nil, gc.CreateComment(org, repo, number, formatResponseRaw(ice.Comment.Body, ice.Comment.HTMLURL, ice.Comment.User.Login, `Jira bug referencing is only supported for Pull Requests, not issues.`, fmt.Sprintf("%s/%s", ice.Repo.Owner.Login, ice.Repo.Name), ""))
 	}
 
 	// Make sure the PR title is referencing a bug
@@ -1229,23 +2205,38 @@ func digestComment(gc githubClient, log *logrus.Entry, ice github.IssueCommentEv
 		baseRef:        pr.Base.Ref,
 		number:         number,
 		merged:         pr.Merged,
+		draft:          pr.Draft,
 		state:          pr.State,
 		body:           ice.Comment.Body,
 		title:          ice.Issue.Title,
 		htmlUrl:        ice.Comment.HTMLURL,
+		commentID:      ice.Comment.ID,
 		login:          ice.Comment.User.Login,
+		author:         pr.User.Login,
 		refresh:        refresh,
 		cc:             cc,
+		uncherrypick:   uncherrypick,
+		unlink:         unlink,
+		linkClone:      linkClone,
+		create:         create,
 		verify:         verified,
 		verifyLater:    verifyLater,
 		verifiedRemove: verifiedRemove,
+		priority:       priority,
+		targetVersion:  targetVersion,
+		fixVersion:     fixVersion,
+		bugStatus:      bugStatus,
+		finalize:       finalize,
+		move:           move,
+		jiraComment:    jiraComment,
 	}
 
-	e.issues, e.missing, e.noJira = jiraKeyFromTitle(pr.Title)
+	bugProjects := cfg.BugProjectSet()
+	e.issues, e.missing, e.noJira = jiraKeysFromPullRequest(pr.Title, pr.Body, cfg.OptionsForBranch(org, repo, pr.Base.Ref), bugProjects)
 
 	if cherrypick {
 		var matchError error
-		e.issues, matchError = cherryPickCommandMatches(ice.Comment.Body)
+		e.issues, matchError = cherryPickCommandMatches(ice.Comment.Body, bugProjects)
 		if matchError != nil {
 			return nil, matchError
 		}
@@ -1262,15 +2253,23 @@ func digestComment(gc githubClient, log *logrus.Entry, ice github.IssueCommentEv
 		e.backport = true
 	}
 
+	if backportCheck {
+		var matchError error
+		e.backportBranches, matchError = backportCheckCommandMatches(ice.Comment.Body)
+		if matchError != nil {
+			return nil, matchError
+		}
+		e.backportCheck = true
+	}
 	return e, nil
 }
 
-func cherryPickCommandMatches(body string) ([]referencedIssue, error) {
+func cherryPickCommandMatches(body string, bugProjects sets.Set[string]) ([]referencedIssue, error) {
 	commandMatches := cherrypickCommandMatch.FindStringSubmatch(body)
 	if len(commandMatches) == 0 {
 		return nil, fmt.Errorf("body %q did not match cherry-pick regex, programmer error", body)
 	}
-	return referencedIssues(commandMatches[0]), nil
+	return referencedIssues(commandMatches[0], bugProjects), nil
 }
 
 func backportCommandMatches(body string) ([]string, error) {
@@ -1281,14 +2280,85 @@ func backportCommandMatches(body string) ([]string, error) {
 	return strings.Split(commandMatches[0][1], ","), nil
 }
 
-func verifyCommandMatches(body string) ([]string, error) {
-	commandMatches := verifyCommandMatch.FindAllStringSubmatch(body, -1)
+func backportCheckCommandMatches(body string) ([]string, error) {
+	commandMatches := backportCheckCommandMatch.FindAllStringSubmatch(body, -1)
 	if len(commandMatches) == 0 || len(commandMatches[0]) < 2 {
-		return nil, fmt.Errorf("body %q did not match verify regex, programmer error", body)
+		return nil, fmt.Errorf("body %q did not match backport-check regex, programmer error", body)
 	}
 	return strings.Split(commandMatches[0][1], ","), nil
 }
 
+func linkCloneCommandMatches(body string) (string, error) {
+	commandMatches := linkCloneCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 2 {
+		return "", fmt.Errorf("body %q did not match link-clone regex, programmer error", body)
+	}
+	return strings.ToUpper(commandMatches[1]), nil
+}
+
+func setPriorityCommandMatches(body string) (string, error) {
+	commandMatches := setPriorityCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 2 {
+		return "", fmt.Errorf("body %q did not match set-priority regex, programmer error", body)
+	}
+	return commandMatches[1], nil
+}
+
+func setVersionCommandMatches(body string) (string, error) {
+	commandMatches := setVersionCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 2 {
+		return "", fmt.Errorf("body %q did not match set-version regex, programmer error", body)
+	}
+	return commandMatches[1], nil
+}
+
+func setFixVersionCommandMatches(body string) (string, error) {
+	commandMatches := setFixVersionCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 2 {
+		return "", fmt.Errorf("body %q did not match set-fixversion regex, programmer error", body)
+	}
+	return commandMatches[1], nil
+}
+
+func moveCommandMatches(body string) (*JiraBugState, error) {
+	commandMatches := moveCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 3 {
+		return nil, fmt.Errorf("body %q did not match move regex, programmer error", body)
+	}
+	return &JiraBugState{Status: commandMatches[1], Resolution: commandMatches[2]}, nil
+}
+
+func jiraCommentCommandMatches(body string) (string, error) {
+	commandMatches := jiraCommentCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 2 {
+		return "", fmt.Errorf("body %q did not match comment regex, programmer error", body)
+	}
+	// the comment spans the lines up to the next command or the end of the body
+	return strings.TrimSpace(strings.ReplaceAll(commandMatches[1], "\r\n", "\n")), nil
+}
+
+func verifyCommandMatches(body string) ([]string, error) {
+	commandMatches := verifyCommandMatch.FindStringSubmatch(body)
+	if len(commandMatches) < 2 {
+		return nil, fmt.Errorf("body %q did not match verify regex, programmer error", body)
+	}
+	return verificationReasons(commandMatches[1]), nil
+}
+
+// verificationReasons splits the argument of the `/verified by` command into its comma-separated
+// reasons, such as user mentions. Free-form reasons containing whitespace are kept verbatim as a
+// single reason.
+func verificationReasons(argument string) []string {
+	reasons := strings.Split(argument, ",")
+	for i := range reasons {
+		reasons[i] = strings.TrimSpace(reasons[i])
+		if strings.ContainsFunc(reasons[i], unicode.IsSpace) {
+			return []string{argument}
+		}
+	}
+	return reasons
+}
+
 func verifyLaterCommandMatches(body string) ([]string, error) {
 	commandMatches := verifyLaterCommandMatch.FindAllStringSubmatch(body, -1)
 	if len(commandMatches) == 0 || len(commandMatches[0]) < 2 {
@@ -1297,14 +2367,16 @@ func verifyLaterCommandMatches(body string) ([]string, error) {
 	return strings.Split(commandMatches[0][1], ","), nil
 }
 
-func referencedIssues(matchingText string) []referencedIssue {
+func referencedIssues(matchingText string, bugProjects sets.Set[string]) []referencedIssue {
 	matches := jiraIssueReferenceMatch.FindAllStringSubmatch(matchingText, -1)
 	var issues []referencedIssue
 	for _, match := range matches {
+		// Jira project keys are uppercase, but users sometimes type them in lowercase
+		project := strings.ToUpper(match[1])
 		issues = append(issues, referencedIssue{
-			Project: match[1],
+			Project: project,
 			ID:      match[2],
-			IsBug:   bugProjects.Has(match[1]),
+			IsBug:   bugProjects.Has(project),
 		})
 	}
 	return issues
@@ -1316,25 +2388,95 @@ type event struct {
 	issues                          []referencedIssue
 	noJira                          bool
 	missing, merged, closed, opened bool
+	reopened                        bool
 	state                           string
+	draft                           bool
 	body, title, htmlUrl, login     string
+	author                          string
 	refresh, cc, cherrypickCmd      bool
+	refreshAll                      bool
 	cherrypick                      bool
 	cherrypickFromPRNum             int
+	uncherrypick                    bool
+	unlink                          bool
+	linkClone                       string
+	create                          bool
 	backport                        bool
+	backportCheck                   bool
 	backportBranches                []string
 	verify, verifyLater             []string
 	verifiedRemove, fileChanged     bool
+	beforeSHA, afterSHA             string
+	verifiedByReview                bool
+	priority                        string
+	targetVersion                   string
+	fixVersion                      string
+	bugStatus                       bool
+	finalize                        bool
+	move                            *JiraBugState
+	jiraComment                     string
+	footer                          string
+	commentID                       int
 }
 
-func (e *event) comment(gc githubClient) func(body string) error {
+func (e *event) comment(gc commentClient) func(body string) error {
 	return func(body string) error {
-		return gc.CreateComment(e.org, e.repo, e.number, formatResponseRaw(e.body, e.htmlUrl, e.login, body, fmt.Sprintf("%s/%s", e.org, e.repo)))
+		if err := gc.CreateComment(e.org, e.repo, e.number, formatResponseRaw(e.body, e.htmlUrl, e.login, body, fmt.Sprintf("%s/%s", e.org, e.repo), e.footer)); err != nil {
+			return err
+		}
+		recordComment(*e)
+		return nil
+	}
+}
+
+// commentType returns the kind of action that the event triggers; this is used to categorize the comments posted by the plugin
+func (e *event) commentType() string {
+	switch {
+	case e.refreshAll:
+		return "refresh-all"
+	case e.fileChanged:
+		return "file-changed"
+	case e.cherrypick:
+		return "cherrypick"
+	case e.uncherrypick:
+		return "uncherrypick"
+	case e.unlink:
+		return "unlink"
+	case e.linkClone != "":
+		return "link-clone"
+	case e.create:
+		return "create"
+	case e.backport:
+		return "backport"
+	case e.backportCheck:
+		return "backport-check"
+	case e.priority != "":
+		return "set-priority"
+	case e.targetVersion != "":
+		return "set-version"
+	case e.fixVersion != "":
+		return "set-fixversion"
+	case e.bugStatus:
+		return "status"
+	case e.finalize:
+		return "finalize"
+	case e.move != nil:
+		return "move"
+	case e.jiraComment != "":
+		return "jira-comment"
+	case e.merged:
+		return "merge"
+	case e.closed:
+		return "close"
+	case len(e.verify) > 0 || len(e.verifyLater) > 0 || e.verifiedRemove:
+		return "verification"
+	default:
+		return "validation"
 	}
 }
 
 // formatResponseRaw nicely formats a response for one does not have an issue comment
-func formatResponseRaw(body, bodyURL, login, reply, orgRepo string) string {
+func formatResponseRaw(body, bodyURL, login, reply, orgRepo, footer string) string {
 	format := `In response to [this](%s):
 
 %s
@@ -1344,29 +2486,39 @@ func formatResponseRaw(body, bodyURL, login, reply, orgRepo string) string {
 	for _, l := range strings.Split(body, "\n") {
 		quoted = append(quoted, ">"+l)
 	}
-	return formatResponse(login, reply, fmt.Sprintf(format, bodyURL, strings.Join(quoted, "\n")), orgRepo)
+	return formatResponse(login, reply, fmt.Sprintf(format, bodyURL, strings.Join(quoted, "\n")), orgRepo, footer)
 }
 
 // formatResponse nicely formats a response to a generic reason.
-func formatResponse(to, message, reason, orgRepo string) string {
-	helpURL := url.URL{
-		Scheme: "https",
-		Host:   "prow.ci.openshift.org",
-		Path:   "command-help",
-	}
-	query := helpURL.Query()
-	query.Add("repo", orgRepo)
-	helpURL.RawQuery = query.Encode()
+func formatResponse(to, message, reason, orgRepo, footer string) string {
 	format := `@%s: %s
 
 <details>
 
 %s
 
-Instructions for interacting with me using PR comments are available [here](%s).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+%s
 </details>`
 
-	return fmt.Sprintf(format, to, message, reason, helpURL.String())
+	return fmt.Sprintf(format, to, message, reason, commentFooter(orgRepo, footer))
+}
+
+// commentFooter returns the end of the details block of every comment: the instructions for interacting with
+// the plugin, followed by the repo-specific footer, if one is configured
+func commentFooter(orgRepo, footer string) string {
+	helpURL := url.URL{
+		Scheme: "https",
+		Host:   "prow.ci.openshift.org",
+		Path:   "command-help",
+	}
+	query := helpURL.Query()
+	query.Add("repo", orgRepo)
+	helpURL.RawQuery = query.Encode()
+	instructions := fmt.Sprintf("Instructions for interacting with me using PR comments are available [here](%s).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.", helpURL.String())
+	if footer == "" {
+		return instructions
+	}
+	return instructions + "\n" + footer
 }
 
 type queryUser struct {
@@ -1385,6 +2537,23 @@ type querySearch struct {
 	Edges []queryEdge
 }
 
+// reportedByAuthor determines whether the reporter of the issue maps to the author of the pull request by their
+// public email. Reporters that cannot be mapped to exactly one GitHub user are not considered to be the author.
+func reportedByAuthor(ghc githubClient, e event, issue *jira.Issue, log *logrus.Entry) bool {
+	if e.author == "" || issue.Fields == nil || issue.Fields.Reporter == nil || issue.Fields.Reporter.EmailAddress == "" {
+		return false
+	}
+	query := &emailToLoginQuery{}
+	queryVars := map[string]any{
+		"email": githubql.String(issue.Fields.Reporter.EmailAddress),
+	}
+	if err := ghc.QueryWithGitHubAppsSupport(context.Background(), query, queryVars, e.org); err != nil {
+		log.WithError(err).Warn("Failed to run graphql github query for the reporter.")
+		return false
+	}
+	return len(query.Search.Edges) == 1 && strings.EqualFold(string(query.Search.Edges[0].Node.User.Login), e.author)
+}
+
 /*
 emailToLoginQuery is a graphql query struct that should result in this graphql query:
 
@@ -1410,7 +2579,7 @@ func processQuery(query *emailToLoginQuery, email string) string {
 	case 0:
 		return fmt.Sprintf("No GitHub users were found matching the public email listed for the QA contact in Jira (%s), skipping review request.", email)
 	case 1:
-		return fmt.Sprintf("Requesting review from QA contact:\n/cc @%s", query.Search.Edges[0].Node.User.Login)
+		return requestQAReview([]string{string(query.Search.Edges[0].Node.User.Login)})
 	default:
 		response := fmt.Sprintf("Multiple GitHub users were found matching the public email listed for the QA contact in Jira (%s), skipping review request. List of users with matching email:", email)
 		for _, edge := range query.Search.Edges {
@@ -1420,7 +2589,32 @@ func processQuery(query *emailToLoginQuery, email string) string {
 	}
 }
 
-func getSeverityLabel(severity string) string {
+// requestQAReview generates a response that requests review from the GitHub users of the QA contacts
+func requestQAReview(logins []string) string {
+	if len(logins) == 1 {
+		return fmt.Sprintf("Requesting review from QA contact:\n/cc @%s", logins[0])
+	}
+	return fmt.Sprintf("Requesting review from QA contacts:\n/cc @%s", strings.Join(logins, " @"))
+}
+
+// severityRanking orders the severities that we understand from most to least severe
+var severityRanking = []string{criticalSeverity, importantSeverity, moderateSeverity, lowSeverity, informationalSeverity}
+
+// severityRankingFor returns the order of severities configured for the branch, falling back
+// to the default ranking
+func severityRankingFor(options JiraBranchOptions) []string {
+	if len(options.SeverityOrder) > 0 {
+		return options.SeverityOrder
+	}
+	return severityRanking
+}
+
+// getSeverityLabel returns the label for the severity, preferring the label configured
+// for the branch over the default one
+func getSeverityLabel(severity string, configured map[string]string) string {
+	if label, ok := configured[severity]; ok {
+		return label
+	}
 	switch severity {
 	case criticalSeverity:
 		return labels.SeverityCritical
@@ -1437,6 +2631,15 @@ func getSeverityLabel(severity string) string {
 	return ""
 }
 
+// severityLabels returns all labels that may be used to denote the severity of a bug
+func severityLabels(configured map[string]string) sets.Set[string] {
+	knownLabels := sets.New[string](labels.SeverityCritical, labels.SeverityImportant, labels.SeverityModerate, labels.SeverityLow, labels.SeverityInformational)
+	for _, label := range configured {
+		knownLabels.Insert(label)
+	}
+	return knownLabels
+}
+
 func bugMatchesStates(bug *jira.Issue, states []JiraBugState) bool {
 	if bug == nil {
 		return false
@@ -1471,8 +2674,10 @@ func prettyStates(statuses []JiraBugState) []string {
 }
 
 // validateBug determines if the bug matches the options and returns a description of why not
-func validateBug(bug *jira.Issue, dependents []dependent, options JiraBranchOptions, jiraEndpoint string) (bool, []string, []string) {
+func validateBug(bug *jira.Issue, dependents, blockers []dependent, options JiraBranchOptions, jiraEndpoint string) (bool, bool, []string, []string) {
 	valid := true
+	// incomplete tracks whether any validation failed because a required field is not set at all
+	var incomplete bool
 	var passes []string
 	var fails []string
 	if options.IsOpen != nil && (bug.Fields == nil || bug.Fields.Status == nil || *options.IsOpen != !strings.EqualFold(bug.Fields.Status.Name, status.Closed)) {
@@ -1496,15 +2701,76 @@ func validateBug(bug *jira.Issue, dependents []dependent, options JiraBranchOpti
 		passes = append(passes, fmt.Sprintf("bug %s open, matching expected state (%s)", was, expected))
 	}
 
+	if options.RejectClosedBugs != nil && *options.RejectClosedBugs {
+		if bug.Fields != nil && bug.Fields.Status != nil && strings.EqualFold(bug.Fields.Status.Name, status.Closed) {
+			valid = false
+			fails = append(fails, fmt.Sprintf("expected the bug to not be closed, but it is in state %s", bug.Fields.Status.Name))
+		} else {
+			passes = append(passes, "bug is not closed")
+		}
+	}
+
+	if options.RequiredSecurityLevel != nil {
+		level, err := helpers.GetIssueSecurityLevel(bug)
+		switch {
+		case err != nil:
+			valid = false
+			fails = append(fails, fmt.Sprintf("failed to get the security level of the bug: %v", err))
+		case level == nil:
+			valid = false
+			incomplete = true
+			fails = append(fails, fmt.Sprintf("expected the bug to have the %q security level, but no security level was set", *options.RequiredSecurityLevel))
+		case level.Name != *options.RequiredSecurityLevel:
+			valid = false
+			fails = append(fails, fmt.Sprintf("expected the bug to have the %q security level, but it has the %q security level instead", *options.RequiredSecurityLevel, level.Name))
+		default:
+			passes = append(passes, fmt.Sprintf("bug has the required %q security level", *options.RequiredSecurityLevel))
+		}
+	}
+
 	if options.TargetVersion != nil {
 		if err := validateTargetVersion(bug, *options.TargetVersion); err != nil {
 			fails = append(fails, err.Error())
 			valid = false
+			incomplete = incomplete || isMissingField(err)
 		} else {
 			passes = append(passes, fmt.Sprintf("bug target version (%s) matches configured target version for branch (%s)", *options.TargetVersion, *options.TargetVersion))
 		}
 	}
 
+	if options.RequireSingleTargetVersion != nil && *options.RequireSingleTargetVersion {
+		if err := validateSingleTargetVersion(bug); err != nil {
+			fails = append(fails, err.Error())
+			valid = false
+		} else {
+			passes = append(passes, "bug does not target more than one version")
+		}
+	}
+
+	if options.RequireActiveSprint != nil && *options.RequireActiveSprint {
+		sprint, found, err := helpers.GetActiveSprintName(helpers.GetSprintField(bug))
+		switch {
+		case err != nil:
+			fails = append(fails, fmt.Sprintf("failed to get the sprint of the bug: %v", err))
+			valid = false
+		case !found:
+			fails = append(fails, "expected the bug to be assigned to an active sprint, but it is not")
+			valid = false
+		default:
+			passes = append(passes, fmt.Sprintf("bug is assigned to active sprint %s", sprint))
+		}
+	}
+
+	if options.FixVersion != nil {
+		if err := validateFixVersion(bug, *options.FixVersion); err != nil {
+			fails = append(fails, err.Error())
+			valid = false
+			incomplete = incomplete || isMissingField(err)
+		} else {
+			passes = append(passes, fmt.Sprintf("bug fix version (%s) matches configured fix version for branch (%s)", *options.FixVersion, *options.FixVersion))
+		}
+	}
+
 	if options.ValidStates != nil {
 		var allowed []JiraBugState
 		allowed = append(allowed, *options.ValidStates...)
@@ -1582,6 +2848,10 @@ func validateBug(bug *jira.Issue, dependents []dependent, options JiraBranchOpti
 		}
 	}
 
+	var dependentTargetVersions string
+	if options.DependentBugTargetVersions != nil && bug.Fields != nil {
+		dependentTargetVersions = prettyTargetVersions(bug.Fields.Project.Key, *options.DependentBugTargetVersions)
+	}
 	if options.DependentBugTargetVersions != nil {
 		for _, depBug := range dependents {
 			if bug.Fields != nil {
@@ -1592,17 +2862,19 @@ func validateBug(bug *jira.Issue, dependents []dependent, options JiraBranchOpti
 				// this should never happen
 				fails = append(fails, fmt.Sprintf("unable to identify project for issue %s", depBug.key))
 			}
+			// versions are matched against the project of the dependent itself, as not all projects truncate versions
+			dependentTargetVersions := prettyTargetVersions(depBug.project(), *options.DependentBugTargetVersions)
 			if depBug.targetVersion == nil {
 				valid = false
-				fails = append(fails, fmt.Sprintf("expected dependent "+issueLink+" to target a version in %s, but no target version was set", depBug.key, jiraEndpoint, depBug.key, strings.Join(*options.DependentBugTargetVersions, ", ")))
+				fails = append(fails, fmt.Sprintf("expected dependent "+issueLink+" to target a version in %s, but no target version was set", depBug.key, jiraEndpoint, depBug.key, dependentTargetVersions))
 			} else if depBug.multipleVersions {
 				valid = false
-				fails = append(fails, fmt.Sprintf("expected dependent "+issueLink+" to target a version in %s, but it has multiple target versions", depBug.key, jiraEndpoint, depBug.key, strings.Join(*options.DependentBugTargetVersions, ", ")))
-			} else if sets.NewString(*options.DependentBugTargetVersions...).Has(*depBug.targetVersion) {
-				passes = append(passes, fmt.Sprintf("dependent "+issueLink+" targets the %q version, which is one of the valid target versions: %s", depBug.key, jiraEndpoint, depBug.key, *depBug.targetVersion, strings.Join(*options.DependentBugTargetVersions, ", ")))
+				fails = append(fails, fmt.Sprintf("expected dependent "+issueLink+" to target a version in %s, but it has multiple target versions", depBug.key, jiraEndpoint, depBug.key, dependentTargetVersions))
+			} else if targetVersionAllowed(depBug.project(), *options.DependentBugTargetVersions, *depBug.targetVersion) {
+				passes = append(passes, fmt.Sprintf("dependent "+issueLink+" targets the %q version, which is one of the valid target versions: %s", depBug.key, jiraEndpoint, depBug.key, *depBug.targetVersion, dependentTargetVersions))
 			} else {
 				valid = false
-				fails = append(fails, fmt.Sprintf("expected dependent "+issueLink+" to target a version in %s, but it targets %q instead", depBug.key, jiraEndpoint, depBug.key, strings.Join(*options.DependentBugTargetVersions, ", "), *depBug.targetVersion))
+				fails = append(fails, fmt.Sprintf("expected dependent "+issueLink+" to target a version in %s, but it targets %q instead", depBug.key, jiraEndpoint, depBug.key, dependentTargetVersions, *depBug.targetVersion))
 			}
 		}
 	}
@@ -1612,34 +2884,181 @@ func validateBug(bug *jira.Issue, dependents []dependent, options JiraBranchOpti
 		case options.DependentBugStates != nil && options.DependentBugTargetVersions != nil:
 			valid = false
 			expected := strings.Join(prettyStates(*options.DependentBugStates), ", ")
-			fails = append(fails, fmt.Sprintf("expected "+issueLink+" to depend on a bug targeting a version in %s and in one of the following states: %s, but no dependents were found", bug.Key, jiraEndpoint, bug.Key, strings.Join(*options.DependentBugTargetVersions, ", "), expected))
+			fails = append(fails, fmt.Sprintf("expected "+issueLink+" to depend on a bug targeting a version in %s and in one of the following states: %s, but no dependents were found", bug.Key, jiraEndpoint, bug.Key, dependentTargetVersions, expected))
 		case options.DependentBugStates != nil:
 			valid = false
 			expected := strings.Join(prettyStates(*options.DependentBugStates), ", ")
 			fails = append(fails, fmt.Sprintf("expected "+issueLink+" to depend on a bug in one of the following states: %s, but no dependents were found", bug.Key, jiraEndpoint, bug.Key, expected))
 		case options.DependentBugTargetVersions != nil:
 			valid = false
-			fails = append(fails, fmt.Sprintf("expected "+issueLink+" to depend on a bug targeting a version in %s, but no dependents were found", bug.Key, jiraEndpoint, bug.Key, strings.Join(*options.DependentBugTargetVersions, ", ")))
+			fails = append(fails, fmt.Sprintf("expected "+issueLink+" to depend on a bug targeting a version in %s, but no dependents were found", bug.Key, jiraEndpoint, bug.Key, dependentTargetVersions))
 		default:
 		}
 	} else {
 		passes = append(passes, "bug has dependents")
 	}
 
-	// make sure all dependents are part of the parent bug's project
-	for _, dependent := range dependents {
+	if options.RequireBlockedBy != nil && *options.RequireBlockedBy {
+		var blockers []string
 		if bug.Fields != nil {
-			if !strings.HasPrefix(dependent.key, bug.Fields.Project.Key+"-") {
-				valid = false
-				fails = append(fails, fmt.Sprintf("dependent bug %s is not in the required `%s` project", dependent.key, bug.Fields.Project.Key))
+			for _, link := range bug.Fields.IssueLinks {
+				// only inward links of the Blocks type point at bugs blocking this one; outward links are bugs this one blocks
+				if link.InwardIssue != nil && link.Type.Name == "Blocks" && link.Type.Inward == "is blocked by" {
+					blockers = append(blockers, fmt.Sprintf(issueLink, link.InwardIssue.Key, jiraEndpoint, link.InwardIssue.Key))
+				}
 			}
-		} else {
+		}
+		if len(blockers) == 0 {
+			valid = false
+			fails = append(fails, fmt.Sprintf("expected "+issueLink+" to be blocked by at least one other bug, but no blocking bugs were found", bug.Key, jiraEndpoint, bug.Key))
+		} else {
+			passes = append(passes, fmt.Sprintf("bug is blocked by %s", strings.Join(blockers, ", ")))
+		}
+	}
+
+	if options.RequireBlockersResolved != nil && *options.RequireBlockersResolved {
+		for _, blocker := range blockers {
+			if blocker.bugState.Resolution == "" {
+				valid = false
+				fails = append(fails, fmt.Sprintf("expected blocker "+issueLink+" to be resolved, but it is %s without a resolution", blocker.key, jiraEndpoint, blocker.key, blocker.bugState.Status))
+			} else {
+				passes = append(passes, fmt.Sprintf("blocker "+issueLink+" is resolved as %s", blocker.key, jiraEndpoint, blocker.key, PrettyStatus(blocker.bugState.Status, blocker.bugState.Resolution)))
+			}
+		}
+	}
+
+	if options.RequireAffectsVersion != nil && *options.RequireAffectsVersion {
+		var affectsVersions []string
+		if bug.Fields != nil {
+			for _, version := range bug.Fields.AffectsVersions {
+				if version != nil && version.Name != "" {
+					affectsVersions = append(affectsVersions, version.Name)
+				}
+			}
+		}
+		if len(affectsVersions) == 0 {
+			valid = false
+			incomplete = true
+			fails = append(fails, "expected the bug to have at least one affects version set, but it has none")
+		} else {
+			passes = append(passes, fmt.Sprintf("bug has affects version(s) set: %s", strings.Join(affectsVersions, ", ")))
+		}
+	}
+
+	if options.RequireDescription != nil && *options.RequireDescription {
+		if bug.Fields == nil || strings.TrimSpace(bug.Fields.Description) == "" {
+			valid = false
+			incomplete = true
+			fails = append(fails, "expected the bug to have a description, but it is empty")
+		} else {
+			passes = append(passes, "bug has a description")
+		}
+	}
+
+	if options.AllowedIssueTypes != nil {
+		if err := validateIssueType(bug, *options.AllowedIssueTypes); err != nil {
+			valid = false
+			fails = append(fails, err.Error())
+		} else {
+			passes = append(passes, fmt.Sprintf("bug is of type %s, which is allowed", bug.Fields.Type.Name))
+		}
+	}
+
+	if epicLinkRequired(bug, options) {
+		if epic, err := validateEpicLink(bug); err != nil {
+			valid = false
+			fails = append(fails, err.Error())
+			incomplete = incomplete || isMissingField(err)
+		} else {
+			passes = append(passes, fmt.Sprintf("%s is linked to the epic %s", strings.ToLower(bug.Fields.Type.Name), epic))
+		}
+	}
+
+	// make sure all dependents are part of the parent bug's project
+	for _, dependent := range dependents {
+		if bug.Fields != nil {
+			if !strings.HasPrefix(dependent.key, bug.Fields.Project.Key+"-") {
+				valid = false
+				fails = append(fails, fmt.Sprintf("dependent bug %s is not in the required `%s` project", dependent.key, bug.Fields.Project.Key))
+			}
+		} else {
 			// this should never happen
 			fails = append(fails, fmt.Sprintf("unable to identify project for issue %s", dependent.key))
 		}
 	}
 
-	return valid, passes, fails
+	return valid, incomplete, passes, fails
+}
+
+// missingFieldError is returned by validations that fail because a required field is not set on the issue,
+// as opposed to being set to an unexpected value
+type missingFieldError struct {
+	msg string
+}
+
+func (e *missingFieldError) Error() string {
+	return e.msg
+}
+
+// isMissingField determines whether the validation error was caused by a required field not being set
+func isMissingField(err error) bool {
+	var missing *missingFieldError
+	return errors.As(err, &missing)
+}
+
+// validateSingleTargetVersion makes sure that the issue does not target more than one version
+func validateSingleTargetVersion(issue *jira.Issue) error {
+	targetVersion, err := helpers.GetIssueTargetVersion(issue)
+	if err != nil {
+		return fmt.Errorf("failed to get target version for bug: %s", err.Error())
+	}
+	if len(targetVersion) <= 1 {
+		return nil
+	}
+	var names []string
+	for _, version := range targetVersion {
+		names = append(names, version.Name)
+	}
+	return fmt.Errorf("expected the bug to target exactly one version, but it targets: %s", strings.Join(names, ", "))
+}
+
+// inconsistentTargetVersions lists the target versions of the referenced bugs if they do not all target the same
+// version, and returns an empty string otherwise. Bugs that cannot be retrieved are skipped, as they are reported on
+// separately. The bugs that were retrieved are returned by key, so that they are not fetched again when validated.
+func inconsistentTargetVersions(jc jiraclient.Client, issues []referencedIssue, log *logrus.Entry) (string, map[string]*jira.Issue) {
+	versions := sets.New[string]()
+	var described []string
+	fetched := map[string]*jira.Issue{}
+	for _, refIssue := range issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		issue, err := jc.GetIssue(refIssue.Key())
+		if err != nil || issue == nil {
+			log.WithError(err).Debugf("Failed to get %s to compare target versions.", refIssue.Key())
+			continue
+		}
+		fetched[refIssue.Key()] = issue
+		targetVersion, err := helpers.GetIssueTargetVersion(issue)
+		if err != nil {
+			log.WithError(err).Warnf("Failed to get the target version of %s.", refIssue.Key())
+			continue
+		}
+		var names []string
+		for _, version := range targetVersion {
+			names = append(names, version.Name)
+		}
+		version := "unset"
+		if len(names) > 0 {
+			version = strings.Join(names, ", ")
+		}
+		versions.Insert(version)
+		described = append(described, fmt.Sprintf("%s (%s)", refIssue.Key(), version))
+	}
+	if versions.Len() <= 1 {
+		return "", fetched
+	}
+	return strings.Join(described, ", "), fetched
 }
 
 func validateTargetVersion(issue *jira.Issue, requiredTargetVersion string) error {
@@ -1654,7 +3073,7 @@ func validateTargetVersion(issue *jira.Issue, requiredTargetVersion string) erro
 		return fmt.Errorf("failed to get target version for %s: %v", issueType, err)
 	}
 	if len(targetVersion) == 0 {
-		return fmt.Errorf("expected the %s to target the %q version, but no target version was set", issueType, requiredTargetVersion)
+		return &missingFieldError{msg: fmt.Sprintf("expected the %s to target the %q version, but no target version was set", issueType, requiredTargetVersion)}
 	}
 	if len(targetVersion) > 1 {
 		return fmt.Errorf("expected the %s to target only the %q version, but multiple target versions were set", issueType, requiredTargetVersion)
@@ -1663,27 +3082,264 @@ func validateTargetVersion(issue *jira.Issue, requiredTargetVersion string) erro
 	//if requiredTargetVersion != targetVersion[0].Name && prefixedRequiredTargetVersion != targetVersion[0].Name {
 	//	return fmt.Errorf("expected the %s to target either version %q or %q, but it targets %q instead", issueType, requiredTargetVersion, prefixedRequiredTargetVersion, targetVersion[0].Name)
 	//}
-	// TODO: Remove this truncated version check...
-	truncatedRequiredTargetVersion := requiredTargetVersion
-	pieces := strings.Split(requiredTargetVersion, ".")
-	if issue.Fields.Project.Key != "DFBUGS" && len(pieces) >= 2 {
-		truncatedRequiredTargetVersion = fmt.Sprintf("%s.%s", pieces[0], pieces[1])
-	}
-	truncatedPrefixedRequiredTargetVersion := fmt.Sprintf("openshift-%s", truncatedRequiredTargetVersion)
+	truncatedRequiredTargetVersion, truncatedPrefixedRequiredTargetVersion := truncateRequiredVersion(issue, requiredTargetVersion)
 	if !strings.HasPrefix(targetVersion[0].Name, truncatedRequiredTargetVersion) && !strings.HasPrefix(targetVersion[0].Name, truncatedPrefixedRequiredTargetVersion) {
 		return fmt.Errorf("expected the %s to target either version %q or %q, but it targets %q instead", issueType, fmt.Sprintf("%s.*", truncatedRequiredTargetVersion), fmt.Sprintf("%s.*", truncatedPrefixedRequiredTargetVersion), targetVersion[0].Name)
 	}
 	return nil
 }
 
+// validateIssueType checks that the type of the issue is one of the allowed types, ignoring case
+func validateIssueType(issue *jira.Issue, allowedTypes []string) error {
+	if issue.Fields == nil || issue.Fields.Type.Name == "" {
+		return fmt.Errorf("expected the issue to be of one of the following types: %s, but it has no type set", strings.Join(allowedTypes, ", "))
+	}
+	for _, allowed := range allowedTypes {
+		if strings.EqualFold(issue.Fields.Type.Name, allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected the issue to be of one of the following types: %s, but it is of type %s instead", strings.Join(allowedTypes, ", "), issue.Fields.Type.Name)
+}
+
+// findLinkedIssue returns the key of the first issue linked to the bug that has the required type, which may be
+// prefixed with the project the linked issue must belong to (e.g. `OCPQE/Test`), or an empty string if there is none
+func findLinkedIssue(jc jiraclient.Client, bug *jira.Issue, required string) (string, error) {
+	project, issueType, found := strings.Cut(required, "/")
+	if !found {
+		project, issueType = "", required
+	}
+	for _, link := range bug.Fields.IssueLinks {
+		// link may be either an outward or inward issue; depends on the link type
+		linkIssue := link.InwardIssue
+		if linkIssue == nil {
+			linkIssue = link.OutwardIssue
+		}
+		if linkIssue == nil || (project != "" && !strings.HasPrefix(linkIssue.Key, project+"-")) {
+			continue
+		}
+		// the issue in the link is often trimmed down; only get the full issue if its type is not included
+		if linkIssue.Fields == nil || linkIssue.Fields.Type.Name == "" {
+			full, err := jc.GetIssue(linkIssue.Key)
+			if err != nil {
+				return "", fmt.Errorf("failed to get linked issue %s: %w", linkIssue.Key, err)
+			}
+			linkIssue = full
+		}
+		if linkIssue.Fields != nil && strings.EqualFold(linkIssue.Fields.Type.Name, issueType) {
+			return linkIssue.Key, nil
+		}
+	}
+	return "", nil
+}
+
+// statusRequiredLabels returns the labels that the pull request must carry for the current status of the bug
+func statusRequiredLabels(bug *jira.Issue, options JiraBranchOptions) []string {
+	current := issueStatus(bug)
+	if current == "" {
+		return nil
+	}
+	for statusName, requiredLabels := range options.StatusRequiredLabels {
+		if strings.EqualFold(statusName, current) {
+			return requiredLabels
+		}
+	}
+	return nil
+}
+
+// validateStatusRequiredLabels checks that the pull request carries all of the labels required for the status of the bug
+func validateStatusRequiredLabels(bug *jira.Issue, requiredLabels []string, prLabels []github.Label) error {
+	present := sets.New[string]()
+	for _, label := range prLabels {
+		present.Insert(label.Name)
+	}
+	var missing []string
+	for _, label := range requiredLabels {
+		if !present.Has(label) {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("expected the pull request to have the label(s) `%s` while the bug is in the %s status, but it is missing `%s`", strings.Join(requiredLabels, "`, `"), issueStatus(bug), strings.Join(missing, "`, `"))
+	}
+	return nil
+}
+
+// epicLinkRequired determines whether the issue must be linked to an epic, which is the case for
+// issues of the configured types (stories by default) when epic links are required
+func epicLinkRequired(issue *jira.Issue, options JiraBranchOptions) bool {
+	if options.RequireEpicLink == nil || !*options.RequireEpicLink || issue.Fields == nil {
+		return false
+	}
+	issueTypes := []string{"Story"}
+	if options.EpicLinkIssueTypes != nil {
+		issueTypes = *options.EpicLinkIssueTypes
+	}
+	return slices.ContainsFunc(issueTypes, func(issueType string) bool { return strings.EqualFold(issueType, issue.Fields.Type.Name) })
+}
+
+// validateEpicLink checks that the issue is linked to an epic, returning the key of the epic
+func validateEpicLink(issue *jira.Issue) (string, error) {
+	issueType := strings.ToLower(issue.Fields.Type.Name)
+	epic, err := helpers.GetIssueEpicLink(issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the epic link of the %s: %w", issueType, err)
+	}
+	if epic == nil {
+		return "", &missingFieldError{msg: fmt.Sprintf("expected the %s to be linked to an epic, but no epic link was set", issueType)}
+	}
+	return *epic, nil
+}
+
+// validateStoryPoints checks that story points are set on the issue. Story points of zero are
+// distinguished from unset story points, and only satisfy the requirement when allowed.
+func validateStoryPoints(issue *jira.Issue, allowZero bool) error {
+	issueType := "issue"
+	if issue.Fields != nil && issue.Fields.Type.Name != "" {
+		issueType = strings.ToLower(issue.Fields.Type.Name)
+	}
+	points, err := helpers.GetIssueStoryPoints(issue)
+	if err != nil {
+		return fmt.Errorf("failed to get the story points of the %s: %w", issueType, err)
+	}
+	if points == nil {
+		return &missingFieldError{msg: fmt.Sprintf("expected the %s to have story points, but no story points were set", issueType)}
+	}
+	if *points == 0 && !allowZero {
+		return fmt.Errorf("expected the %s to have story points, but its story points are zero", issueType)
+	}
+	return nil
+}
+
+func validateFixVersion(issue *jira.Issue, requiredFixVersion string) error {
+	issueType := "bug"
+	if issue.Fields != nil && issue.Fields.Type.Name != "" {
+		issueType = strings.ToLower(issue.Fields.Type.Name)
+	}
+	if issue.Fields == nil || len(issue.Fields.FixVersions) == 0 {
+		return &missingFieldError{msg: fmt.Sprintf("expected the %s to have the %q fix version, but no fix version was set", issueType, requiredFixVersion)}
+	}
+	truncatedRequiredFixVersion, truncatedPrefixedRequiredFixVersion := truncateRequiredVersion(issue, requiredFixVersion)
+	var fixVersions []string
+	for _, fixVersion := range issue.Fields.FixVersions {
+		if fixVersion == nil {
+			continue
+		}
+		// bugs may have multiple fix versions; only one of them needs to match
+		if strings.HasPrefix(fixVersion.Name, truncatedRequiredFixVersion) || strings.HasPrefix(fixVersion.Name, truncatedPrefixedRequiredFixVersion) {
+			return nil
+		}
+		fixVersions = append(fixVersions, fmt.Sprintf("%q", fixVersion.Name))
+	}
+	return fmt.Errorf("expected the %s to have a fix version of either %q or %q, but it has %s instead", issueType, fmt.Sprintf("%s.*", truncatedRequiredFixVersion), fmt.Sprintf("%s.*", truncatedPrefixedRequiredFixVersion), strings.Join(fixVersions, ", "))
+}
+
+// truncateRequiredVersion returns the required version truncated to its major and minor components
+// along with the same version prefixed with `openshift-`. DFBUGS versions are not truncated.
+// TODO: Remove this truncated version check...
+func truncateRequiredVersion(issue *jira.Issue, requiredVersion string) (string, string) {
+	var project string
+	if issue.Fields != nil {
+		project = issue.Fields.Project.Key
+	}
+	return truncateVersionForProject(project, requiredVersion)
+}
+
+// truncateVersionForProject truncates the required version as truncateRequiredVersion does for issues in the project
+func truncateVersionForProject(project, requiredVersion string) (string, string) {
+	truncatedRequiredVersion := requiredVersion
+	pieces := strings.Split(requiredVersion, ".")
+	if project != "DFBUGS" && len(pieces) >= 2 {
+		truncatedRequiredVersion = fmt.Sprintf("%s.%s", pieces[0], pieces[1])
+	}
+	return truncatedRequiredVersion, fmt.Sprintf("openshift-%s", truncatedRequiredVersion)
+}
+
+// targetVersionAllowed determines whether the version matches one of the allowed versions, using the same
+// prefix matching as the target version check: allowed versions are truncated for issues in the project and may be
+// prefixed with `openshift-`
+func targetVersionAllowed(project string, allowed []string, version string) bool {
+	for _, allowedVersion := range allowed {
+		truncated, prefixed := truncateVersionForProject(project, allowedVersion)
+		if strings.HasPrefix(version, truncated) || strings.HasPrefix(version, prefixed) {
+			return true
+		}
+	}
+	return false
+}
+
+// prettyTargetVersions formats the patterns that versions are matched against by targetVersionAllowed
+func prettyTargetVersions(project string, allowed []string) string {
+	var patterns []string
+	seen := sets.New[string]()
+	for _, allowedVersion := range allowed {
+		truncated, prefixed := truncateVersionForProject(project, allowedVersion)
+		for _, pattern := range []string{truncated + ".*", prefixed + ".*"} {
+			if !seen.Has(pattern) {
+				seen.Insert(pattern)
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+	return strings.Join(patterns, ", ")
+}
+
 type prParts struct {
 	Org  string
 	Repo string
 	Num  int
 }
 
-func handleMerge(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry, allRepos sets.Set[string]) error {
-	if options.StateAfterMerge == nil {
+// prLink formats a markdown link to the pull request
+func prLink(pr prParts) string {
+	return fmt.Sprintf("[%s/%s#%d](https://github.com/%s/%s/pull/%d)", pr.Org, pr.Repo, pr.Num, pr.Org, pr.Repo, pr.Num)
+}
+
+// linkedPullRequestsMessage lists the pull requests that the bug refers to using the external bug tracker
+func linkedPullRequestsMessage(jc jiraclient.Client, issue *jira.Issue) (string, error) {
+	links, err := jc.GetRemoteLinks(issue.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote links: %w", err)
+	}
+	var pullRequests []string
+	for _, link := range links {
+		pr, isPR, err := prPartsFromRemoteLink(link)
+		if err != nil || !isPR {
+			continue
+		}
+		pullRequests = append(pullRequests, fmt.Sprintf(" * %s", prLink(pr)))
+	}
+	if len(pullRequests) == 0 {
+		return "No pull requests are linked to the bug via external trackers.", nil
+	}
+	return fmt.Sprintf("The following pull requests are linked to the bug via external trackers:\n%s", strings.Join(pullRequests, "\n")), nil
+}
+
+// prPartsFromRemoteLink parses the GitHub pull request a Jira remote link points at. Links to the files or commits
+// of a pull request identify the pull request itself. Links that do not point at a pull request are reported as
+// such, while links that do but cannot be parsed are returned as errors.
+func prPartsFromRemoteLink(link jira.RemoteLink) (prParts, bool, error) {
+	if link.Object == nil {
+		return prParts{}, false, nil
+	}
+	identifier := strings.TrimPrefix(link.Object.URL, "https://github.com/")
+	parts := strings.Split(identifier, "/")
+	if len(parts) >= 3 && parts[2] != "pull" {
+		// this is not a github pull request link
+		return prParts{}, false, nil
+	}
+	if len(parts) != 4 && (len(parts) != 5 || parts[4] != "" && parts[4] != "files") && (len(parts) != 6 || ((parts[4] != "files" || parts[5] != "") && parts[4] != "commits")) {
+		return prParts{}, true, fmt.Errorf("invalid pull identifier with %d parts: %q", len(parts), identifier)
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return prParts{}, true, fmt.Errorf("invalid pull identifier: could not parse %s as number: %w", parts[3], err)
+	}
+	return prParts{Org: parts[0], Repo: parts[1], Num: number}, true, nil
+}
+
+func handleMerge(e event, gc githubClient, jc jiraclient.Client, inserter BigQueryInserter, options JiraBranchOptions, log *logrus.Entry, allRepos sets.Set[string]) error {
+	if options.StateAfterMerge == nil || transitionsDisabled(options) {
 		return nil
 	}
 	if e.missing {
@@ -1699,10 +3355,14 @@ func handleMerge(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 		if msg != "" {
 			msg += "\n\n"
 		}
-		bug, err := getJira(jc, refIssue.Key(), log, comment)
+		bug, err := getJira(jc, options, refIssue.Key(), log, comment)
 		if err != nil || bug == nil {
 			return err
 		}
+		if e.finalize && bugMatchesStates(bug, []JiraBugState{*options.StateAfterMerge}) {
+			msg += fmt.Sprintf(issueLink+" is already in the %s state, so there is nothing to finalize.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), options.StateAfterMerge)
+			continue
+		}
 		if options.ValidStates != nil || options.StateAfterValidation != nil {
 			// we should only migrate if we can be fairly certain that the bug
 			// is not in a state that required human intervention to get to.
@@ -1726,36 +3386,43 @@ func handleMerge(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 		links, err := jc.GetRemoteLinks(bug.ID)
 		if err != nil {
 			log.WithError(err).Warn("Unexpected error listing external tracker bugs for Jira bug.")
-			msg += formatError("searching for external tracker bugs", jc.JiraURL(), refIssue.Key(), err)
+			msg += formatError(options, "searching for external tracker bugs", jc.JiraURL(), refIssue.Key(), err)
 			continue
 		}
-		shouldMigrate := true
-		var mergedPRs []prParts
-		unmergedPrStates := map[prParts]string{}
-		prsVerified := true // track whether external PRs are all verified; this is changed to false if a PR does not have the verified label
+		// identify all linked pull requests up front so that their state can be fetched in a single batch
+		type linkedPR struct {
+			item     prParts
+			parseErr string
+		}
+		var linkedPRs []linkedPR
+		var toFetch []prParts
 		for _, link := range links {
-			identifier := strings.TrimPrefix(link.Object.URL, "https://github.com/")
-			parts := strings.Split(identifier, "/")
-			if len(parts) >= 3 && parts[2] != "pull" {
-				// this is not a github link
-				continue
-			}
-			if len(parts) != 4 && (len(parts) != 5 || parts[4] != "" && parts[4] != "files") && (len(parts) != 6 || ((parts[4] != "files" || parts[5] != "") && parts[4] != "commits")) {
+			item, isPR, err := prPartsFromRemoteLink(link)
+			if err != nil {
 				log.WithError(err).Warn("Unexpected error splitting github URL for Jira external link.")
-				msg += formatError(fmt.Sprintf("invalid pull identifier with %d parts: %q", len(parts), identifier), jc.JiraURL(), refIssue.Key(), err)
+				linkedPRs = append(linkedPRs, linkedPR{parseErr: formatError(options, "parsing the pull request linked via an external tracker", jc.JiraURL(), refIssue.Key(), err)})
 				continue
 			}
-			number, err := strconv.Atoi(parts[3])
-			if err != nil {
-				log.WithError(err).Warn("Unexpected error splitting github URL for Jira external link.")
-				msg += formatError(fmt.Sprintf("invalid pull identifier: could not parse %s as number", parts[3]), jc.JiraURL(), refIssue.Key(), err)
+			if !isPR {
 				continue
 			}
-			item := prParts{
-				Org:  parts[0],
-				Repo: parts[1],
-				Num:  number,
+			linkedPRs = append(linkedPRs, linkedPR{item: item})
+			if !(e.org == item.Org && e.repo == item.Repo && e.number == item.Num) && allRepos.Has(item.Org+"/"+item.Repo) {
+				toFetch = append(toFetch, item)
+			}
+		}
+		pulls, fetchErrs := getPullRequests(gc, toFetch)
+
+		shouldMigrate := true
+		var mergedPRs []prParts
+		unmergedPrStates := map[prParts]string{}
+		prsVerified := true // track whether external PRs are all verified; this is changed to false if a PR does not have the verified label
+		for _, linked := range linkedPRs {
+			if linked.parseErr != "" {
+				msg += linked.parseErr
+				continue
 			}
+			item := linked.item
 			var merged bool
 			var state string
 			if e.org == item.Org && e.repo == item.Repo && e.number == item.Num {
@@ -1768,15 +3435,15 @@ func handleMerge(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 					logrus.WithField("pr", item.Org+"/"+item.Repo+"#"+strconv.Itoa(item.Num)).Debug("Not processing PR from third-party repo")
 					continue
 				}
-				pr, err := gc.GetPullRequest(item.Org, item.Repo, item.Num)
-				if err != nil {
+				if err := fetchErrs[item]; err != nil {
 					log.WithError(err).Warn("Unexpected error checking merge state of related pull request.")
-					msg += formatError(fmt.Sprintf("checking the state of a related pull request at https://github.com/%s/%s/pull/%d", item.Org, item.Repo, item.Num), jc.JiraURL(), refIssue.Key(), err)
+					msg += formatError(options, fmt.Sprintf("checking the state of a related pull request at https://github.com/%s/%s/pull/%d", item.Org, item.Repo, item.Num), jc.JiraURL(), refIssue.Key(), err)
 					continue
 				}
+				pr := pulls[item]
 				merged = pr.Merged
 				state = pr.State
-				prsVerified = prsVerified && isCommentVerified(pr.Labels)
+				prsVerified = prsVerified && isPRVerified(pr.Labels, options)
 			}
 			if merged {
 				mergedPRs = append(mergedPRs, item)
@@ -1786,21 +3453,16 @@ func handleMerge(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 			// only update Jira bug status if all PRs have merged
 			shouldMigrate = shouldMigrate && merged
 			if !shouldMigrate {
-				// we could give more complete feedback to the user by checking all PRs
-				// but we save tokens by exiting when we find an unmerged one, so we
-				// prefer to do that
+				// we could give more complete feedback to the user by checking all PRs,
+				// but we keep the feedback focused on the first unmerged one
 				break
 			}
 		}
 
-		link := func(pr prParts) string {
-			return fmt.Sprintf("[%s/%s#%d](https://github.com/%s/%s/pull/%d)", pr.Org, pr.Repo, pr.Num, pr.Org, pr.Repo, pr.Num)
-		}
-
 		mergedMessage := func(statement string) string {
 			var links []string
 			for _, bug := range mergedPRs {
-				links = append(links, fmt.Sprintf(" * %s", link(bug)))
+				links = append(links, fmt.Sprintf(" * %s", prLink(bug)))
 			}
 			return fmt.Sprintf(`%s pull requests linked via external trackers have merged:
 %s
@@ -1810,14 +3472,14 @@ func handleMerge(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 
 		var statements []string
 		for bug, state := range unmergedPrStates {
-			statements = append(statements, fmt.Sprintf(" * %s is %s", link(bug), state))
+			statements = append(statements, fmt.Sprintf(" * %s is %s", prLink(bug), state))
 		}
 		unmergedMessage := fmt.Sprintf(`The following pull requests linked via external trackers have not merged:
 %s
 
-These pull request must merge or be unlinked from the Jira bug in order for it to move to the next state. Once unlinked, request a bug refresh with <code>/jira refresh</code>.
+These pull request must merge or be unlinked from the Jira bug in order for it to move to the next state. %s
 
-`, strings.Join(statements, "\n"))
+`, strings.Join(statements, "\n"), refreshHint(options, "Once unlinked, request a bug refresh with <code>/jira refresh</code>."))
 
 		outcomeMessage := func(action string) string {
 			return fmt.Sprintf(issueLink+" has %sbeen moved to the %s state.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), action, options.StateAfterMerge)
@@ -1825,22 +3487,28 @@ These pull request must merge or be unlinked from the Jira bug in order for it t
 
 		if shouldMigrate {
 			var commentVerified, premergeVerified bool
+			var fieldChanges []fieldChange
 			if labels, err := gc.GetIssueLabels(e.org, e.repo, e.number); err != nil {
 				log.WithError(err).Warn("Could not list labels on PR")
 			} else {
 				premergeVerified = isPreMergeVerified(bug, labels)
-				commentVerified = prsVerified && isCommentVerified(labels)
+				commentVerified = prsVerified && isPRVerified(labels, options)
 			}
 			if commentVerified {
 				outcomeMessage = func(action string) string {
-					return fmt.Sprintf("All linked pull requests have the `verified` tag. "+issueLink+" has %sbeen moved to the `VERIFIED` state.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), action)
+					return fmt.Sprintf("All linked pull requests have the `%s` tag. "+issueLink+" has %sbeen moved to the `VERIFIED` state.", verifiedLabel(options), refIssue.Key(), jc.JiraURL(), refIssue.Key(), action)
 				}
 				if bug.Fields.Status == nil || !strings.EqualFold("VERIFIED", bug.Fields.Status.Name) {
+					oldStatus := issueStatus(bug)
 					if err := jc.UpdateStatus(bug.Key, "VERIFIED"); err != nil {
 						log.WithError(err).Warn("Unexpected error updating jira issue.")
-						msg += formatError(fmt.Sprintf("updating to the %s state", options.PreMergeStateAfterClose.Status), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+						msg += formatError(options, fmt.Sprintf("updating to the %s state", options.PreMergeStateAfterClose.Status), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
 						continue
 					}
+					recordTransition(e, "VERIFIED")
+					recordAudit(log, e, auditActionTransition, bug.Key, oldStatus, "VERIFIED")
+					fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: "VERIFIED"})
+					recordTimeInState(inserter, jc, e, options, bug, oldStatus, "VERIFIED", log)
 				}
 			} else if premergeVerified {
 				outcomeMessage = func(action string) string {
@@ -1848,41 +3516,65 @@ These pull request must merge or be unlinked from the Jira bug in order for it t
 				}
 				if options.PreMergeStateAfterMerge != nil {
 					if options.PreMergeStateAfterMerge.Status != "" && (bug.Fields.Status == nil || !strings.EqualFold(bug.Fields.Status.Name, options.PreMergeStateAfterMerge.Status)) {
+						oldStatus := issueStatus(bug)
 						if err := jc.UpdateStatus(bug.Key, options.PreMergeStateAfterMerge.Status); err != nil {
 							log.WithError(err).Warn("Unexpected error updating jira bug.")
-							msg += formatError(fmt.Sprintf("updating to the %s state", options.PreMergeStateAfterMerge.Status), jc.JiraURL(), refIssue.Key(), err)
+							msg += formatError(options, fmt.Sprintf("updating to the %s state", options.PreMergeStateAfterMerge.Status), jc.JiraURL(), refIssue.Key(), err)
 							continue
 						}
+						recordTransition(e, options.PreMergeStateAfterMerge.Status)
+						recordAudit(log, e, auditActionTransition, bug.Key, oldStatus, options.PreMergeStateAfterMerge.Status)
+						fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: options.PreMergeStateAfterMerge.Status})
+						recordTimeInState(inserter, jc, e, options, bug, oldStatus, options.PreMergeStateAfterMerge.Status, log)
 					}
 					if options.PreMergeStateAfterMerge.Resolution != "" && (bug.Fields.Resolution == nil || !strings.EqualFold(bug.Fields.Status.Name, options.PreMergeStateAfterMerge.Resolution)) {
+						oldResolution := issueResolution(bug)
 						updatebug := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: options.PreMergeStateAfterMerge.Resolution}}}
 						if _, err := jc.UpdateIssue(&updatebug); err != nil {
 							log.WithError(err).Warn("Unexpected error updating jira bug.")
-							msg += formatError(fmt.Sprintf("updating to the %s resolution", options.PreMergeStateAfterMerge.Resolution), jc.JiraURL(), refIssue.Key(), err)
+							msg += formatError(options, fmt.Sprintf("updating to the %s resolution", options.PreMergeStateAfterMerge.Resolution), jc.JiraURL(), refIssue.Key(), err)
 							continue
 						}
+						recordAudit(log, e, auditActionResolution, bug.Key, oldResolution, options.PreMergeStateAfterMerge.Resolution)
+						fieldChanges = append(fieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: options.PreMergeStateAfterMerge.Resolution})
 					}
 				}
 			} else {
 				if options.StateAfterMerge != nil {
 					if options.StateAfterMerge.Status != "" && (bug.Fields.Status == nil || !strings.EqualFold(options.StateAfterMerge.Status, bug.Fields.Status.Name)) {
+						oldStatus := issueStatus(bug)
 						if err := jc.UpdateStatus(refIssue.Key(), options.StateAfterMerge.Status); err != nil {
 							log.WithError(err).Warn("Unexpected error updating jira issue.")
-							msg += formatError(fmt.Sprintf("updating to the %s state", options.StateAfterMerge.Status), jc.JiraURL(), refIssue.Key(), err)
+							msg += formatError(options, fmt.Sprintf("updating to the %s state", options.StateAfterMerge.Status), jc.JiraURL(), refIssue.Key(), err)
 							continue
 						}
+						recordTransition(e, options.StateAfterMerge.Status)
+						recordAudit(log, e, auditActionTransition, bug.Key, oldStatus, options.StateAfterMerge.Status)
+						fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: options.StateAfterMerge.Status})
+						recordTimeInState(inserter, jc, e, options, bug, oldStatus, options.StateAfterMerge.Status, log)
 						if options.StateAfterMerge.Resolution != "" && (bug.Fields.Resolution == nil || !strings.EqualFold(options.StateAfterMerge.Resolution, bug.Fields.Resolution.Name)) {
+							oldResolution := issueResolution(bug)
 							updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: options.StateAfterMerge.Resolution}}}
 							if _, err := jc.UpdateIssue(&updateIssue); err != nil {
 								log.WithError(err).Warn("Unexpected error updating jira issue.")
-								msg += formatError(fmt.Sprintf("updating to the %s resolution", options.StateAfterMerge.Resolution), jc.JiraURL(), refIssue.Key(), err)
+								msg += formatError(options, fmt.Sprintf("updating to the %s resolution", options.StateAfterMerge.Resolution), jc.JiraURL(), refIssue.Key(), err)
 								continue
 							}
+							recordAudit(log, e, auditActionResolution, bug.Key, oldResolution, options.StateAfterMerge.Resolution)
+							fieldChanges = append(fieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: options.StateAfterMerge.Resolution})
 						}
 					}
 				}
 			}
-			msg += fmt.Sprintf(issueLink+": %s%s", refIssue.Key(), jc.JiraURL(), refIssue.Key(), mergedMessage("All"), outcomeMessage(""))
+			var pullRequests []string
+			for _, pr := range mergedPRs {
+				pullRequests = append(pullRequests, prLink(pr))
+			}
+			if templated, hasTemplate := renderCommentTemplate(options, commentTemplateMerged, commentTemplateData{Key: refIssue.Key(), URL: issueURL(jc.JiraURL(), refIssue.Key()), PullRequests: pullRequests}, log); hasTemplate {
+				msg += templated + outcomeMessage("") + fieldChangesComment(options, refIssue.Key(), fieldChanges)
+				continue
+			}
+			msg += fmt.Sprintf(issueLink+": %s%s%s", refIssue.Key(), jc.JiraURL(), refIssue.Key(), mergedMessage("All"), outcomeMessage(""), fieldChangesComment(options, refIssue.Key(), fieldChanges))
 			continue
 		}
 		msg += fmt.Sprintf(issueLink+": %s%s%s", refIssue.Key(), jc.JiraURL(), refIssue.Key(), mergedMessage("Some"), unmergedMessage, outcomeMessage("not "))
@@ -1894,6 +3586,54 @@ These pull request must merge or be unlinked from the Jira bug in order for it t
 	}
 }
 
+// handleFinalize re-runs the merge handling for a merged pull request, for when the merge event was missed. As bugs
+// already in the state after merge are not moved again, finalizing is idempotent.
+func handleFinalize(e event, gc githubClient, jc jiraclient.Client, inserter BigQueryInserter, options JiraBranchOptions, log *logrus.Entry, allRepos sets.Set[string]) error {
+	comment := e.comment(gc)
+	if !e.merged {
+		return comment("This pull request has not merged yet. Bugs are moved to their state after merge once it merges; `/jira finalize` is only needed if that did not happen.")
+	}
+	if options.StateAfterMerge == nil || transitionsDisabled(options) {
+		return comment("Bugs are not moved to a new state when pull requests targeting this branch merge, so there is nothing to finalize.")
+	}
+	if e.missing || !slices.ContainsFunc(e.issues, func(issue referencedIssue) bool { return issue.IsBug }) {
+		return comment("No Jira bug is referenced in the title of this pull request, so there is nothing to finalize.")
+	}
+	return handleMerge(e, gc, jc, inserter, options, log, allRepos)
+}
+
+// maxConcurrentPullRequestFetches limits the number of pull requests that are fetched from GitHub at once
+const maxConcurrentPullRequestFetches = 5
+
+// getPullRequests fetches the provided pull requests concurrently, fetching each distinct pull request only once.
+// The returned maps hold the pull request or the error encountered while fetching it.
+func getPullRequests(gc pullRequestClient, prs []prParts) (map[prParts]*github.PullRequest, map[prParts]error) {
+	unique := sets.New[prParts](prs...)
+	pulls := make(map[prParts]*github.PullRequest, unique.Len())
+	errs := map[prParts]error{}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentPullRequestFetches)
+	for item := range unique {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			pr, err := gc.GetPullRequest(item.Org, item.Repo, item.Num)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs[item] = err
+				return
+			}
+			pulls[item] = pr
+		}()
+	}
+	wg.Wait()
+	return pulls, errs
+}
+
 func identifyClones(issue *jira.Issue) []*jira.Issue {
 	var clones []*jira.Issue
 	for _, link := range issue.Fields.IssueLinks {
@@ -1906,7 +3646,7 @@ func identifyClones(issue *jira.Issue) []*jira.Issue {
 	return clones
 }
 
-func handleCherrypick(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+func handleCherrypick(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, bugProjects sets.Set[string], log *logrus.Entry) error {
 	comment := e.comment(gc)
 	var issues []referencedIssue
 	if e.cherrypickCmd {
@@ -1916,10 +3656,10 @@ func handleCherrypick(e event, gc githubClient, jc jiraclient.Client, options Ji
 		pr, err := gc.GetPullRequest(e.org, e.repo, e.cherrypickFromPRNum)
 		if err != nil {
 			log.WithError(err).Warn("Unexpected error getting title of pull request being cherrypicked from.")
-			return comment(fmt.Sprintf("Error creating a cherry-pick bug in Jira: failed to check the state of cherrypicked pull request at https://github.com/%s/%s/pull/%d: %v.\nPlease contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.", e.org, e.repo, e.cherrypickFromPRNum, err))
+			return comment(fmt.Sprintf("Error creating a cherry-pick bug in Jira: failed to check the state of cherrypicked pull request at https://github.com/%s/%s/pull/%d: %v.\n%s", e.org, e.repo, e.cherrypickFromPRNum, err, refreshHint(options, "Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.")))
 		}
-		// Attempt to identify bug from PR title
-		issues, _, _ = jiraKeyFromTitle(pr.Title)
+		// Attempt to identify bug from the PR
+		issues, _, _ = jiraKeysFromPullRequest(pr.Title, pr.Body, options, bugProjects)
 		if len(issues) == 0 {
 			log.Debugf("Parent PR %d doesn't have associated bug; not creating cherrypicked bug", pr.Number)
 			// if there is no jira bug, we should simply ignore this PR
@@ -1950,7 +3690,7 @@ func handleCherrypick(e event, gc githubClient, jc jiraclient.Client, options Ji
 
 	retitleList := make(map[string]string)
 	for _, refIssue := range bugs {
-		bug, err := getJira(jc, refIssue.Key(), log, commentWithPrefix)
+		bug, err := getJira(jc, options, refIssue.Key(), log, commentWithPrefix)
 		if err != nil || bug == nil {
 			return err
 		}
@@ -1963,6 +3703,14 @@ func handleCherrypick(e event, gc githubClient, jc jiraclient.Client, options Ji
 			}
 		}
 		cloneKey, response, err := createCherryPickBug(jc, bug, e.baseRef, options, log)
+		if cloneKey != "" {
+			recordAudit(log, e, auditActionClone, bug.Key, nil, cloneKey)
+			if options.AddCloneLabel != nil && *options.AddCloneLabel {
+				if err := gc.AddLabel(e.org, e.repo, e.number, labels.ClonePrefix+cloneKey); err != nil {
+					log.WithError(err).Error("Failed to add clone label.")
+				}
+			}
+		}
 		retitleList[refIssue.Key()] = cloneKey
 		msg += response
 		if err != nil {
@@ -1972,71 +3720,332 @@ func handleCherrypick(e event, gc githubClient, jc jiraclient.Client, options Ji
 	}
 	msg = strings.TrimSuffix(msg, "\n\n")
 	if len(retitleList) > 0 {
-		// Replace old bugID(s) in title with new cloneID(s)
-		var newTitle string
-		if e.cherrypickCmd {
-			// use a set to allow sorted keys for deterministic results
-			keySet := sets.NewString()
-			for _, newBug := range retitleList {
-				keySet.Insert(newBug)
-			}
-			var keyList string
-			for _, newBug := range keySet.List() {
-				keyList += newBug + ","
-			}
-			keyList = strings.TrimSuffix(keyList, ",")
-			newTitle = fmt.Sprintf("%s: %s", keyList, e.title)
-		} else {
-			newTitle = e.title
-			for oldKey, newKey := range retitleList {
-				newTitle = strings.ReplaceAll(newTitle, oldKey, newKey)
-			}
-		}
-		msg += "\n/retitle " + newTitle
+		msg += retitleInstruction(options, "", cloneTitle(e, retitleList))
 	}
 	return comment(msg)
 }
 
-// createCherrypickBug has the following return values:
-// 1. string: key of clone
-// 2. string: message to print after clone. The `handleBackport` function does not use this field.
-// 3. error: a message regarding what went wrong during the clone
-func createCherryPickBug(jc jiraclient.Client, bug *jira.Issue, branch string, options JiraBranchOptions, log *logrus.Entry) (string, string, error) {
-	allowed, err := isBugAllowed(bug, options.AllowedSecurityLevels)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to check is issue is in allowed security level: %w", err)
+// cloneTitle returns the title of the PR with the bugs in the retitle list (original key -> clone key) replaced
+// by their clones. Clones of bugs requested with the cherrypick command are prefixed to the title instead.
+func cloneTitle(e event, retitleList map[string]string) string {
+	if e.cherrypickCmd {
+		// use a set to allow sorted keys for deterministic results
+		keySet := sets.NewString()
+		for _, newBug := range retitleList {
+			keySet.Insert(newBug)
+		}
+		var keyList string
+		for _, newBug := range keySet.List() {
+			keyList += newBug + ","
+		}
+		keyList = strings.TrimSuffix(keyList, ",")
+		return fmt.Sprintf("%s: %s", keyList, e.title)
 	}
-	if !allowed {
-		// ignore bugs that are in non-allowed groups for this repo
-		return "", "", nil
+	newTitle := e.title
+	for oldKey, newKey := range retitleList {
+		// keys are normalized to uppercase, but may have been referenced in lowercase in the title
+		newTitle = regexp.MustCompile(`(?i)`+regexp.QuoteMeta(oldKey)).ReplaceAllLiteralString(newTitle, newKey)
 	}
-	oldLink := fmt.Sprintf(issueLink, bug.Key, jc.JiraURL(), bug.Key)
-	for _, label := range bug.Fields.Labels {
-		match := existingBackportMatch.FindString(label)
-		if len(match) > 0 {
-			match = strings.TrimPrefix(match, "jlp-")
-			branchKey := strings.Split(match, ":")
-			if branchKey[0] == branch {
-				return branchKey[1], fmt.Sprintf("Detected clone of %s with correct target version. Will retitle the PR to link to the clone.", oldLink), nil
-			}
+	return newTitle
+}
+
+// handleLinkClone links an existing issue as a clone of the bug referenced in the PR title, creating the same
+// links a cherrypick would, and retitles the PR to reference the clone
+func handleLinkClone(e event, gc commentClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	commentWithPrefix := func(body string) error {
+		return comment(fmt.Sprintf("Failed to link the clone in Jira: %s", body))
+	}
+	var bugs []referencedIssue
+	for _, refIssue := range e.issues {
+		if refIssue.IsBug {
+			bugs = append(bugs, refIssue)
 		}
 	}
-	if options.TargetVersion == nil {
-		// this should never happen if the config is properly set up
-		msg := fmt.Sprintf("Could not make automatic cherrypick of %s for this PR as the target version is not set for this branch in the jira plugin config. Running refresh:\n/jira refresh", oldLink) + "\n\n"
-		return "", msg, nil
+	if len(bugs) != 1 {
+		return comment(fmt.Sprintf("The `/jira link-clone` command requires exactly one Jira bug to be referenced in the title of this pull request, but %d are referenced.", len(bugs)))
 	}
-	targetVersion := *options.TargetVersion
-	clones := identifyClones(bug)
-	for _, baseClone := range clones {
-		// get full issue struct
-		clone, err := jc.GetIssue(baseClone.Key)
-		if err != nil {
-			return "", "", fmt.Errorf("failed to get %s, which is a clone of %s: %w", baseClone.Key, bug.Key, err)
+	parent, err := getJira(jc, options, bugs[0].Key(), log, commentWithPrefix)
+	if err != nil || parent == nil {
+		return err
+	}
+	clone, err := getJira(jc, options, e.linkClone, log, commentWithPrefix)
+	if err != nil || clone == nil {
+		return err
+	}
+	parentLink := fmt.Sprintf(issueLink, parent.Key, jc.JiraURL(), parent.Key)
+	cloneLink := fmt.Sprintf(issueLink, clone.Key, jc.JiraURL(), clone.Key)
+	if parent.Key == clone.Key {
+		return comment(fmt.Sprintf("%s cannot be linked as a clone of itself.", cloneLink))
+	}
+	if parent.Fields.Project.Key != clone.Fields.Project.Key {
+		return comment(fmt.Sprintf("%s cannot be linked as a clone of %s, as it is in the %s project instead of the %s project.", cloneLink, parentLink, clone.Fields.Project.Key, parent.Fields.Project.Key))
+	}
+	var hasClonersLink, hasBlocksLink bool
+	for _, link := range clone.Fields.IssueLinks {
+		// the outward issue of the Cloners type is always the issue that the provided issue was cloned from
+		if link.Type.Name == "Cloners" && link.OutwardIssue != nil && link.OutwardIssue.Key == parent.Key {
+			hasClonersLink = true
+		}
+		if link.Type.Name == "Blocks" && link.InwardIssue != nil && link.InwardIssue.Key == parent.Key {
+			hasBlocksLink = true
+		}
+	}
+	var links []jira.IssueLink
+	if !hasClonersLink {
+		links = append(links, clonersLink(parent, clone))
+	}
+	if !hasBlocksLink {
+		links = append(links, blocksLink(parent, clone))
+	}
+	for _, link := range links {
+		if err := jc.CreateIssueLink(&link); err != nil {
+			log.WithError(err).Warn("Unexpected error creating jira issue link.")
+			return comment(formatError(options, fmt.Sprintf("creating `%s` type link with %s", link.Type.Name, parentLink), jc.JiraURL(), clone.Key, err))
+		}
+		recordAudit(log, e, auditActionIssueLinkAdd, clone.Key, nil, parent.Key)
+	}
+	msg := fmt.Sprintf("%s has been linked as a clone of %s.", cloneLink, parentLink)
+	if len(links) == 0 {
+		msg = fmt.Sprintf("%s is already linked as a clone of %s.", cloneLink, parentLink)
+	}
+	if newTitle := cloneTitle(e, map[string]string{parent.Key: clone.Key}); newTitle != e.title {
+		msg += retitleInstruction(options, " Will retitle the PR to link to the clone.", newTitle)
+	}
+	return comment(msg)
+}
+
+// handleCreate creates a bug in the configured project for a PR that does not reference a Jira issue, populated
+// from the title and description of the PR, and retitles the PR to reference it. The created bug links back to
+// the PR when external links are enabled for the branch.
+func handleCreate(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	if ok, err := checkCollaborator(gc, e, "/jira create", comment, log); !ok {
+		return err
+	}
+	if !e.missing && !e.noJira {
+		return comment("The `/jira create` command can only be used on pull requests that do not reference a Jira issue in their title.")
+	}
+	if options.CreateIssueProject == nil || options.CreateIssueAssignee == nil {
+		return comment("The `/jira create` command requires a project and an assignee for created bugs to be configured for this branch.")
+	}
+	pr, err := gc.GetPullRequest(e.org, e.repo, e.number)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get pull request.")
+		return comment(fmt.Sprintf("Failed to get the description of this pull request: %v", err))
+	}
+	title := pr.Title
+	if e.noJira {
+		// drop the NO-ISSUE/NO-JIRA prefix, as the title will reference the created bug instead
+		title = strings.TrimSpace(strings.Replace(title, titleMatchJiraIssue.FindString(title), "", 1))
+	}
+	prURL := fmt.Sprintf("https://github.com/%s/%s/pull/%d", e.org, e.repo, e.number)
+	bug := &jira.Issue{Fields: &jira.IssueFields{
+		Project:     jira.Project{Key: *options.CreateIssueProject},
+		Type:        jira.IssueType{Name: "Bug"},
+		Summary:     title,
+		Description: fmt.Sprintf("This bug was created from %s.\n\n%s", prURL, pr.Body),
+		Assignee:    &jira.User{Name: *options.CreateIssueAssignee},
+	}}
+	created, err := jc.CreateIssue(bug)
+	if err != nil {
+		log.WithError(err).Warn("Unexpected error creating jira issue.")
+		return comment(fmt.Sprintf("An error was encountered creating a bug in the %s project for this pull request: %v", *options.CreateIssueProject, err))
+	}
+	recordAudit(log, e, auditActionCreate, created.Key, nil, prURL)
+	createdLink := fmt.Sprintf(issueLink, created.Key, jc.JiraURL(), created.Key)
+	newTitle := fmt.Sprintf("%s: %s", created.Key, title)
+	if options.AddExternalLink == nil || !*options.AddExternalLink {
+		return comment(fmt.Sprintf("%s has been created for this pull request.%s", createdLink, retitleInstruction(options, " Will retitle the PR to link to the bug.", newTitle)))
+	}
+	e.title = newTitle
+	if _, _, err := upsertGitHubLinkToIssue(log, created, jc, options, e); err != nil {
+		log.WithError(err).Warn("Unexpected error adding external tracker bug to Jira bug.")
+		return comment(fmt.Sprintf("%s has been created, but an error was encountered linking it to this pull request: %v%s", createdLink, err, retitleInstruction(options, "\nWill retitle the PR to link to the bug.", newTitle)))
+	}
+	return comment(fmt.Sprintf("%s has been created and linked to this pull request.%s", createdLink, retitleInstruction(options, " Will retitle the PR to link to the bug.", newTitle)))
+}
+
+// clonersLink returns the link that marks the clone as having been cloned from the parent issue
+func clonersLink(parent, clone *jira.Issue) jira.IssueLink {
+	return jira.IssueLink{
+		OutwardIssue: &jira.Issue{ID: parent.ID},
+		InwardIssue:  &jira.Issue{ID: clone.ID},
+		Type: jira.IssueLinkType{
+			Name:    "Cloners",
+			Inward:  "is cloned by",
+			Outward: "clones",
+		},
+	}
+}
+
+// blocksLink returns the link that marks the parent issue as being blocked by its clone
+func blocksLink(parent, clone *jira.Issue) jira.IssueLink {
+	return jira.IssueLink{
+		OutwardIssue: &jira.Issue{ID: clone.ID},
+		InwardIssue:  &jira.Issue{ID: parent.ID},
+		Type: jira.IssueLinkType{
+			Name:    "Blocks",
+			Inward:  "is blocked by",
+			Outward: "blocks",
+		},
+	}
+}
+
+// handleUncherrypick removes the links that were created between the bugs referenced in the PR title and the
+// bugs they were cloned from during a cherrypick, along with the backport labels on the parent bugs
+func handleUncherrypick(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	var msgs []string
+	for _, refIssue := range e.issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		clone, err := getJira(jc, options, refIssue.Key(), log, comment)
+		if err != nil || clone == nil {
+			return err
+		}
+		cloneLink := fmt.Sprintf(issueLink, clone.Key, jc.JiraURL(), clone.Key)
+		// the outward issue of the Cloners type is always the issue that the provided issue was cloned from
+		var parentKey string
+		var linkIDs []string
+		for _, link := range clone.Fields.IssueLinks {
+			if link.Type.Name == "Cloners" && link.OutwardIssue != nil {
+				parentKey = link.OutwardIssue.Key
+				linkIDs = append(linkIDs, link.ID)
+			}
+		}
+		if parentKey == "" {
+			msgs = append(msgs, fmt.Sprintf("%s is not a clone of another bug; nothing was unlinked.", cloneLink))
+			continue
+		}
+		// cherrypicks also create a link where the clone blocks its parent
+		for _, link := range clone.Fields.IssueLinks {
+			if link.Type.Name == "Blocks" && link.InwardIssue != nil && link.InwardIssue.Key == parentKey {
+				linkIDs = append(linkIDs, link.ID)
+			}
+		}
+		parentLink := fmt.Sprintf(issueLink, parentKey, jc.JiraURL(), parentKey)
+		var failed bool
+		for _, id := range linkIDs {
+			if err := jc.DeleteLink(id); err != nil {
+				log.WithError(err).Warn("Unexpected error removing jira issue link.")
+				msgs = append(msgs, formatError(options, fmt.Sprintf("removing the link between %s and %s", clone.Key, parentKey), jc.JiraURL(), clone.Key, err))
+				failed = true
+				break
+			}
+			recordAudit(log, e, auditActionIssueLinkRemove, clone.Key, parentKey, nil)
+		}
+		if failed {
+			continue
+		}
+		parent, err := jc.GetIssue(parentKey)
+		if err != nil {
+			log.WithError(err).Warn("Unexpected error getting jira issue.")
+			msgs = append(msgs, formatError(options, "getting the parent bug", jc.JiraURL(), parentKey, err))
+			continue
+		}
+		var keptLabels, removedLabels []string
+		for _, label := range parent.Fields.Labels {
+			if match := existingBackportMatch.FindString(label); match == label && strings.HasSuffix(label, ":"+clone.Key) {
+				removedLabels = append(removedLabels, label)
+			} else {
+				keptLabels = append(keptLabels, label)
+			}
+		}
+		msg := fmt.Sprintf("Removed the links between %s and the bug it was cloned from, %s.", cloneLink, parentLink)
+		if len(removedLabels) > 0 {
+			updateIssue := jira.Issue{Key: parent.Key, Fields: &jira.IssueFields{Labels: keptLabels}}
+			if len(keptLabels) == 0 {
+				// empty labels are omitted when marshalling the issue, so they must be explicitly cleared
+				updateIssue.Fields.Unknowns = tcontainer.MarshalMap{"labels": []string{}}
+			}
+			if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+				log.WithError(err).Warn("Unexpected error updating jira issue.")
+				msgs = append(msgs, msg, formatError(options, "removing the backport labels", jc.JiraURL(), parentKey, err))
+				continue
+			}
+			recordAudit(log, e, auditActionLabels, parent.Key, parent.Fields.Labels, keptLabels)
+			msg += fmt.Sprintf(" Removed the `%s` label(s) from %s.", strings.Join(removedLabels, "`, `"), parentLink)
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return comment("No Jira bugs are referenced in the title of this pull request; nothing was unlinked.")
+	}
+	return comment(strings.Join(msgs, "\n\n"))
+}
+
+// handleUnlink removes the external link to the current PR from the bugs referenced in the PR title, leaving
+// the state of the bugs untouched
+func handleUnlink(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	prURL := prURLFromCommentURL(e.htmlUrl)
+	var msgs []string
+	for _, refIssue := range e.issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		link := fmt.Sprintf(issueLink, refIssue.Key(), jc.JiraURL(), refIssue.Key())
+		changed, err := jc.DeleteRemoteLinkViaURL(refIssue.Key(), prURL)
+		if err != nil && !strings.HasPrefix(err.Error(), "could not find remote link on issue with URL") {
+			log.WithError(err).Warn("Unexpected error removing external tracker bug from Jira bug.")
+			msgs = append(msgs, formatError(options, "removing this pull request from the external tracker bugs", jc.JiraURL(), refIssue.Key(), err))
+			continue
+		}
+		if !changed {
+			msgs = append(msgs, fmt.Sprintf("%s does not refer to this pull request using the external bug tracker; nothing was unlinked.", link))
+			continue
+		}
+		recordAudit(log, e, auditActionRemoteLinkRemove, refIssue.Key(), prURL, nil)
+		msgs = append(msgs, fmt.Sprintf("%s has been updated to no longer refer to this pull request using the external bug tracker.", link))
+	}
+	if len(msgs) == 0 {
+		return comment("No Jira bugs are referenced in the title of this pull request; nothing was unlinked.")
+	}
+	return comment(strings.Join(msgs, "\n\n"))
+}
+
+// createCherrypickBug has the following return values:
+// 1. string: key of clone
+// 2. string: message to print after clone. The `handleBackport` function does not use this field.
+// 3. error: a message regarding what went wrong during the clone
+func createCherryPickBug(jc jiraclient.Client, bug *jira.Issue, branch string, options JiraBranchOptions, log *logrus.Entry) (string, string, error) {
+	allowed, err := isBugAllowed(bug, options.AllowedSecurityLevels)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check is issue is in allowed security level: %w", err)
+	}
+	if !allowed {
+		// ignore bugs that are in non-allowed groups for this repo
+		return "", "", nil
+	}
+	oldLink := fmt.Sprintf(issueLink, bug.Key, jc.JiraURL(), bug.Key)
+	for _, label := range bug.Fields.Labels {
+		match := existingBackportMatch.FindString(label)
+		if len(match) > 0 {
+			match = strings.TrimPrefix(match, "jlp-")
+			branchKey := strings.Split(match, ":")
+			if branchKey[0] == branch {
+				return branchKey[1], fmt.Sprintf("Detected clone of %s with correct target version. Will retitle the PR to link to the clone.", oldLink), nil
+			}
+		}
+	}
+	if options.TargetVersion == nil {
+		// this should never happen if the config is properly set up
+		msg := fmt.Sprintf("Could not make automatic cherrypick of %s for this PR as the target version is not set for this branch in the jira plugin config. Running refresh:\n/jira refresh", oldLink) + "\n\n"
+		return "", msg, nil
+	}
+	targetVersion := *options.TargetVersion
+	clones := identifyClones(bug)
+	for _, baseClone := range clones {
+		// get full issue struct
+		clone, err := jc.GetIssue(baseClone.Key)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get %s, which is a clone of %s: %w", baseClone.Key, bug.Key, err)
 		}
 		cloneVersion, err := helpers.GetIssueTargetVersion(clone)
 		if err != nil {
-			return "", "", errors.New(formatError(fmt.Sprintf("getting the target version for clone %s", clone.Key), jc.JiraURL(), bug.Key, err))
+			return "", "", errors.New(formatError(options, fmt.Sprintf("getting the target version for clone %s", clone.Key), jc.JiraURL(), bug.Key, err))
 		}
 		if len(cloneVersion) == 1 && cloneVersion[0].Name == targetVersion {
 			return clone.Key, fmt.Sprintf("Detected clone of %s with correct target version. Will retitle the PR to link to the clone.", oldLink), nil
@@ -2056,46 +4065,57 @@ func createCherryPickBug(jc jiraclient.Client, bug *jira.Issue, branch string, o
 	delete(bugCopy.Fields.Unknowns, helpers.SprintField)
 	releaseNoteType := bugCopy.Fields.Unknowns[helpers.ReleaseNoteTypeField]
 	releaseNoteText := bugCopy.Fields.Unknowns[helpers.ReleaseNoteTextField]
-	if len(options.IgnoreCloneLabels) != 0 {
-		labelsSet := sets.New[string](bugCopy.Fields.Labels...)
-		labelsSet.Delete(options.IgnoreCloneLabels...)
-		bugCopy.Fields.Labels = labelsSet.UnsortedList()
+	// fields requested by the config are set again after cloning, as jira drops fields that cannot be set on creation
+	copiedFields := tcontainer.MarshalMap{}
+	for _, field := range options.CloneCopyFields {
+		if value, ok := bugCopy.Fields.Unknowns[field]; ok && value != nil {
+			copiedFields[field] = value
+		}
+	}
+	if len(options.IgnoreCloneLabels) != 0 || len(options.IgnoreCloneLabelPrefixes) != 0 {
+		ignoredLabels := sets.New[string](options.IgnoreCloneLabels...)
+		labels := []string{}
+		for _, label := range bugCopy.Fields.Labels {
+			if ignoredLabels.Has(label) || slices.ContainsFunc(options.IgnoreCloneLabelPrefixes, func(prefix string) bool { return strings.HasPrefix(label, prefix) }) {
+				continue
+			}
+			labels = append(labels, label)
+		}
+		bugCopy.Fields.Labels = labels
 	}
 	// unset assignee so we can more easily check when jira's internal auto-assign completes
 	bugCopy.Fields.Assignee = nil
 	clone, err := jc.CloneIssue(&bugCopy)
 	if err != nil {
 		log.WithError(err).Debugf("Failed to clone bug %+v", bug)
-		return "", "", errors.New(formatError("cloning bug for cherrypick", jc.JiraURL(), bug.Key, err))
+		return "", "", errors.New(formatError(options, "cloning bug for cherrypick", jc.JiraURL(), bug.Key, err))
 	}
 	cloneLink := fmt.Sprintf(issueLink, clone.Key, jc.JiraURL(), clone.Key)
 	// add blocking issue link between parent and clone
-	blockLink := jira.IssueLink{
-		OutwardIssue: &jira.Issue{ID: clone.ID},
-		InwardIssue:  &jira.Issue{ID: bug.ID},
-		Type: jira.IssueLinkType{
-			Name:    "Blocks",
-			Inward:  "is blocked by",
-			Outward: "blocks",
-		},
-	}
+	blockLink := blocksLink(bug, clone)
 	if err := jc.CreateIssueLink(&blockLink); err != nil {
 		log.WithError(err).Debugf("Unable to create blocks link for bug %s", clone.Key)
-		return "", "", errors.New(formatError(fmt.Sprintf("updating cherry-pick bug in Jira: Created cherrypick %s, but encountered error creating `Blocks` type link with original bug", cloneLink), jc.JiraURL(), clone.Key, err))
+		return "", "", errors.New(formatError(options, fmt.Sprintf("updating cherry-pick bug in Jira: Created cherrypick %s, but encountered error creating `Blocks` type link with original bug", cloneLink), jc.JiraURL(), clone.Key, err))
 	}
 	response := fmt.Sprintf("%s has been cloned as %s. Will retitle bug to link to clone.", oldLink, cloneLink)
-	// jira has automation to set the assignee to a default based on component; we wait up to 1 minute to avoid a race
-	for range 10 {
-		if issue, err := jc.GetIssue(clone.Key); err == nil && issue.Fields.Assignee != nil && issue.Fields.Assignee.Name != "" {
-			break
+	assignee := bug.Fields.Assignee
+	if assignee == nil && options.CloneDefaultAssignee != nil {
+		// the configured default takes the place of jira's automation, so there is nothing to wait for
+		assignee = &jira.User{Name: *options.CloneDefaultAssignee}
+	} else {
+		// jira has automation to set the assignee to a default based on component; we wait up to 1 minute to avoid a race
+		for range 10 {
+			if issue, err := jc.GetIssue(clone.Key); err == nil && issue.Fields.Assignee != nil && issue.Fields.Assignee.Name != "" {
+				break
+			}
+			time.Sleep(time.Second * 6)
 		}
-		time.Sleep(time.Second * 6)
 	}
 	// Update the version of the bug to the target release
 	update := jira.Issue{
 		Key: clone.Key,
 		Fields: &jira.IssueFields{
-			Assignee: bug.Fields.Assignee,
+			Assignee: assignee,
 			Unknowns: tcontainer.MarshalMap{
 				helpers.TargetVersionField: []*jira.Version{{Name: targetVersion}},
 			},
@@ -2107,6 +4127,12 @@ func createCherryPickBug(jc jiraclient.Client, bug *jira.Issue, branch string, o
 	if releaseNoteType != nil {
 		update.Fields.Unknowns[helpers.ReleaseNoteTypeField] = releaseNoteType
 	}
+	for field, value := range copiedFields {
+		// fields that are handled explicitly above take precedence
+		if _, ok := update.Fields.Unknowns[field]; !ok {
+			update.Fields.Unknowns[field] = value
+		}
+	}
 	sprintID, err := helpers.GetActiveSprintID(sprintField)
 	errs := []string{}
 	if err != nil {
@@ -2143,8 +4169,11 @@ WARNING: Failed to update the target version, assignee, and sprint for the clone
 	return clone.Key, response, errMsg
 }
 
-func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions map[string]JiraBranchOptions, log *logrus.Entry) error {
-	comment := e.comment(gc)
+// resolveBackportChain determines, for a backport of a PR against the base branch to the provided branches,
+// which branches the clones of the bugs for each branch need to be created on, keyed by the parent branch.
+// Branches whose dependent target versions are not covered by any of the provided branches are reported as
+// missing dependencies, sorted for deterministic messages.
+func resolveBackportChain(baseRef string, backportBranches []string, repoOptions map[string]JiraBranchOptions) (map[string][]string, []string) {
 	versionToBranch := map[string][]string{}
 	for branch, bOpts := range repoOptions {
 		if bOpts.TargetVersion != nil {
@@ -2153,13 +4182,11 @@ func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions
 	}
 	missingDependencies := sets.New[string]()
 	childBranches := make(map[string][]string)
-	var cherrypickBranches string
 	// sort for deterministic tests
-	existingBranches := append(e.backportBranches, e.baseRef)
+	existingBranches := append(slices.Clone(backportBranches), baseRef)
 	sort.Strings(existingBranches)
 	existingBranchesSet := sets.New(existingBranches...)
-	for _, branch := range e.backportBranches {
-		cherrypickBranches += fmt.Sprintf("\n/cherrypick %s", branch)
+	for _, branch := range backportBranches {
 		if _, ok := repoOptions[branch]; !ok {
 			continue
 		}
@@ -2195,14 +4222,93 @@ func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions
 			}
 		}
 	}
-	if len(missingDependencies) != 0 {
-		message := "Missing required branches for backport chain:\n"
-		errorMsgs := missingDependencies.UnsortedList()
-		sort.Strings(errorMsgs)
-		for _, errorMsg := range errorMsgs {
-			message += "- " + errorMsg + "\n"
+	return childBranches, sets.List(missingDependencies)
+}
+
+// resolveBackportBranches replaces the requested backport targets that are not configured branches but match the
+// target version of exactly one configured branch with that branch, so that users may request backports by version.
+// Targets matching neither are kept as-is, as they may be branches without any specific configuration. Backports
+// to branches that are not eligible for them are refused.
+func resolveBackportBranches(requested []string, repoOptions map[string]JiraBranchOptions) ([]string, error) {
+	var resolved []string
+	for _, target := range requested {
+		if _, ok := repoOptions[target]; ok {
+			resolved = append(resolved, target)
+			continue
+		}
+		var branches []string
+		for branch, bOpts := range repoOptions {
+			if bOpts.TargetVersion != nil && *bOpts.TargetVersion == target {
+				branches = append(branches, branch)
+			}
+		}
+		switch len(branches) {
+		case 0:
+			resolved = append(resolved, target)
+		case 1:
+			resolved = append(resolved, branches[0])
+		default:
+			// sort for deterministic messages
+			sort.Strings(branches)
+			return nil, fmt.Errorf("target version %s is configured for multiple branches (%s); please request the backport by branch instead", target, strings.Join(branches, ", "))
 		}
-		return comment(message)
+	}
+	if ineligible := ineligibleBackportBranches(resolved, repoOptions); len(ineligible) > 0 {
+		return nil, fmt.Errorf("the following branches are not eligible for backports: %s; please request the backport to eligible branches only", strings.Join(ineligible, ", "))
+	}
+	return resolved, nil
+}
+
+// ineligibleBackportBranches returns the branches that may not be backported to. Branches are only restricted once
+// any branch of the repo sets `backport_eligible`, after which only branches where it is true are eligible. Branches
+// without specific configuration use the configuration of the wildcard branch.
+func ineligibleBackportBranches(branches []string, repoOptions map[string]JiraBranchOptions) []string {
+	var restricted bool
+	for _, options := range repoOptions {
+		if options.BackportEligible != nil {
+			restricted = true
+			break
+		}
+	}
+	if !restricted {
+		return nil
+	}
+	var ineligible []string
+	for _, branch := range branches {
+		options, ok := repoOptions[branch]
+		if !ok {
+			options = repoOptions[JiraOptionsWildcard]
+		}
+		if options.BackportEligible == nil || !*options.BackportEligible {
+			ineligible = append(ineligible, branch)
+		}
+	}
+	return ineligible
+}
+
+// missingBackportDependenciesMessage describes the branches missing from a backport chain
+func missingBackportDependenciesMessage(missingDependencies []string) string {
+	message := "Missing required branches for backport chain:\n"
+	for _, errorMsg := range missingDependencies {
+		message += "- " + errorMsg + "\n"
+	}
+	return message
+}
+
+func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions map[string]JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	backportBranches, err := resolveBackportBranches(e.backportBranches, repoOptions)
+	if err != nil {
+		return comment(err.Error())
+	}
+	e.backportBranches = backportBranches
+	var cherrypickBranches string
+	for _, branch := range e.backportBranches {
+		cherrypickBranches += fmt.Sprintf("\n/cherrypick %s", branch)
+	}
+	childBranches, missingDependencies := resolveBackportChain(e.baseRef, e.backportBranches, repoOptions)
+	if len(missingDependencies) != 0 {
+		return comment(missingBackportDependenciesMessage(missingDependencies))
 	}
 	createdIssuesMessageLines := []string{}
 	for _, refIssue := range e.issues {
@@ -2219,6 +4325,9 @@ func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions
 		if err != nil {
 			return comment(fmt.Sprintf("Failed to create backported issues: %v", err))
 		}
+		for key := range createdIssues {
+			recordAudit(issueBranchLogger, e, auditActionClone, issue.Key, nil, key)
+		}
 		updateIssue := jira.Issue{Key: issue.Key, Fields: &jira.IssueFields{
 			Labels: issue.Fields.Labels,
 		}}
@@ -2230,10 +4339,12 @@ func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions
 		// sorting the labels isn't necessary for production but helps with tests
 		sort.Strings(newLabels)
 		updateIssue.Fields.Labels = append(updateIssue.Fields.Labels, newLabels...)
+		oldLabels := issue.Fields.Labels
 		issue, err = jc.UpdateIssue(&updateIssue)
 		if err != nil {
 			return comment(fmt.Sprintf("Failed to add label to issue %s: %v", issue.Key, err))
 		}
+		recordAudit(issueBranchLogger, e, auditActionLabels, updateIssue.Key, oldLabels, updateIssue.Fields.Labels)
 	}
 	// make message deterministic for tests
 	sort.Strings(createdIssuesMessageLines)
@@ -2241,6 +4352,45 @@ func handleBackport(e event, gc githubClient, jc jiraclient.Client, repoOptions
 	return comment(fmt.Sprintf("The following backport issues have been created:\n%s\n\nQueuing cherrypicks to the requested branches to be created after this PR merges:%s", createdIssuesMessage, cherrypickBranches))
 }
 
+// handleBackportCheck reports which issues the `/jira backport` command would create for the requested branches,
+// or which branches are missing from the backport chain, without making any changes in Jira
+func handleBackportCheck(e event, gc githubClient, repoOptions map[string]JiraBranchOptions) error {
+	comment := e.comment(gc)
+	backportBranches, err := resolveBackportBranches(e.backportBranches, repoOptions)
+	if err != nil {
+		return comment(err.Error())
+	}
+	e.backportBranches = backportBranches
+	childBranches, missingDependencies := resolveBackportChain(e.baseRef, e.backportBranches, repoOptions)
+	if len(missingDependencies) != 0 {
+		return comment(missingBackportDependenciesMessage(missingDependencies))
+	}
+	var bugKeys []string
+	for _, refIssue := range e.issues {
+		if refIssue.IsBug {
+			bugKeys = append(bugKeys, refIssue.Key())
+		}
+	}
+	if len(bugKeys) == 0 {
+		return comment("No Jira bugs are referenced in the title of this pull request, so no backport issues would be created.")
+	}
+	var cloneLines []string
+	var addClones func(parentBranch string)
+	addClones = func(parentBranch string) {
+		for _, childBranch := range childBranches[parentBranch] {
+			cloneLines = append(cloneLines, fmt.Sprintf("- a clone for branch %s, blocking the issue for branch %s", childBranch, parentBranch))
+			addClones(childBranch)
+		}
+	}
+	addClones(e.baseRef)
+	// make message deterministic for tests
+	sort.Strings(cloneLines)
+	if len(cloneLines) == 0 {
+		return comment(fmt.Sprintf("The backport chain is complete, but no backport issues would be created for %s, as none of the requested branches depend on another branch.", strings.Join(bugKeys, ", ")))
+	}
+	return comment(fmt.Sprintf("The backport chain is complete. Running `/jira backport` would create the following issues for each of %s:\n%s\n\nNo issues have been created.", strings.Join(bugKeys, ", "), strings.Join(cloneLines, "\n")))
+}
+
 // createLinkedJiras recursively creates all descendants of the provided parent issue based on the child branches map
 // 1. map[string]string: issue key -> branch
 // 2. error
@@ -2281,7 +4431,7 @@ func createLinkedJiras(jc jiraclient.Client, parentIssue *jira.Issue, parentBran
 // 1: issues as an array of referencedIssue, if exists
 // 2: missing: true/false based on whether the title is missing a jira ref
 // 3: noJira: true/false based on whether the title contains jira excluding term (i.e. "NO-JIRA" or "NO-ISSUE")
-func jiraKeyFromTitle(title string) ([]referencedIssue, bool, bool) {
+func jiraKeyFromTitle(title string, bugProjects sets.Set[string]) ([]referencedIssue, bool, bool) {
 	titleMatches := titleMatchJiraIssue.FindStringSubmatch(title)
 	if len(titleMatches) == 0 || len(titleMatches) < 3 {
 		return nil, true, false
@@ -2290,25 +4440,249 @@ func jiraKeyFromTitle(title string) ([]referencedIssue, bool, bool) {
 		return nil, false, true
 	}
 
-	return referencedIssues(titleMatches[0]), false, false
+	// the same issue may be referenced more than once, but should only be handled once
+	var issues []referencedIssue
+	seen := sets.New[string]()
+	for _, issue := range referencedIssues(titleMatches[0], bugProjects) {
+		if seen.Has(issue.Key()) {
+			continue
+		}
+		seen.Insert(issue.Key())
+		issues = append(issues, issue)
+	}
+	return issues, false, false
+}
+
+// jiraKeysFromPullRequest returns the issues referenced by a pull request, along with whether a reference is missing
+// and whether the pull request opted out of referencing an issue, like jiraKeyFromTitle. References in the title are
+// authoritative; the body is only considered when the title has none and references from the body are enabled.
+func jiraKeysFromPullRequest(title, body string, options JiraBranchOptions, bugProjects sets.Set[string]) ([]referencedIssue, bool, bool) {
+	issues, missing, noJira := jiraKeyFromTitle(title, bugProjects)
+	if missing && options.ReferencesFromBody != nil && *options.ReferencesFromBody {
+		if bodyIssues := jiraKeysFromBody(body, bugProjects); len(bodyIssues) != 0 {
+			return bodyIssues, false, false
+		}
+	}
+	return issues, missing, noJira
+}
+
+// jiraKeysFromBody returns the issues referenced in the body of a pull request with phrases like "Fixes OCPBUGS-123",
+// in the order they are first referenced
+func jiraKeysFromBody(body string, bugProjects sets.Set[string]) []referencedIssue {
+	var issues []referencedIssue
+	seen := sets.New[string]()
+	for _, match := range bodyMatchJiraIssue.FindAllStringSubmatch(body, -1) {
+		for _, issue := range referencedIssues(match[1], bugProjects) {
+			if seen.Has(issue.Key()) {
+				continue
+			}
+			seen.Insert(issue.Key())
+			issues = append(issues, issue)
+		}
+	}
+	return issues
 }
 
-func getJira(jc jiraclient.Client, jiraKey string, log *logrus.Entry, comment func(string) error) (*jira.Issue, error) {
+func getJira(jc jiraclient.Client, options JiraBranchOptions, jiraKey string, log *logrus.Entry, comment func(string) error) (*jira.Issue, error) {
 	issue, err := jc.GetIssue(jiraKey)
 	if err != nil && !jiraclient.IsNotFound(err) {
 		log.WithError(err).Warn("Unexpected error searching for Jira issue.")
-		return nil, comment(formatError("searching", jc.JiraURL(), jiraKey, err))
+		return nil, comment(formatError(options, "searching", jc.JiraURL(), jiraKey, err))
 	}
 	if jiraclient.IsNotFound(err) || issue == nil {
 		log.Debug("No jira issue found.")
 		return nil, comment(fmt.Sprintf(`No Jira issue with key %s exists in the tracker at %s.
-Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.`,
-			jiraKey, jc.JiraURL()))
+%s`,
+			jiraKey, jc.JiraURL(), refreshHint(options, "Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.")))
 	}
 	return issue, nil
 }
 
-func formatError(action, endpoint, bugKey string, err error) string {
+// resolveIsBug determines whether the referenced issue is a bug from the project of the fetched issue, which
+// is authoritative over the flag parsed from the title when the two disagree
+func resolveIsBug(refIssue referencedIssue, issue *jira.Issue, bugProjects sets.Set[string], log *logrus.Entry) bool {
+	if issue == nil || issue.Fields == nil || issue.Fields.Project.Key == "" {
+		return refIssue.IsBug
+	}
+	isBug := bugProjects.Has(strings.ToUpper(issue.Fields.Project.Key))
+	if isBug != refIssue.IsBug {
+		log.Infof("Reference %s was parsed with IsBug=%t, but the issue belongs to project %s, so it is handled with IsBug=%t.", refIssue.Key(), refIssue.IsBug, issue.Fields.Project.Key, isBug)
+	}
+	return isBug
+}
+
+// unknownProjectHint returns a hint about the expected prefix format when strict project prefixes are configured and
+// the issue references a project that is neither a known bug project nor reachable in Jira, or an empty string otherwise
+func unknownProjectHint(jc jiraclient.Client, listProjects func() (*jira.ProjectList, error), refIssue referencedIssue, bugProjects sets.Set[string], options JiraBranchOptions, log *logrus.Entry) string {
+	if refIssue.IsBug || options.StrictProjectPrefixes == nil || !*options.StrictProjectPrefixes {
+		return ""
+	}
+	projects, err := listProjects()
+	if err != nil {
+		// without the list of projects we cannot tell typos apart, so treat the reference as usual
+		log.WithError(err).Warn("Failed to list Jira projects.")
+		return ""
+	}
+	if projects != nil {
+		for _, project := range *projects {
+			if strings.EqualFold(project.Key, refIssue.Project) {
+				return ""
+			}
+		}
+	}
+	return fmt.Sprintf("The title of this pull request references %s, but %s is neither a known bug project nor a project in the tracker at %s.\n"+
+		"Bugs are referenced by adding one of %s followed by the bug number (e.g. '%s-123:') to the title of this pull request. %s",
+		refIssue.Key(), refIssue.Project, jc.JiraURL(), strings.Join(sets.List(bugProjects), ", "), sets.List(bugProjects)[0],
+		refreshHint(options, "Once the title is corrected, request a refresh with <code>/jira refresh</code>."))
+}
+
+const (
+	commentTemplateValid   = "valid"
+	commentTemplateInvalid = "invalid"
+	commentTemplateMerged  = "merged"
+)
+
+var commentTemplateTypes = sets.New[string](commentTemplateValid, commentTemplateInvalid, commentTemplateMerged)
+
+// commentTemplateData is the data made available to comment templates configured for a branch
+type commentTemplateData struct {
+	Key          string
+	URL          string
+	Validations  []string
+	PullRequests []string
+}
+
+// issueURL returns the URL at which users can browse the issue. The endpoint is the base URL reported by the
+// Jira client, which is the configured display URL when there is one.
+func issueURL(endpoint, key string) string {
+	return fmt.Sprintf("%s/browse/%s", endpoint, key)
+}
+
+// displayURLJiraClient reports the configured display URL as the base URL of the Jira server, so that every
+// link the plugin posts points at the address users reach Jira through
+type displayURLJiraClient struct {
+	jiraclient.Client
+	url string
+}
+
+func (c *displayURLJiraClient) JiraURL() string {
+	return c.url
+}
+
+// renderCommentTemplate renders the comment template configured for the message type, if there is one.
+// Templates are validated when the config is loaded, but if rendering fails we fall back to the default
+// message rather than leave the user without a response.
+func renderCommentTemplate(options JiraBranchOptions, templateType string, data commentTemplateData, log *logrus.Entry) (string, bool) {
+	raw, ok := options.CommentTemplates[templateType]
+	if !ok {
+		return "", false
+	}
+	tmpl, err := template.New(templateType).Parse(raw)
+	if err != nil {
+		log.WithError(err).Warnf("Failed to parse %s comment template, falling back to the default message.", templateType)
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.WithError(err).Warnf("Failed to render %s comment template, falling back to the default message.", templateType)
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// remoteLinkTitleData is the data made available to the remote link title template configured for a branch
+type remoteLinkTitleData struct {
+	Org    string
+	Repo   string
+	Number int
+	Title  string
+}
+
+// remoteLinkIcon returns the icon for the external links added to bugs, defaulting to the GitHub icon
+func remoteLinkIcon(options JiraBranchOptions) *jira.RemoteLinkIcon {
+	if options.RemoteLinkIcon == nil {
+		return &jira.RemoteLinkIcon{
+			Url16x16: "https://github.com/favicon.ico",
+			Title:    "GitHub",
+		}
+	}
+	return &jira.RemoteLinkIcon{
+		Url16x16: options.RemoteLinkIcon.URL,
+		Title:    options.RemoteLinkIcon.Title,
+	}
+}
+
+// remoteLinkTitle returns the title of the external link added to bugs for the pull request. As existing links
+// are compared against this title, a template that fails to render falls back to the default title rather
+// than to an empty one.
+func remoteLinkTitle(options JiraBranchOptions, e event, log *logrus.Entry) string {
+	defaultTitle := fmt.Sprintf("%s/%s#%d: %s", e.org, e.repo, e.number, e.title)
+	if options.RemoteLinkTitleTemplate == nil {
+		return defaultTitle
+	}
+	tmpl, err := template.New("remote_link_title_template").Parse(*options.RemoteLinkTitleTemplate)
+	if err != nil {
+		log.WithError(err).Warn("Failed to parse remote link title template, falling back to the default title.")
+		return defaultTitle
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, remoteLinkTitleData{Org: e.org, Repo: e.repo, Number: e.number, Title: e.title}); err != nil {
+		log.WithError(err).Warn("Failed to render remote link title template, falling back to the default title.")
+		return defaultTitle
+	}
+	return buf.String()
+}
+
+// branchConfigured determines whether any configuration other than the `*` wildcard matches the branch,
+// either by name or as a regular expression
+func branchConfigured(branch string, repoOptions map[string]JiraBranchOptions) bool {
+	for key := range repoOptions {
+		if key == JiraOptionsWildcard {
+			continue
+		}
+		if key == branch || itemPatternMatches(key, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatWarnings renders the warnings in the order they were found. A single warning is
+// reported on its own line, while multiple warnings are listed under a shared heading.
+func formatWarnings(warnings []string) string {
+	switch len(warnings) {
+	case 0:
+		return ""
+	case 1:
+		return "\n\nWarning: " + warnings[0]
+	default:
+		return "\n\nWarnings:\n * " + strings.Join(warnings, "\n * ")
+	}
+}
+
+// refreshHint returns the instruction that tells users how to get the referenced bugs re-evaluated, preferring
+// the override configured for the branch over the provided default.
+func refreshHint(options JiraBranchOptions, defaultHint string) string {
+	if options.RefreshHint != nil {
+		return *options.RefreshHint
+	}
+	return defaultHint
+}
+
+// retitleInstruction returns the end of a comment that retitles the pull request to the given title: the
+// announcement followed by the configured retitle command, or a request to retitle manually if it is disabled
+func retitleInstruction(options JiraBranchOptions, announcement, title string) string {
+	command := "/retitle"
+	if options.RetitleCommand != nil {
+		command = *options.RetitleCommand
+	}
+	if command == "" {
+		return fmt.Sprintf("\nPlease retitle the pull request to: %s", title)
+	}
+	return fmt.Sprintf("%s\n%s %s", announcement, command, title)
+}
+
+func formatError(options JiraBranchOptions, action, endpoint, bugKey string, err error) string {
 	knownErrors := map[string]string{
 		// TODO: Most of this code is copied from the bugzilla client. If Jira rate limits us the same way, this could come in handy. We will keep this for now in case it is needed
 		//"There was an error reported for a GitHub REST call": "The Bugzilla server failed to load data from GitHub when creating the bug. This is usually caused by rate-limiting, please try again later.",
@@ -2320,6 +4694,20 @@ func formatError(action, endpoint, bugKey string, err error) string {
 		}
 	}
 	digest := "No known errors were detected, please see the full error message for details."
+	hint := "Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>."
+	var permissionErr jiraPermissionError
+	var transientErr jiraTransientError
+	switch {
+	case errors.As(err, &permissionErr):
+		digest = "The bot is not permitted to access the bug."
+		hint = "Please contact a Jira administrator to grant the bot access to the bug, then request a bug refresh with <code>/jira refresh</code>."
+	case errors.As(err, &transientErr):
+		digest = "The Jira server is temporarily unavailable."
+		hint = "Please wait a few minutes, then request a bug refresh with <code>/jira refresh</code>."
+	case jiraclient.IsNotFound(err):
+		digest = "The bug does not exist on the Jira server."
+		hint = "Please make sure the title of this pull request references the correct bug, then request a bug refresh with <code>/jira refresh</code>."
+	}
 	if len(applicable) > 0 {
 		digest = "We were able to detect the following conditions from the error:\n\n"
 		for _, item := range applicable {
@@ -2336,12 +4724,38 @@ func formatError(action, endpoint, bugKey string, err error) string {
 
 </details>
 
-Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.`,
-		action, bugKey, endpoint, digest, err)
+%s`,
+		action, bugKey, endpoint, digest, err, refreshHint(options, hint))
 }
 
 var PrivateVisibility = jira.CommentVisibility{Type: "group", Value: "Red Hat Employee"}
 
+// privateVisibility returns the visibility of private comments posted on Jira issues for the branch
+func privateVisibility(options JiraBranchOptions) jira.CommentVisibility {
+	if options.PrivateCommentVisibility == nil {
+		return PrivateVisibility
+	}
+	return jira.CommentVisibility{Type: options.PrivateCommentVisibility.Type, Value: options.PrivateCommentVisibility.Value}
+}
+
+// addValidationComment adds a private comment to the bug that links it to the pull request, unless the bug
+// already has an identical comment from an earlier validation
+func addValidationComment(jc jiraclient.Client, issue *jira.Issue, e event, options JiraBranchOptions, log *logrus.Entry) error {
+	body := fmt.Sprintf("Linked to PR %s/%s#%d: https://github.com/%s/%s/pull/%d", e.org, e.repo, e.number, e.org, e.repo, e.number)
+	if issue.Fields != nil && issue.Fields.Comments != nil {
+		for _, existing := range issue.Fields.Comments.Comments {
+			if existing != nil && existing.Body == body {
+				return nil
+			}
+		}
+	}
+	if _, err := jc.AddComment(issue.ID, &jira.Comment{Body: body, Visibility: privateVisibility(options)}); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	recordAudit(log, e, auditActionComment, issue.Key, nil, body)
+	return nil
+}
+
 func handleClose(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
 	comment := e.comment(gc)
 	if e.missing {
@@ -2357,25 +4771,28 @@ func handleClose(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 			changed, err := jc.DeleteRemoteLinkViaURL(refIssue.Key(), prURLFromCommentURL(e.htmlUrl))
 			if err != nil && !strings.HasPrefix(err.Error(), "could not find remote link on issue with URL") {
 				log.WithError(err).Warn("Unexpected error removing external tracker bug from Jira bug.")
-				msg += formatError("removing this pull request from the external tracker bugs", jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+				msg += formatError(options, "removing this pull request from the external tracker bugs", jc.JiraURL(), refIssue.Key(), err) + "\n\n"
 				continue
 			}
-			if options.StateAfterClose != nil || options.PreMergeStateAfterClose != nil {
+			if changed {
+				recordAudit(log, e, auditActionRemoteLinkRemove, refIssue.Key(), prURLFromCommentURL(e.htmlUrl), nil)
+			}
+			if (options.StateAfterClose != nil || options.PreMergeStateAfterClose != nil) && !transitionsDisabled(options) {
 				issue, err := jc.GetIssue(refIssue.Key())
 				if err != nil {
 					log.WithError(err).Warn("Unexpected error getting Jira issue.")
-					msg += formatError("getting issue", jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+					msg += formatError(options, "getting issue", jc.JiraURL(), refIssue.Key(), err) + "\n\n"
 					continue
 				}
 				if !strings.EqualFold(issue.Fields.Status.Name, status.Closed) {
 					links, err := jc.GetRemoteLinks(issue.ID)
 					if err != nil {
 						log.WithError(err).Warn("Unexpected error getting remote links for Jira issue.")
-						msg += formatError("getting remote links", jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+						msg += formatError(options, "getting remote links", jc.JiraURL(), refIssue.Key(), err) + "\n\n"
 						continue
 					}
 					if len(links) == 0 {
-						bug, err := getJira(jc, refIssue.Key(), log, comment)
+						bug, err := getJira(jc, options, refIssue.Key(), log, comment)
 						if err != nil || bug == nil {
 							return err
 						}
@@ -2385,46 +4802,78 @@ func handleClose(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 						} else {
 							premergeVerified = isPreMergeVerified(bug, labels)
 						}
-						updatedState := JiraBugState{}
-						if premergeVerified {
-							updatedState = JiraBugState{Status: options.PreMergeStateAfterClose.Status, Resolution: options.PreMergeStateAfterClose.Resolution}
-							if options.PreMergeStateAfterClose.Status != "" && (bug.Fields.Status == nil || !strings.EqualFold(options.PreMergeStateAfterClose.Status, bug.Fields.Status.Name)) {
-								if err := jc.UpdateStatus(issue.ID, options.PreMergeStateAfterClose.Status); err != nil {
-									log.WithError(err).Warn("Unexpected error updating jira issue.")
-									msg += formatError(fmt.Sprintf("updating to the %s state", options.PreMergeStateAfterClose.Status), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
-									continue
-								}
-								if options.PreMergeStateAfterClose.Resolution != "" && (bug.Fields.Resolution == nil || !strings.EqualFold(options.PreMergeStateAfterClose.Resolution, bug.Fields.Resolution.Name)) {
-									updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: options.PreMergeStateAfterClose.Resolution}}}
-									if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+						if gracePeriod := options.CloseGracePeriod; gracePeriod != nil && bug.Fields != nil && time.Since(time.Time(bug.Fields.Updated)) < gracePeriod.Duration {
+							intendedState := options.StateAfterClose
+							if premergeVerified {
+								intendedState = options.PreMergeStateAfterClose
+							}
+							response += fmt.Sprintf(" All external bug links have been closed. The bug has not been moved to the %s state as it was updated within the last %s.", intendedState, gracePeriod.Duration)
+							jiraComment := &jira.Comment{Body: fmt.Sprintf("Bug status not changed to %s after previous linked PR https://github.com/%s/%s/pull/%d was closed, as the bug was updated within the last %s", intendedState, e.org, e.repo, e.number, gracePeriod.Duration), Visibility: privateVisibility(options)}
+							if _, err := jc.AddComment(bug.ID, jiraComment); err != nil {
+								response += "\nWarning: Failed to comment on Jira bug with reason for unchanged state."
+							} else {
+								recordAudit(log, e, auditActionComment, bug.Key, nil, jiraComment.Body)
+							}
+						} else {
+							updatedState := JiraBugState{}
+							var fieldChanges []fieldChange
+							if premergeVerified {
+								updatedState = JiraBugState{Status: options.PreMergeStateAfterClose.Status, Resolution: options.PreMergeStateAfterClose.Resolution}
+								if options.PreMergeStateAfterClose.Status != "" && (bug.Fields.Status == nil || !strings.EqualFold(options.PreMergeStateAfterClose.Status, bug.Fields.Status.Name)) {
+									oldStatus := issueStatus(bug)
+									if err := jc.UpdateStatus(issue.ID, options.PreMergeStateAfterClose.Status); err != nil {
 										log.WithError(err).Warn("Unexpected error updating jira issue.")
-										msg += formatError(fmt.Sprintf("updating to the %s resolution", options.PreMergeStateAfterClose.Resolution), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+										msg += formatError(options, fmt.Sprintf("updating to the %s state", options.PreMergeStateAfterClose.Status), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
 										continue
 									}
+									recordTransition(e, options.PreMergeStateAfterClose.Status)
+									recordAudit(log, e, auditActionTransition, bug.Key, oldStatus, options.PreMergeStateAfterClose.Status)
+									fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: options.PreMergeStateAfterClose.Status})
+									if options.PreMergeStateAfterClose.Resolution != "" && (bug.Fields.Resolution == nil || !strings.EqualFold(options.PreMergeStateAfterClose.Resolution, bug.Fields.Resolution.Name)) {
+										oldResolution := issueResolution(bug)
+										updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: options.PreMergeStateAfterClose.Resolution}}}
+										if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+											log.WithError(err).Warn("Unexpected error updating jira issue.")
+											msg += formatError(options, fmt.Sprintf("updating to the %s resolution", options.PreMergeStateAfterClose.Resolution), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+											continue
+										}
+										recordAudit(log, e, auditActionResolution, bug.Key, oldResolution, options.PreMergeStateAfterClose.Resolution)
+										fieldChanges = append(fieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: options.PreMergeStateAfterClose.Resolution})
+									}
 								}
-							}
-						} else {
-							updatedState = JiraBugState{Status: options.StateAfterClose.Status, Resolution: options.StateAfterClose.Resolution}
-							if options.StateAfterClose.Status != "" && (bug.Fields.Status == nil || !strings.EqualFold(options.StateAfterClose.Status, bug.Fields.Status.Name)) {
-								if err := jc.UpdateStatus(issue.ID, options.StateAfterClose.Status); err != nil {
-									log.WithError(err).Warn("Unexpected error updating jira issue.")
-									msg += formatError(fmt.Sprintf("updating to the %s state", options.StateAfterClose.Status), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
-									continue
-								}
-								if options.StateAfterClose.Resolution != "" && (bug.Fields.Resolution == nil || !strings.EqualFold(options.StateAfterClose.Resolution, bug.Fields.Resolution.Name)) {
-									updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: options.StateAfterClose.Resolution}}}
-									if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+							} else {
+								updatedState = JiraBugState{Status: options.StateAfterClose.Status, Resolution: options.StateAfterClose.Resolution}
+								if options.StateAfterClose.Status != "" && (bug.Fields.Status == nil || !strings.EqualFold(options.StateAfterClose.Status, bug.Fields.Status.Name)) {
+									oldStatus := issueStatus(bug)
+									if err := jc.UpdateStatus(issue.ID, options.StateAfterClose.Status); err != nil {
 										log.WithError(err).Warn("Unexpected error updating jira issue.")
-										msg += formatError(fmt.Sprintf("updating to the %s resolution", options.StateAfterClose.Resolution), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+										msg += formatError(options, fmt.Sprintf("updating to the %s state", options.StateAfterClose.Status), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
 										continue
 									}
+									recordTransition(e, options.StateAfterClose.Status)
+									recordAudit(log, e, auditActionTransition, bug.Key, oldStatus, options.StateAfterClose.Status)
+									fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: options.StateAfterClose.Status})
+									if options.StateAfterClose.Resolution != "" && (bug.Fields.Resolution == nil || !strings.EqualFold(options.StateAfterClose.Resolution, bug.Fields.Resolution.Name)) {
+										oldResolution := issueResolution(bug)
+										updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: options.StateAfterClose.Resolution}}}
+										if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+											log.WithError(err).Warn("Unexpected error updating jira issue.")
+											msg += formatError(options, fmt.Sprintf("updating to the %s resolution", options.StateAfterClose.Resolution), jc.JiraURL(), refIssue.Key(), err) + "\n\n"
+											continue
+										}
+										recordAudit(log, e, auditActionResolution, bug.Key, oldResolution, options.StateAfterClose.Resolution)
+										fieldChanges = append(fieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: options.StateAfterClose.Resolution})
+									}
 								}
 							}
-						}
-						response += fmt.Sprintf(" All external bug links have been closed. The bug has been moved to the %s state.", PrettyStatus(updatedState.Status, updatedState.Resolution))
-						jiraComment := &jira.Comment{Body: fmt.Sprintf("Bug status changed to %s as previous linked PR https://github.com/%s/%s/pull/%d has been closed", options.StateAfterClose.Status, e.org, e.repo, e.number), Visibility: PrivateVisibility}
-						if _, err := jc.AddComment(bug.ID, jiraComment); err != nil {
-							response += "\nWarning: Failed to comment on Jira bug with reason for changed state."
+							response += fmt.Sprintf(" All external bug links have been closed. The bug has been moved to the %s state.", PrettyStatus(updatedState.Status, updatedState.Resolution))
+							response += fieldChangesComment(options, refIssue.Key(), fieldChanges)
+							jiraComment := &jira.Comment{Body: fmt.Sprintf("Bug status changed to %s as previous linked PR https://github.com/%s/%s/pull/%d has been closed", options.StateAfterClose.Status, e.org, e.repo, e.number), Visibility: privateVisibility(options)}
+							if _, err := jc.AddComment(bug.ID, jiraComment); err != nil {
+								response += "\nWarning: Failed to comment on Jira bug with reason for changed state."
+							} else {
+								recordAudit(log, e, auditActionComment, bug.Key, nil, jiraComment.Body)
+							}
 						}
 					}
 				}
@@ -2441,17 +4890,139 @@ func handleClose(e event, gc githubClient, jc jiraclient.Client, options JiraBra
 	return nil
 }
 
-func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, log *logrus.Entry) error {
+// verifiedCommandUserAllowed determines whether the user is in the list of users allowed to run verification commands
+func verifiedCommandUserAllowed(allowed []string, login string) bool {
+	for _, user := range allowed {
+		if github.NormLogin(user) == github.NormLogin(login) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordHandleMetrics uploads the API calls made and the time taken to handle the event to Big Query.
+func recordHandleMetrics(inserter BigQueryInserter, e event, counts *callCounts, start time.Time, log *logrus.Entry) {
+	if inserter == nil {
+		return
+	}
+	metrics := HandleMetrics{
+		Org:               e.org,
+		Repo:              e.repo,
+		PRNum:             e.number,
+		Branch:            e.baseRef,
+		EventType:         e.commentType(),
+		JiraGetIssueCalls: int(counts.jiraGetIssue.Load()),
+		JiraUpdateCalls:   int(counts.jiraUpdate.Load()),
+		GitHubCalls:       int(counts.github.Load()),
+		Duration:          time.Since(start),
+		Timestamp:         time.Now(),
+	}
+	if err := inserter.Put(context.TODO(), metrics); err != nil {
+		log.WithError(err).Error("Failed to upload handle metrics to Big Query")
+	}
+}
+
+// jiraChangelogTimeLayout is the layout of the creation time of the entries in the changelog of a Jira issue
+const jiraChangelogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// getIssueChangelog returns the changelog of the issue, fetching it from Jira if it was not expanded when the issue was fetched.
+// The Jira client does not expand the changelog when fetching an issue, so the issue is searched for with it expanded instead.
+func getIssueChangelog(jc jiraclient.Client, issue *jira.Issue) (*jira.Changelog, error) {
+	if issue.Changelog != nil {
+		return issue.Changelog, nil
+	}
+	issues, _, err := jc.SearchWithContext(context.TODO(), fmt.Sprintf("key = %s", issue.Key), &jira.SearchOptions{Expand: "changelog", Fields: []string{"key"}, MaxResults: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, jiraclient.NewNotFoundError(fmt.Errorf("no issue %s found", issue.Key))
+	}
+	return issues[0].Changelog, nil
+}
+
+// stateEnteredAt returns when the issue was last moved to the status according to its changelog,
+// and whether the changelog records such a transition at all
+func stateEnteredAt(changelog *jira.Changelog, status string) (time.Time, bool, error) {
+	var entered time.Time
+	var found bool
+	if changelog == nil {
+		return entered, found, nil
+	}
+	for _, history := range changelog.Histories {
+		for _, item := range history.Items {
+			if item.Field != "status" || !strings.EqualFold(item.ToString, status) {
+				continue
+			}
+			created, err := time.Parse(jiraChangelogTimeLayout, history.Created)
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("failed to parse the creation time of changelog entry %s: %w", history.Id, err)
+			}
+			if !found || created.After(entered) {
+				entered, found = created, true
+			}
+		}
+	}
+	return entered, found, nil
+}
+
+// recordTimeInState uploads how long the bug spent in its previous state before it was moved to the new one.
+// Errors are only logged, as the analytics must never block handling of the event.
+func recordTimeInState(inserter BigQueryInserter, jc jiraclient.Client, e event, options JiraBranchOptions, bug *jira.Issue, oldStatus, newStatus string, log *logrus.Entry) {
+	if inserter == nil || options.RecordTimeInState == nil || !*options.RecordTimeInState || oldStatus == "" {
+		return
+	}
+	changelog, err := getIssueChangelog(jc, bug)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get the changelog of the Jira issue.")
+		return
+	}
+	entered, found, err := stateEnteredAt(changelog, oldStatus)
+	if err != nil {
+		log.WithError(err).Warn("Failed to determine when the Jira issue entered its previous state.")
+		return
+	}
+	if !found {
+		log.Debugf("The changelog of the Jira issue does not record when it entered the %s state.", oldStatus)
+		return
+	}
+	now := time.Now()
+	timeInState := TimeInState{
+		Org:          e.org,
+		Repo:         e.repo,
+		PRNum:        e.number,
+		Branch:       e.baseRef,
+		Issue:        bug.Key,
+		State:        oldStatus,
+		NewState:     newStatus,
+		EnteredState: entered,
+		Duration:     now.Sub(entered),
+		Timestamp:    now,
+	}
+	if err := inserter.Put(context.TODO(), timeInState); err != nil {
+		log.WithError(err).Error("Failed to upload time in state to Big Query")
+	}
+}
+
+func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, options JiraBranchOptions, log *logrus.Entry) error {
 	comment := e.comment(ghc)
 	if len(e.verifyLater) > 0 && len(e.verify) > 0 && e.verifiedRemove {
 		return comment("The `/verified`, `/verified later`, and `/verified remove` commands cannot be used in the same comment.")
 	}
-	if ok, err := ghc.IsCollaborator(e.org, e.repo, e.login); !ok {
-		return comment("Jira verification commands are restricted to collaborators for this repo.")
-	} else if err != nil {
-		return comment(fmt.Sprintf("Failed to determine wheter user %s is a collaborator for the %s/%s repo. Please try again.", e.login, e.org, e.repo))
+	// reviewers verifying through a review have already been matched against the QE reviewers configured for the branch
+	if !e.verifiedByReview {
+		if len(options.VerifiedCommandUsers) > 0 {
+			if !verifiedCommandUserAllowed(options.VerifiedCommandUsers, e.login) {
+				return comment(fmt.Sprintf("Jira verification commands are restricted to collaborators for this repo. For this branch, that is limited to the following users: %s.", strings.Join(options.VerifiedCommandUsers, ", ")))
+			}
+		} else if ok, err := ghc.IsCollaborator(e.org, e.repo, e.login); !ok {
+			return comment("Jira verification commands are restricted to collaborators for this repo.")
+		} else if err != nil {
+			return comment(fmt.Sprintf("Failed to determine wheter user %s is a collaborator for the %s/%s repo. Please try again.", e.login, e.org, e.repo))
+		}
 	}
 	msg := ""
+	verified, verifiedLater := verifiedLabel(options), verifiedLaterLabel(options)
 	prLabels, err := ghc.GetIssueLabels(e.org, e.repo, e.number)
 	if err != nil {
 		log.WithError(err).Warn("Could not list labels on PR")
@@ -2461,10 +5032,10 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 		// make sure the PR is not already marked as verified
 		var existingLabel bool
 		for _, label := range prLabels {
-			if label.Name == labels.Verified {
-				return comment("PR is already has `verified` label. Cannot change PR to `verify-later`")
+			if label.Name == verified {
+				return comment(fmt.Sprintf("PR is already has `%s` label. Cannot change PR to `verify-later`", verified))
 			}
-			if label.Name == labels.VerifiedLater {
+			if label.Name == verifiedLater {
 				existingLabel = true
 			}
 		}
@@ -2475,9 +5046,9 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 			}
 		}
 		if !existingLabel {
-			if err := ghc.AddLabel(e.org, e.repo, e.number, labels.VerifiedLater); err != nil {
+			if err := ghc.AddLabel(e.org, e.repo, e.number, verifiedLater); err != nil {
 				log.WithError(err).Error("Failed to add verified later label.")
-				return comment("Failed to add `verified-later` label. Please try again.")
+				return comment(fmt.Sprintf("Failed to add `%s` label. Please try again.", verifiedLater))
 			}
 			msg = fmt.Sprintf("This PR has been marked to be verified later by `%s`. Jira issue(s) in the title of this PR will not be moved to the `VERIFIED` state on merge.", strings.Join(e.verifyLater, ","))
 		} else {
@@ -2505,23 +5076,23 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 	if len(e.verify) > 0 {
 		var verifyLabel, laterLabel bool
 		for _, label := range prLabels {
-			if label.Name == labels.Verified {
+			if label.Name == verified {
 				verifyLabel = true
-			} else if label.Name == labels.VerifiedLater {
+			} else if label.Name == verifiedLater {
 				laterLabel = true
 			}
 		}
 		if !verifyLabel {
-			if err := ghc.AddLabel(e.org, e.repo, e.number, labels.Verified); err != nil {
+			if err := ghc.AddLabel(e.org, e.repo, e.number, verified); err != nil {
 				log.WithError(err).Error("Failed to add verified label.")
-				return comment("Failed to add `verified` label. Please try again.")
+				return comment(fmt.Sprintf("Failed to add `%s` label. Please try again.", verified))
 			}
 			msg = fmt.Sprintf("This PR has been marked as verified by `%s`. Jira issue(s) in the title of this PR will be moved to the `VERIFIED` state on merge.", strings.Join(e.verify, ","))
 		} else {
 			msg = fmt.Sprintf("`%s` has been added as a verification reason for this PR. Jira issue(s) in the title of this PR will be moved to the `VERIFIED` state on merge.", strings.Join(e.verify, ","))
 		}
 		if laterLabel {
-			if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.VerifiedLater); err != nil {
+			if err := ghc.RemoveLabel(e.org, e.repo, e.number, verifiedLater); err != nil {
 				log.WithError(err).Error("Failed to remove verified-later label.")
 			}
 		}
@@ -2547,16 +5118,16 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 	if e.verifiedRemove {
 		var verifyLabel, laterLabel bool
 		for _, label := range prLabels {
-			if label.Name == labels.Verified {
+			if label.Name == verified {
 				verifyLabel = true
-			} else if label.Name == labels.VerifiedLater {
+			} else if label.Name == verifiedLater {
 				laterLabel = true
 			}
 		}
 		if verifyLabel {
-			if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.Verified); err != nil {
+			if err := ghc.RemoveLabel(e.org, e.repo, e.number, verified); err != nil {
 				log.WithError(err).Error("Failed to remove verified label.")
-				return comment("Failed to remove `verified` label. Please try again.")
+				return comment(fmt.Sprintf("Failed to remove `%s` label. Please try again.", verified))
 			}
 			info := VerificationInfo{
 				User:      e.login,
@@ -2571,12 +5142,12 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 			if err := inserter.Put(context.TODO(), info); err != nil {
 				log.WithError(err).Error("Failed to upload info to Big Query")
 			}
-			msg += "The `verified` label has been removed."
+			msg += fmt.Sprintf("The `%s` label has been removed.", verified)
 		}
 		if laterLabel {
-			if err := ghc.RemoveLabel(e.org, e.repo, e.number, labels.VerifiedLater); err != nil {
+			if err := ghc.RemoveLabel(e.org, e.repo, e.number, verifiedLater); err != nil {
 				log.WithError(err).Error("Failed to remove verified-later label.")
-				return comment("Failed to remove `verified-later` label. Please try again.")
+				return comment(fmt.Sprintf("Failed to remove `%s` label. Please try again.", verifiedLater))
 			}
 			info := VerificationInfo{
 				User:      e.login,
@@ -2591,7 +5162,7 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 			if err := inserter.Put(context.TODO(), info); err != nil {
 				log.WithError(err).Error("Failed to upload info to Big Query")
 			}
-			msg += "The `verified-later` label has been removed."
+			msg += fmt.Sprintf("The `%s` label has been removed.", verifiedLater)
 		}
 	}
 	if len(msg) != 0 {
@@ -2600,6 +5171,312 @@ func handleVerification(e event, ghc githubClient, inserter BigQueryInserter, lo
 	return nil
 }
 
+// handleSetPriority updates the priority of all bugs referenced in the PR title to the priority requested via
+// the `/jira set-priority` command
+func handleSetPriority(e event, gc commentClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	var priority string
+	for _, validPriority := range validPriorities {
+		if strings.EqualFold(validPriority, e.priority) {
+			priority = validPriority
+			break
+		}
+	}
+	if priority == "" {
+		return comment(fmt.Sprintf("%q is not a valid Jira priority. Valid priorities are: %s", e.priority, strings.Join(validPriorities, ", ")))
+	}
+	var msgs []string
+	for _, refIssue := range e.issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		bug, err := getJira(jc, options, refIssue.Key(), log, comment)
+		if err != nil || bug == nil {
+			return err
+		}
+		if bug.Fields != nil && bug.Fields.Priority != nil && bug.Fields.Priority.Name == priority {
+			msgs = append(msgs, fmt.Sprintf(issueLink+" already has the %s priority.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), priority))
+			continue
+		}
+		updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Priority: &jira.Priority{Name: priority}}}
+		if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+			log.WithError(err).Warn("Unexpected error updating jira issue.")
+			msgs = append(msgs, formatError(options, fmt.Sprintf("updating to the %s priority", priority), jc.JiraURL(), refIssue.Key(), err))
+			continue
+		}
+		var oldPriority string
+		if bug.Fields != nil && bug.Fields.Priority != nil {
+			oldPriority = bug.Fields.Priority.Name
+		}
+		recordAudit(log, e, auditActionPriority, bug.Key, oldPriority, priority)
+		msgs = append(msgs, fmt.Sprintf("The priority of "+issueLink+" has been set to %s.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), priority))
+	}
+	if len(msgs) == 0 {
+		return comment("No Jira bugs are referenced in the title of this pull request; the priority was not updated.")
+	}
+	return comment(strings.Join(msgs, "\n\n"))
+}
+
+// versionField is a version field of a bug that can be set with a command
+type versionField struct {
+	// name is how the field is referred to in comments, and title how it is referred to in the summary of changed fields
+	name, title string
+	// alreadySet is the message reported when the bug is already set to the requested version
+	alreadySet  string
+	auditAction string
+	// versions returns the names of the versions the bug is currently set to
+	versions func(bug *jira.Issue) ([]string, error)
+	// fields returns the fields of an update setting the bug to the version
+	fields func(version string) *jira.IssueFields
+}
+
+var (
+	targetVersionField = versionField{
+		name:        "target version",
+		title:       "Target Version",
+		alreadySet:  "already targets version %s",
+		auditAction: auditActionTargetVersion,
+		versions: func(bug *jira.Issue) ([]string, error) {
+			targetVersion, err := helpers.GetIssueTargetVersion(bug)
+			if err != nil {
+				return nil, err
+			}
+			var versions []string
+			for _, version := range targetVersion {
+				versions = append(versions, version.Name)
+			}
+			return versions, nil
+		},
+		fields: func(version string) *jira.IssueFields {
+			return &jira.IssueFields{Unknowns: tcontainer.MarshalMap{
+				helpers.TargetVersionField: []*jira.Version{{Name: version}},
+			}}
+		},
+	}
+	fixVersionField = versionField{
+		name:        "fix version",
+		title:       "Fix Version",
+		alreadySet:  "already has fix version %s",
+		auditAction: auditActionFixVersion,
+		versions: func(bug *jira.Issue) ([]string, error) {
+			var versions []string
+			for _, version := range bug.Fields.FixVersions {
+				if version != nil {
+					versions = append(versions, version.Name)
+				}
+			}
+			return versions, nil
+		},
+		fields: func(version string) *jira.IssueFields {
+			return &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: version}}}
+		},
+	}
+)
+
+// checkCollaborator determines whether the user that issued the command is a collaborator on the repo, which is
+// required for commands that change the referenced bugs. If they are not, or if that could not be determined, the
+// user is told so and false is returned along with the result of commenting.
+func checkCollaborator(gc permissionClient, e event, command string, comment func(body string) error, log *logrus.Entry) (bool, error) {
+	ok, err := gc.IsCollaborator(e.org, e.repo, e.login)
+	if err != nil {
+		log.WithError(err).Warn("Failed to check if user is a collaborator")
+		return false, comment(fmt.Sprintf("Failed to determine whether user %s is a collaborator for the %s/%s repo. Please try again.", e.login, e.org, e.repo))
+	}
+	if !ok {
+		return false, comment(fmt.Sprintf("The `%s` command is restricted to collaborators for this repo.", command))
+	}
+	return true, nil
+}
+
+// handleSetVersion sets the target version of all bugs referenced in the PR title to the version provided
+// via the `/jira set-version` command
+func handleSetVersion(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	return setVersion(e, gc, jc, options, log, "/jira set-version", targetVersionField, e.targetVersion)
+}
+
+// handleSetFixVersion sets the fix version of all bugs referenced in the PR title to the version provided
+// via the `/jira set-fixversion` command
+func handleSetFixVersion(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	return setVersion(e, gc, jc, options, log, "/jira set-fixversion", fixVersionField, e.fixVersion)
+}
+
+// setVersion sets the version field of all bugs referenced in the PR title to the version provided via the command
+func setVersion(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry, command string, field versionField, version string) error {
+	comment := e.comment(gc)
+	if ok, err := checkCollaborator(gc, e, command, comment, log); !ok {
+		return err
+	}
+	var msgs []string
+	for _, refIssue := range e.issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		bug, err := getJira(jc, options, refIssue.Key(), log, comment)
+		if err != nil || bug == nil {
+			return err
+		}
+		oldVersions, err := field.versions(bug)
+		if err != nil {
+			msgs = append(msgs, formatError(options, "getting the "+field.name, jc.JiraURL(), refIssue.Key(), err))
+			continue
+		}
+		if len(oldVersions) == 1 && oldVersions[0] == version {
+			msgs = append(msgs, fmt.Sprintf(issueLink+" "+field.alreadySet+".", refIssue.Key(), jc.JiraURL(), refIssue.Key(), version))
+			continue
+		}
+		updateIssue := jira.Issue{Key: bug.Key, Fields: field.fields(version)}
+		if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+			log.WithError(err).Warn("Unexpected error updating jira issue.")
+			msgs = append(msgs, formatError(options, fmt.Sprintf("updating to the %s %s", version, field.name), jc.JiraURL(), refIssue.Key(), err))
+			continue
+		}
+		oldVersion := strings.Join(oldVersions, ", ")
+		recordAudit(log, e, field.auditAction, bug.Key, oldVersion, version)
+		msgs = append(msgs, fmt.Sprintf("The %s of "+issueLink+" has been set to %s.", field.name, refIssue.Key(), jc.JiraURL(), refIssue.Key(), version)+
+			fieldChangesComment(options, refIssue.Key(), []fieldChange{{field: field.title, oldValue: oldVersion, newValue: version}}))
+	}
+	if len(msgs) == 0 {
+		return comment(fmt.Sprintf("No Jira bugs are referenced in the title of this pull request; the %s was not updated.", field.name))
+	}
+	return comment(strings.Join(msgs, "\n\n"))
+}
+
+// handleStatus reports the current state of all bugs referenced in the PR title without changing them
+func handleStatus(e event, gc commentClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	var rows []string
+	for _, refIssue := range e.issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		bug, err := getJira(jc, options, refIssue.Key(), log, comment)
+		if err != nil || bug == nil {
+			return err
+		}
+		targetVersion, err := helpers.GetIssueTargetVersion(bug)
+		if err != nil {
+			return comment(formatError(options, "getting the target version", jc.JiraURL(), refIssue.Key(), err))
+		}
+		status, resolution, version, assignee := "unset", "unset", "unset", "unassigned"
+		if bug.Fields != nil {
+			if bug.Fields.Status != nil && bug.Fields.Status.Name != "" {
+				status = bug.Fields.Status.Name
+			}
+			if bug.Fields.Resolution != nil && bug.Fields.Resolution.Name != "" {
+				resolution = bug.Fields.Resolution.Name
+			}
+			if bug.Fields.Assignee != nil && bug.Fields.Assignee.DisplayName != "" {
+				assignee = bug.Fields.Assignee.DisplayName
+			}
+		}
+		if len(targetVersion) != 0 {
+			var names []string
+			for _, v := range targetVersion {
+				names = append(names, v.Name)
+			}
+			version = strings.Join(names, ", ")
+		}
+		rows = append(rows, fmt.Sprintf("| "+issueLink+" | %s | %s | %s | %s |", refIssue.Key(), jc.JiraURL(), refIssue.Key(), status, resolution, version, assignee))
+	}
+	if len(rows) == 0 {
+		return comment("No Jira bugs are referenced in the title of this pull request.")
+	}
+	return comment("| Bug | Status | Resolution | Target Version | Assignee |\n| --- | --- | --- | --- | --- |\n" + strings.Join(rows, "\n"))
+}
+
+// handleMove transitions all bugs referenced in the PR title to the state requested via the `/jira move` command,
+// provided that the state can be reached from the current state of each bug
+func handleMove(e event, gc githubClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	if ok, err := checkCollaborator(gc, e, "/jira move", comment, log); !ok {
+		return err
+	}
+	var msgs []string
+	for _, refIssue := range e.issues {
+		if !refIssue.IsBug {
+			continue
+		}
+		bug, err := getJira(jc, options, refIssue.Key(), log, comment)
+		if err != nil || bug == nil {
+			return err
+		}
+		oldStatus := issueStatus(bug)
+		var fieldChanges []fieldChange
+		if !strings.EqualFold(oldStatus, e.move.Status) {
+			transitions, err := jc.GetTransitions(bug.ID)
+			if err != nil {
+				log.WithError(err).Warn("Unexpected error getting jira transitions.")
+				msgs = append(msgs, formatError(options, "getting the available transitions", jc.JiraURL(), refIssue.Key(), err))
+				continue
+			}
+			var transition *jira.Transition
+			var available []string
+			for i := range transitions {
+				if strings.EqualFold(transitions[i].To.Name, e.move.Status) {
+					transition = &transitions[i]
+					break
+				}
+				available = append(available, transitions[i].To.Name)
+			}
+			if transition == nil {
+				msgs = append(msgs, fmt.Sprintf(issueLink+" cannot be moved to the %s state from the %s state. Available states are: %s", refIssue.Key(), jc.JiraURL(), refIssue.Key(), e.move.Status, oldStatus, strings.Join(available, ", ")))
+				continue
+			}
+			if err := jc.DoTransition(bug.ID, transition.ID); err != nil {
+				log.WithError(err).Warn("Unexpected error updating jira issue.")
+				msgs = append(msgs, formatError(options, fmt.Sprintf("updating to the %s state", transition.To.Name), jc.JiraURL(), refIssue.Key(), err))
+				continue
+			}
+			recordTransition(e, transition.To.Name)
+			recordAudit(log, e, auditActionTransition, bug.Key, oldStatus, transition.To.Name)
+			fieldChanges = append(fieldChanges, fieldChange{field: "Status", oldValue: oldStatus, newValue: transition.To.Name})
+		}
+		if oldResolution := issueResolution(bug); e.move.Resolution != "" && !strings.EqualFold(oldResolution, e.move.Resolution) {
+			updateIssue := jira.Issue{Key: bug.Key, Fields: &jira.IssueFields{Resolution: &jira.Resolution{Name: e.move.Resolution}}}
+			if _, err := jc.UpdateIssue(&updateIssue); err != nil {
+				log.WithError(err).Warn("Unexpected error updating jira issue.")
+				msgs = append(msgs, formatError(options, fmt.Sprintf("updating to the %s resolution", e.move.Resolution), jc.JiraURL(), refIssue.Key(), err))
+				continue
+			}
+			recordAudit(log, e, auditActionResolution, bug.Key, oldResolution, e.move.Resolution)
+			fieldChanges = append(fieldChanges, fieldChange{field: "Resolution", oldValue: oldResolution, newValue: e.move.Resolution})
+		}
+		msgs = append(msgs, fmt.Sprintf(issueLink+" has been moved to the %s state.", refIssue.Key(), jc.JiraURL(), refIssue.Key(), e.move)+fieldChangesComment(options, refIssue.Key(), fieldChanges))
+	}
+	if len(msgs) == 0 {
+		return comment("No Jira bugs are referenced in the title of this pull request; no bugs were moved.")
+	}
+	return comment(strings.Join(msgs, "\n\n"))
+}
+
+// handleJiraComment adds the text provided via the `/jira comment` command as a comment on all issues referenced
+// in the PR title
+func handleJiraComment(e event, gc commentClient, jc jiraclient.Client, options JiraBranchOptions, log *logrus.Entry) error {
+	comment := e.comment(gc)
+	if len(e.issues) == 0 {
+		return comment("No Jira issues are referenced in the title of this pull request; no comment was added.")
+	}
+	var msgs []string
+	for _, refIssue := range e.issues {
+		issue, err := getJira(jc, options, refIssue.Key(), log, comment)
+		if err != nil || issue == nil {
+			return err
+		}
+		jiraComment := &jira.Comment{Body: fmt.Sprintf("%s commented on %s:\n\n%s", e.login, e.htmlUrl, e.jiraComment)}
+		if options.PrivateComments != nil && *options.PrivateComments {
+			jiraComment.Visibility = privateVisibility(options)
+		}
+		if _, err := jc.AddComment(issue.ID, jiraComment); err != nil {
+			log.WithError(err).Warn("Unexpected error adding comment to jira issue.")
+			msgs = append(msgs, formatError(options, "adding a comment", jc.JiraURL(), refIssue.Key(), err))
+			continue
+		}
+		recordAudit(log, e, auditActionComment, issue.Key, nil, jiraComment.Body)
+		msgs = append(msgs, fmt.Sprintf("Added comment to "+issueLink+".", refIssue.Key(), jc.JiraURL(), refIssue.Key()))
+	}
+	return comment(strings.Join(msgs, "\n\n"))
+}
+
 func isBugAllowed(issue *jira.Issue, allowedSecurityLevel []string) (bool, error) {
 	// if no allowed visibilities are listed, assume all visibilities are allowed
 	if len(allowedSecurityLevel) == 0 {
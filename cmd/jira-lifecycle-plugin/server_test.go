@@ -5,15 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/andygrunwald/go-jira"
 	"github.com/google/go-cmp/cmp"
 	"github.com/openshift-eng/jira-lifecycle-plugin/pkg/helpers"
 	"github.com/openshift-eng/jira-lifecycle-plugin/pkg/labels"
 	"github.com/openshift-eng/jira-lifecycle-plugin/pkg/status"
+	githubql "github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
 	"github.com/trivago/tgo/tcontainer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 
@@ -30,14 +37,52 @@ import (
 
 var allowEventAndDate = cmp.AllowUnexported(event{}, jira.Date{})
 
+// the real GitHub client and the fake used throughout these tests must both provide every call the plugin makes
+var (
+	_ githubClient = github.Client(nil)
+	_ githubClient = fakeGHClient{}
+)
+
 type fakeGHClient struct {
 	*fakegithub.FakeClient
 }
 
+// fakeGitHubLogins maps public emails to the GitHub users that have them listed
+var fakeGitHubLogins = map[string]string{"mapped-qa@example.com": "qa-user", "qa-alias@example.com": "qa-user", "second-qa@example.com": "second-qa-user"}
+
 func (f fakeGHClient) QueryWithGitHubAppsSupport(ctx context.Context, q any, vars map[string]any, org string) error {
+	query, ok := q.(*emailToLoginQuery)
+	if !ok {
+		return nil
+	}
+	email, _ := vars["email"].(githubql.String)
+	if login, ok := fakeGitHubLogins[string(email)]; ok {
+		query.Search.Edges = append(query.Search.Edges, queryEdge{Node: queryNode{User: queryUser{Login: githubql.String(login)}}})
+	}
 	return nil
 }
 
+// the upstream fake github client does not list pull requests, so we do it here
+func (f fakeGHClient) GetPullRequests(org, repo string) ([]github.PullRequest, error) {
+	var prs []github.PullRequest
+	for _, pr := range f.PullRequests {
+		prs = append(prs, *pr)
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+	return prs, nil
+}
+
+// countingGHClient counts the number of pull requests fetched from GitHub
+type countingGHClient struct {
+	fakeGHClient
+	pullRequestFetches atomic.Int32
+}
+
+func (c *countingGHClient) GetPullRequest(org, repo string, number int) (*github.PullRequest, error) {
+	c.pullRequestFetches.Add(1)
+	return c.fakeGHClient.GetPullRequest(org, repo, number)
+}
+
 type fakeJiraClient struct {
 	*fakejira.FakeClient
 }
@@ -58,6 +103,21 @@ func (f *fakeJiraClient) CloneIssue(issue *jira.Issue) (*jira.Issue, error) {
 	return clonedIssue, err
 }
 
+// the upstream fake jira client does not list projects, so we list the projects of the known issues
+func (f *fakeJiraClient) ListProjects() (*jira.ProjectList, error) {
+	keys := sets.New[string]()
+	for _, issue := range f.Issues {
+		if issue.Fields != nil && issue.Fields.Project.Key != "" {
+			keys.Insert(issue.Fields.Project.Key)
+		}
+	}
+	projects := make(jira.ProjectList, keys.Len())
+	for i, key := range sets.List(keys) {
+		projects[i].Key = key
+	}
+	return &projects, nil
+}
+
 func TestHandle(t *testing.T) {
 	t.Parallel()
 	yes := true
@@ -67,6 +127,18 @@ func TestHandle(t *testing.T) {
 	v3Str := "v3"
 	v4Str := "v4"
 	v5Str := "v5"
+	refreshHintStr := "Ask the release team to re-run the bug validation job."
+	commentFooterStr := "Reach out in #forum-my-team on Slack."
+	linkTitleTemplate := "{{.Repo}} PR {{.Number}}: {{.Title}}"
+	maxOneBug, maxTwoBugs := 1, 2
+	createProject, createAssignee := "OCPBUGS", "testUser"
+	cloneAssignee := "teamLead"
+	jiraDisplayURL := "https://jira-proxy.example.com/"
+	customVerified := "qe-approved"
+	linkedTestType := "OCPQE/Test"
+	slackChannel := "#team-bugs"
+	minimumSeverity := importantSeverity
+	linkIcon := RemoteLinkIcon{URL: "https://gitlab.com/favicon.ico", Title: "GitLab"}
 	v1zStr := "v1z"
 	v2zStr := "v2z"
 	v3zStr := "v3z"
@@ -82,6 +154,10 @@ func TestHandle(t *testing.T) {
 	verified := []JiraBugState{{Status: "VERIFIED"}}
 	active1 := "com.atlassian.greenhopper.service.sprint.Sprint@11b54434[id=57955,rapidViewId=14885,state=ACTIVE,name=uShift Sprint 248,startDate=2024-01-15T09:00:00.000Z,endDate=2024-02-05T09:00:00.000Z,completeDate=<null>,activatedDate=2024-01-15T08:17:37.677Z,sequence=57955,goal=,autoStartStop=false,synced=false]"
 	closed1 := "com.atlassian.greenhopper.service.sprint.Sprint@57a3e8ba[id=57484,rapidViewId=14885,state=CLOSED,name=uShift Sprint 247,startDate=2023-12-25T17:07:00.000Z,endDate=2024-01-15T17:07:00.000Z,completeDate=2024-01-15T08:15:40.614Z,activatedDate=2023-12-25T14:11:56.948Z,sequence=57484,goal=,autoStartStop=false,synced=false]"
+	recentlyUpdated := jira.Time(time.Now().Add(-time.Minute))
+	titleCommand, noRetitleCommand := "/title", ""
+	enteredModified, _ := time.Parse(jiraChangelogTimeLayout, "2024-01-15T09:00:00.000+0000")
+	updatedLongAgo := jira.Time(time.Now().Add(-48 * time.Hour))
 	jiraTransitions := []jira.Transition{
 		{
 			ID:   "1",
@@ -336,8 +412,11 @@ func TestHandle(t *testing.T) {
 		merged                     bool
 		closed                     bool
 		opened                     bool
+		reopened                   bool
 		refresh                    bool
 		backport                   bool
+		backportCheck              bool
+		create                     bool
 		backportBranches           []string
 		cherrypick                 bool
 		cherryPickFromPRNum        int
@@ -346,9 +425,12 @@ func TestHandle(t *testing.T) {
 		baseRef                    string
 		replaceReferencedBugs      []referencedIssue
 		noJira                     bool
+		cc                         bool
 		remoteLinks                map[string][]jira.RemoteLink
 		prs                        []github.PullRequest
 		prComments                 map[int][]github.IssueComment
+		prChanges                  []github.PullRequestChange
+		prCommits                  []github.RepositoryCommit
 		issues                     []jira.Issue
 		issueGetErrors             map[string]error
 		issueCreateErrors          map[string]error
@@ -361,16 +443,36 @@ func TestHandle(t *testing.T) {
 		expectedNewRemoteLinks     []jira.RemoteLink
 		expectedRemovedRemoteLinks []jira.RemoteLink
 		existingIssueLinks         []*jira.IssueLink
+		expectedIssueLinks         []*jira.IssueLink
 		// most of the tests can be handled by a single event struct with small modifications; for tests with more extensive differences, allow override
 		overrideEvent               *event
 		disabledProjects            []string
 		expectedCommentUpdates      []string
+		expectedDeletedComments     []string
+		expectedStatuses            map[string][]github.Status
 		verified                    []string
 		verifiedLater               []string
 		verifiedRemove, fileChanged bool
+		beforeSHA, afterSHA         string
+		verifiedByReview            bool
+		finalize                    bool
 		login                       string
+		author                      string
 		verificationInfo            []VerificationInfo
+		expectedTimeInState         []TimeInState
 		nilBigQuery                 bool
+		priority                    string
+		targetVersion               string
+		fixVersion                  string
+		bugStatus                   bool
+		move                        *JiraBugState
+		draft                       bool
+		jiraComment                 string
+		uncherrypick                bool
+		unlink                      bool
+		linkClone                   string
+		dryRun                      bool
+		expectedSlackMessages       []slackMessage
 	}{
 		{
 			name:    "Unrelated event gets no action",
@@ -470,15 +572,21 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:           "valid DFBUGS bug removes invalid label, adds valid/severity labels and comments",
-			issues:         []jira.Issue{{ID: "1", Key: "DFBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "DFBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "DFBUGS", ID: "123", IsBug: true}}, body: "This PR fixes DFBUGS-123", title: "DFBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
-			},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue DFBUGS-123](https://my-jira.com/browse/DFBUGS-123), which is valid.
+			name: "valid bug with previous invalid comment deletes the stale invalid comment",
+			prComments: map[int][]github.IssueComment{1: {
+				{ID: 1, Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.`, User: github.User{Login: fakegithub.Bot}},
+				{ID: 2, Body: "This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid: quoting the bot", User: github.User{Login: "alex"}},
+				{ID: 3, Body: "@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.", User: github.User{Login: fakegithub.Bot}},
+			}},
+			issues:                  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:                 JiraBranchOptions{}, // no requirements --> always valid
+			labels:                  []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityCritical},
+			expectedLabels:          []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedDeletedComments: []string{"org/repo#1"},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
 <details><summary>No validations were run on this bug</summary></details>
 
@@ -486,22 +594,23 @@ Instructions for interacting with me using PR comments are available [here](http
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes DFBUGS-123
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:           "invalid bug adds invalid label, removes valid label and comments",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
-			options:        JiraBranchOptions{IsOpen: &open},
-			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
- - expected the bug to be open, but it isn't
+			name:           "valid bug without QA contact and automatic QA review requests adds a warning",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{AutoCCQA: &yes},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+<details><summary>No validations were run on this bug</summary></details>
+
+Warning: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) does not have a QA contact, skipping review request.
 
 <details>
 
@@ -514,11 +623,16 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "invalid bug with matching previous comment adds invalid label, removes valid label and comments",
-			prComments: map[int][]github.IssueComment{1: {{Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
- - expected the bug to be open, but it isn't
+			name:           "valid bug with QA contact not mapped to a GitHub user and automatic QA review requests comments",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.QAContactField: map[string]any{"emailAddress": "qa@example.com"}}}}},
+			options:        JiraBranchOptions{AutoCCQA: &yes},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+<details><summary>No validations were run on this bug</summary></details>
+
+No GitHub users were found matching the public email listed for the QA contact in Jira (qa@example.com), skipping review request.
 
 <details>
 
@@ -528,15 +642,19 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`, User: github.User{Login: fakegithub.Bot}}}},
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
-			options:        JiraBranchOptions{IsOpen: &open},
-			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
- - expected the bug to be open, but it isn't
+</details>`,
+		},
+		{
+			name:           "valid bug reported by the PR author gets a warning when configured",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Reporter: &jira.User{EmailAddress: "mapped-qa@example.com"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{WarnSelfReportedFix: &yes},
+			author:         "qa-user",
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+<details><summary>No validations were run on this bug</summary></details>
+
+Warning: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) was reported by the author of this pull request, @qa-user. Self-reported fixes may need additional review.
 
 <details>
 
@@ -549,11 +667,21 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "invalid bug with correct state and previous comment does not comment",
-			prComments: map[int][]github.IssueComment{1: {{Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
- - expected the bug to be open, but it isn't
+			name:    "valid bug on a branch only matching the wildcard configuration gets a warning when configured",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options: JiraBranchOptions{WarnOnDefaultBranchConfig: &yes},
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":            {WarnOnDefaultBranchConfig: &yes},
+					"release-4.16": {WarnOnDefaultBranchConfig: &yes, ValidateByDefault: &yes},
+				},
+			},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+<details><summary>No validations were run on this bug</summary></details>
+
+Warning: No configuration matches the branch branch this PR targets, so the default configuration for all branches is used. If this branch should be configured, check the configuration of the plugin for this repository.
 
 <details>
 
@@ -563,32 +691,22 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`, User: github.User{Login: fakegithub.Bot}}}},
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
-			options:        JiraBranchOptions{IsOpen: &open},
-			labels:         []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+</details>`,
 		},
 		{
-			name: "one invalid bug and one valid bug adds invalid/severity labels and comments",
-			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			name:    "valid bug on a branch matching a configured pattern gets no default configuration warning",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options: JiraBranchOptions{WarnOnDefaultBranchConfig: &yes},
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":     {WarnOnDefaultBranchConfig: &yes},
+					"bra.*": {WarnOnDefaultBranchConfig: &yes, ValidateByDefault: &yes},
+				},
 			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			options:               JiraBranchOptions{IsOpen: &open},
-			labels:                []string{},
-			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityCritical},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
- - expected the bug to be open, but it isn't
-
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
-
-This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
-
-<details><summary>1 validation(s) were run on this bug</summary>
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-* bug is open, matching expected state (open)</details>
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -601,22 +719,31 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "one valid bug and one invalid bug adds invalid/severity labels and comments",
-			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			options:               JiraBranchOptions{IsOpen: &open},
-			labels:                []string{},
-			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityCritical},
+			name:           "valid bug reported by someone other than the PR author gets no warning",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Reporter: &jira.User{EmailAddress: "mapped-qa@example.com"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{WarnSelfReportedFix: &yes},
+			author:         "user",
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
 			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-<details><summary>1 validation(s) were run on this bug</summary>
+<details><summary>No validations were run on this bug</summary></details>
 
-* bug is open, matching expected state (open)</details>
+<details>
 
-This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is invalid:
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "invalid bug comment links to the configured jira display url",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, JiraDisplayURL: &jiraDisplayURL},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://jira-proxy.example.com/browse/OCPBUGS-123), which is invalid:
  - expected the bug to be open, but it isn't
 
 Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
@@ -632,165 +759,150 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "two valid bugs valid/severity labels and comments",
-			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			options:               JiraBranchOptions{IsOpen: &open},
-			labels:                []string{},
-			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+			name:    "missing bug comment names the configured jira display url",
+			options: JiraBranchOptions{JiraDisplayURL: &jiraDisplayURL},
+			expectedComment: `org/repo#1:@user: No Jira issue with key OCPBUGS-123 exists in the tracker at https://jira-proxy.example.com.
+Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
 
-<details><summary>1 validation(s) were run on this bug</summary>
+<details>
 
-* bug is open, matching expected state (open)</details>
+In response to [this](https://github.com/org/repo/pull/1):
 
-This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+>This PR fixes OCPBUGS-123
 
-<details><summary>1 validation(s) were run on this bug</summary>
 
-* bug is open, matching expected state (open)</details>
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "cc-qa requests review from the GitHub user of the QA contact",
+			body:           "/jira cc-qa",
+			cc:             true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.QAContactField: map[string]any{"emailAddress": "mapped-qa@example.com"}}}}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+Requesting review from QA contact:
+/cc @qa-user
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-123
+>/jira cc-qa
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name: "two valid bugs with different severities set valid and higher severity labels and comments",
-			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}},
-			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			options:               JiraBranchOptions{IsOpen: &open},
-			labels:                []string{},
-			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			name:           "cc-qa requests review from the GitHub users of all QA contacts once",
+			body:           "/jira cc-qa",
+			cc:             true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.QAContactField: []any{map[string]any{"emailAddress": "mapped-qa@example.com"}, map[string]any{"emailAddress": "second-qa@example.com"}, map[string]any{"emailAddress": "qa-alias@example.com"}}}}}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
 			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-<details><summary>1 validation(s) were run on this bug</summary>
-
-* bug is open, matching expected state (open)</details>
-
-This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
-
-<details><summary>1 validation(s) were run on this bug</summary>
+<details><summary>No validations were run on this bug</summary></details>
 
-* bug is open, matching expected state (open)</details>
+Requesting review from QA contacts:
+/cc @qa-user @second-qa-user
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-123
+>/jira cc-qa
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:           "invalid bug adds keeps human-added valid bug label",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
-			options:        JiraBranchOptions{IsOpen: &open},
-			humanLabelled:  true,
-			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
- - expected the bug to be open, but it isn't
+			name:           "cc-qa requests review from the mapped QA contacts and reports the unmapped ones",
+			body:           "/jira cc-qa",
+			cc:             true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.QAContactField: []any{map[string]any{"emailAddress": "qa@example.com"}, map[string]any{"emailAddress": "mapped-qa@example.com"}, map[string]any{"displayName": "No Email"}}}}}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+<details><summary>No validations were run on this bug</summary></details>
 
-Retaining the jira/valid-bug label as it was manually added.
+The QA contact No Email for [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) does not have a listed email, skipping review request.
+
+Requesting review from QA contact:
+/cc @qa-user
+
+No GitHub users were found matching the public email listed for the QA contact in Jira (qa@example.com), skipping review request.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-123
+>/jira cc-qa
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:    "no bug removes all labels and comments",
-			missing: true,
-			labels:  []string{labels.JiraValidBug, labels.JiraInvalidBug},
-			expectedComment: `org/repo#1:@user: No Jira issue is referenced in the title of this pull request.
-To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and request another refresh with <code>/jira refresh</code>.
+			name:           "cc-qa comments when no QA contact is set on the bug",
+			body:           "/jira cc-qa",
+			cc:             true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+No QA contact is set on [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), skipping review request.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-123
+>/jira cc-qa
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name: "valid premerge bug with status update comments and updates status",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:         jira.Project{Key: "OCPBUGS"},
-				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
-				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
-				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			options:        JiraBranchOptions{StateAfterValidation: &updated, PreMergeStateAfterValidation: &updated2}, // no requirements --> always valid
-			labels:         []string{labels.QEApproved},
-			expectedLabels: []string{labels.JiraValidRef, labels.QEApproved},
-			expectedComment: `org/repo#1:@user: This pull request references OCPBUGS-123 which is a valid jira issue. The bug has been moved to the UPDATED2 state.
+			name:           "valid DFBUGS bug removes invalid label, adds valid/severity labels and comments",
+			issues:         []jira.Issue{{ID: "1", Key: "DFBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "DFBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "DFBUGS", ID: "123", IsBug: true}}, body: "This PR fixes DFBUGS-123", title: "DFBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+			},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue DFBUGS-123](https://my-jira.com/browse/DFBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-123
+>This PR fixes DFBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:         jira.Project{Key: "OCPBUGS"},
-				Status:          &jira.Status{Name: "UPDATED2"},
-				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
-				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
-				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
-			}}},
 		},
 		{
-			name:   "valid bug with status update removes invalid label, adds valid label, comments and updates status",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			options:        JiraBranchOptions{StateAfterValidation: &updated}, // no requirements --> always valid
+			name:           "valid bug with severity labels disabled does not add a severity label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{DisableSeverityLabels: &yes},
 			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the UPDATED state.
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
 <details><summary>No validations were run on this bug</summary></details>
 
@@ -803,19 +915,16 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:  jira.Project{Key: "OCPBUGS"},
-				Status:   &jira.Status{Name: "UPDATED"},
-				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
-			}}},
 		},
 		{
-			name:                  "valid jira removes invalid label, adds valid label, comments",
-			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
-			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
-			labels:                []string{labels.JiraInvalidBug},
-			expectedLabels:        []string{labels.JiraValidRef},
-			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
+			name:           "valid bug with severity labels disabled does not remove an existing severity label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{DisableSeverityLabels: &yes},
+			labels:         []string{labels.JiraInvalidBug, labels.SeverityLow},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityLow},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -828,15 +937,15 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:                  "valid jira with incorrect version removes invalid label, adds valid label,comments",
-			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
-			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Issue"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
-			labels:                []string{labels.JiraInvalidBug},
-			expectedLabels:        []string{labels.JiraValidRef},
-			options:               JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
+			name:           "invalid bug adds invalid label, removes valid label and comments",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open},
+			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-Warning: The referenced jira issue has an invalid target version for the target branch this PR targets: expected the issue to target the "v1" version, but no target version was set.
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -849,22 +958,16 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "valid bug and valid jira ref adds valid/severity labels and comments",
-			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
-				{ID: "2", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}}},
-			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "JIRA", ID: "123", IsBug: false}},
-			options:               JiraBranchOptions{IsOpen: &open},
-			labels:                []string{},
-			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			name:           "bug in a status with required labels is valid when the PR has them",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "POST"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{StatusRequiredLabels: map[string][]string{"POST": {"needs-qe"}}},
+			labels:         []string{"needs-qe"},
+			expectedLabels: []string{"needs-qe", labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
 			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
 <details><summary>1 validation(s) were run on this bug</summary>
 
-* bug is open, matching expected state (open)</details>
-
-This pull request references JIRA-123 which is a valid jira issue.
+* pull request has the label(s) ` + "`needs-qe`" + ` required for bugs in the POST status</details>
 
 <details>
 
@@ -877,12 +980,14 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:                  "invalid jira with status update removes valid label, comments",
-			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
-			labels:                []string{labels.JiraValidRef},
-			expectedLabels:        []string{},
-			expectedComment: `org/repo#1:@user: No Jira issue with key JIRA-123 exists in the tracker at https://my-jira.com.
-Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
+			name:           "bug in a status with required labels is invalid when the PR is missing them",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "POST"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{StatusRequiredLabels: map[string][]string{"POST": {"needs-qe"}}},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the pull request to have the label(s) ` + "`needs-qe`" + ` while the bug is in the POST status, but it is missing ` + "`needs-qe`" + `
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -895,11 +1000,13 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:           "valid no-jira removes invalid label, adds valid label, comments",
-			noJira:         true,
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef},
-			expectedComment: `org/repo#1:@user: This pull request explicitly references no jira issue.
+			name:           "bug in a status without required labels is not checked for them",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{StatusRequiredLabels: map[string][]string{"POST": {"needs-qe"}}},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -912,40 +1019,60 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:           "valid no-jira with no changes comments if there is no previous matching comment",
-			prComments:     map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: "Hello again", User: github.User{Login: fakegithub.Bot}}}},
-			noJira:         true,
-			labels:         []string{labels.JiraValidRef},
-			expectedLabels: []string{labels.JiraValidRef},
-			expectedComment: `org/repo#1:@user: This pull request explicitly references no jira issue.
+			name:           "opened PR only updates labels when comments are only posted on refresh",
+			opened:         true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, CommentOnlyOnRefresh: &yes},
+			labels:         []string{labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+		},
+		{
+			name:           "refresh comments when comments are only posted on refresh",
+			body:           "/jira refresh",
+			refresh:        true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, CommentOnlyOnRefresh: &yes},
+			labels:         []string{labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-123
+>/jira refresh
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name: "valid no-jira with no changes comments if there if latest bot comment does not match",
-			prComments: map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: `org/repo#1:@user: This pull request explicitly references no jira issue.
-
-<details>
+			name:   "opened PR still comments on failed premerge transitions when comments are only posted on refresh",
+			opened: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Status:          &jira.Status{Name: "NEW"},
+				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+			}}},
+			options:        JiraBranchOptions{PreMergeStateAfterValidation: &JiraBugState{Status: "ON_QA"}, CommentOnlyOnRefresh: &yes},
+			labels:         []string{labels.QEApproved},
+			expectedLabels: []string{labels.JiraValidRef, labels.QEApproved},
+			expectedComment: `org/repo#1:@user: An error was encountered updating to the ON_QA state for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
 
-In response to [this](https://github.com/org/repo/pull/1):
+<details><summary>Full error message.</summary>
 
->This PR fixes OCPBUGS-123
+<code>
+No transition status with name ` + "`ON_QA`" + ` could be found. Please select from the following list: [NEW MODIFIED UPDATED VERIFIED CLOSED UPDATED2 NEW2]
+</code>
 
+</details>
 
-Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`, User: github.User{Login: fakegithub.Bot}}, {Body: "different comment", User: github.User{Login: fakegithub.Bot}}}},
-			noJira:         true,
-			labels:         []string{labels.JiraValidRef},
-			expectedLabels: []string{labels.JiraValidRef},
-			expectedComment: `org/repo#1:@user: This pull request explicitly references no jira issue.
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
 
 <details>
 
@@ -958,8 +1085,21 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "valid no-jira with no changes does not comments if latest bot comment matches",
-			prComments: map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: `org/repo#1:@user: This pull request explicitly references no jira issue.
+			name:           "opened PR still comments on errors when comments are only posted on refresh",
+			opened:         true,
+			issueGetErrors: map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
+			options:        JiraBranchOptions{CommentOnlyOnRefresh: &yes},
+			expectedComment: `org/repo#1:@user: An error was encountered searching for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+
+<details><summary>Full error message.</summary>
+
+<code>
+injected error getting bug
+</code>
+
+</details>
+
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
 
 <details>
 
@@ -969,21 +1109,21 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`, User: github.User{Login: fakegithub.Bot}}}},
-			noJira:         true,
-			labels:         []string{labels.JiraValidRef},
-			expectedLabels: []string{labels.JiraValidRef},
+</details>`,
 		},
 		{
-			name:           "valid bug with status update removes invalid label, adds valid label, comments and updates status with resolution",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}}},
-			options:        JiraBranchOptions{StateAfterValidation: &JiraBugState{Status: "CLOSED", Resolution: "VALIDATED"}}, // no requirements --> always valid
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityLow},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the CLOSED (VALIDATED) state.
+			name:           "opened PR still comments on warnings when comments are only posted on refresh",
+			opened:         true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Reporter: &jira.User{EmailAddress: "mapped-qa@example.com"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{WarnSelfReportedFix: &yes, CommentOnlyOnRefresh: &yes},
+			author:         "qa-user",
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
 <details><summary>No validations were run on this bug</summary></details>
 
+Warning: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) was reported by the author of this pull request, @qa-user. Self-reported fixes may need additional review.
+
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
@@ -993,27 +1133,17 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project: jira.Project{Key: "OCPBUGS"},
-				Status: &jira.Status{
-					Name: "CLOSED",
-				},
-				Resolution: &jira.Resolution{
-					Name: "VALIDATED",
-				},
-				// due to the way `Unknowns` works, this has to be provided as a map[string]interface{}
-				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: map[string]any{"Value": string(`<img alt="" src="/images/icons/priorities/low.svg" width="16" height="16"> Low`)}},
-			}}},
 		},
 		{
-			name:           "valid bug with status update removes invalid label, adds valid label, comments and does not update status when it is already correct",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "UPDATED"}}}},
-			options:        JiraBranchOptions{StateAfterValidation: &updated}, // no requirements --> always valid
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+			name:           "bug becoming invalid notifies the configured Slack channel",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, SlackChannel: &slackChannel},
+			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-<details><summary>No validations were run on this bug</summary></details>
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1024,19 +1154,21 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "UPDATED"}}}},
+			expectedSlackMessages: []slackMessage{{
+				channel: "#team-bugs",
+				text:    "https://github.com/org/repo/pull/1 references Jira issue(s) which are invalid:\n - OCPBUGS-123: expected the bug to be open, but it isn't",
+			}},
 		},
 		{
-			name:           "valid bug with external link removes invalid label, adds valid label, comments, makes an external bug link",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-			options:        JiraBranchOptions{AddExternalLink: &yes}, // no requirements --> always valid
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
-
-<details><summary>No validations were run on this bug</summary></details>
+			name:           "bug that is already invalid does not notify the configured Slack channel again",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, SlackChannel: &slackChannel},
+			labels:         []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-The bug has been updated to refer to the pull request using the external bug tracker.
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1047,35 +1179,23 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-			expectedNewRemoteLinks: []jira.RemoteLink{{Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			},
-			}},
 		},
 		{
-			name:   "valid bug with already existing external link removes invalid label, adds valid label, comments to say nothing changed",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			options:        JiraBranchOptions{AddExternalLink: &yes}, // no requirements --> always valid
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+			name: "bug with an unresolved blocker is invalid when blockers are required to be resolved",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}, IssueLinks: []*jira.IssueLink{{
+					Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+					InwardIssue: &jira.Issue{Key: "OCPBUGS-124"},
+				}}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "POST"}}},
+			},
+			options:        JiraBranchOptions{RequireBlockersResolved: &yes},
+			labels:         []string{labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected blocker [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to be resolved, but it is POST without a resolution
 
-<details><summary>No validations were run on this bug</summary></details>
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1086,108 +1206,84 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
 		},
 		{
-			name: "failure to fetch dependent bug results in a comment",
-			issues: []jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
-			}}},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "This PR fixes OCPBUGS-124", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			name: "bug linked to an issue of the required type is valid",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}, IssueLinks: []*jira.IssueLink{{
+					Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+					InwardIssue: &jira.Issue{Key: "OCPBUGS-124"},
+				}, {
+					Type:         jira.IssueLinkType{Name: "Related", Inward: "is related to", Outward: "relates to"},
+					OutwardIssue: &jira.Issue{Key: "OCPQE-1"},
+				}}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Type: jira.IssueType{Name: "Bug"}}},
+				{ID: "3", Key: "OCPQE-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPQE"}, Type: jira.IssueType{Name: "Test"}}},
 			},
-			existingIssueLinks: []*jira.IssueLink{&cloneBetween123to124, &blocksBetween123to124},
-			issueGetErrors:     map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
-			options:            JiraBranchOptions{DependentBugStates: &verified},
-			expectedComment: `org/repo#2:@user: An error was encountered searching for dependent bug OCPBUGS-123 for bug OCPBUGS-124 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
-
-<details><summary>Full error message.</summary>
-
-<code>
-injected error getting bug
-</code>
+			options:        JiraBranchOptions{RequireLinkedIssueType: &linkedTestType},
+			labels:         []string{},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-</details>
+<details><summary>1 validation(s) were run on this bug</summary>
 
-Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+* bug is linked to the OCPQE/Test issue [Jira Issue OCPQE-1](https://my-jira.com/browse/OCPQE-1)</details>
 
 <details>
 
-In response to [this](https://github.com/org/repo/pull/2):
+In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-124
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name: "valid bug with dependent bugs removes invalid label, adds valid label, comments",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:    jira.Project{Key: "OCPBUGS"},
-				Status:     &jira.Status{Name: "VERIFIED"},
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: &v2,
-				},
-			},
-			}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Project:    jira.Project{Key: "OCPBUGS"},
-				Status:     &jira.Status{Name: "MODIFIED"},
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: &v1,
-				},
-			}}},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "This PR fixes OCPBUGS-124", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			name: "bug without a linked issue of the required type is invalid",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}, IssueLinks: []*jira.IssueLink{{
+					Type:        jira.IssueLinkType{Name: "Related", Inward: "is related to", Outward: "relates to"},
+					InwardIssue: &jira.Issue{Key: "OCPBUGS-124", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Test"}}},
+				}}}},
 			},
-			existingIssueLinks: []*jira.IssueLink{&cloneBetween123to124, &blocksBetween123to124},
-			options:            JiraBranchOptions{IsOpen: &yes, TargetVersion: &v1Str, DependentBugStates: &verified, DependentBugTargetVersions: &[]string{v2Str}},
-			labels:             []string{labels.JiraInvalidBug},
-			expectedLabels:     []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedComment: `org/repo#2:@user: This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
-
-<details><summary>5 validation(s) were run on this bug</summary>
+			options:        JiraBranchOptions{RequireLinkedIssueType: &linkedTestType},
+			labels:         []string{labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be linked to an issue of type OCPQE/Test, but no such issue is linked
 
-* bug is open, matching expected state (open)
-* bug target version (v1) matches configured target version for branch (v1)
-* dependent bug [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in the state VERIFIED, which is one of the valid states (VERIFIED)
-* dependent [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) targets the "v2" version, which is one of the valid target versions: v2
-* bug has dependents</details>
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
-In response to [this](https://github.com/org/repo/pull/2):
+In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-124
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:   "valid bug on merged PR with one external link migrates to new state with resolution and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project: jira.Project{Key: "OCPBUGS"},
-				Status:  &jira.Status{Name: "MODIFIED"},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
-			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+			name: "qe approved label is added when all dependent bugs are verified",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}, IssueLinks: []*jira.IssueLink{{
+					Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+					InwardIssue: &jira.Issue{Key: "OCPBUGS-124"},
+				}}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "VERIFIED"}}},
+			},
+			options:        JiraBranchOptions{AutoQEApproveOnVerifiedDependents: &yes},
+			labels:         []string{},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant, labels.QEApproved},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED (MERGED) state.
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug has dependents</details>
+
+Adding the qe-approved label as all dependent bugs are VERIFIED.
 
 <details>
 
@@ -1198,39 +1294,26 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:    jira.Project{Key: "OCPBUGS"},
-				Status:     &jira.Status{Name: "CLOSED"},
-				Resolution: &jira.Resolution{Name: "MERGED"},
-				Unknowns:   tcontainer.MarshalMap{},
-			}}},
 		},
 		{
-			name:   "valid premerge bug on merged PR with one external link migrates to new state with resolution and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:         jira.Project{Key: "OCPBUGS"},
-				Status:          &jira.Status{Name: "MODIFIED"},
-				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
-				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
+			name: "qe approved label is removed when a dependent bug is no longer verified",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}, IssueLinks: []*jira.IssueLink{{
+					Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+					InwardIssue: &jira.Issue{Key: "OCPBUGS-124"},
+				}}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "ON_QA"}}},
+			},
+			options:        JiraBranchOptions{AutoQEApproveOnVerifiedDependents: &yes},
 			labels:         []string{labels.QEApproved},
-			expectedLabels: []string{labels.QEApproved},
-			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
-			options:        JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}, PreMergeStateAfterMerge: &JiraBugState{Status: "UPDATED2", Resolution: "MERGED2"}}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the UPDATED2 (MERGED2) state.
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug has dependents</details>
+
+Removing the qe-approved label as not all dependent bugs are VERIFIED.
 
 <details>
 
@@ -1241,50 +1324,27 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:         jira.Project{Key: "OCPBUGS"},
-				Status:          &jira.Status{Name: "UPDATED2"},
-				Resolution:      &jira.Resolution{Name: "MERGED2"},
-				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
-				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
-				Unknowns:        tcontainer.MarshalMap{},
-			}}},
 		},
 		{
-			name:   "2 valid bugs on merged PR with one external link migrates to new state with resolution and comments",
-			merged: true,
+			name: "manually added qe approved label is retained when a dependent bug is no longer verified",
 			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Modified}}},
-				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Modified}}},
-			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}}}, "OCPBUGS-124": {{ID: 2, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}}},
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}, IssueLinks: []*jira.IssueLink{{
+					Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+					InwardIssue: &jira.Issue{Key: "OCPBUGS-124"},
+				}}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "ON_QA"}}},
 			},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
-			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+			options:        JiraBranchOptions{AutoQEApproveOnVerifiedDependents: &yes},
+			labels:         []string{labels.QEApproved},
+			humanLabelled:  true,
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant, labels.QEApproved},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED (MERGED) state.
+<details><summary>1 validation(s) were run on this bug</summary>
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+* bug has dependents</details>
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been moved to the CLOSED (MERGED) state.
+Retaining the qe-approved label as it was manually added.
 
 <details>
 
@@ -1295,37 +1355,17 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:    jira.Project{Key: "OCPBUGS"},
-				Status:     &jira.Status{Name: "CLOSED"},
-				Resolution: &jira.Resolution{Name: "MERGED"},
-				Unknowns:   tcontainer.MarshalMap{},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Project:    jira.Project{Key: "OCPBUGS"},
-				Status:     &jira.Status{Name: "CLOSED"},
-				Resolution: &jira.Resolution{Name: "MERGED"},
-				Unknowns:   tcontainer.MarshalMap{},
-			}}},
 		},
 		{
-			name:   "valid bug on merged PR with one external link migrates to new state and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
-			options: JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+			name:           "bug without target version gets needs information label when configured",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}, Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{TargetVersion: &v1Str, NeedsInformationLabel: &yes},
+			labels:         []string{},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.NeedsInformation, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to target the "v1" version, but no target version was set
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1336,52 +1376,17 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
 		},
 		{
-			name:   "valid bug on merged PR with many external links migrates to new state and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
-				ID: 1,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/1",
-					Title: "org/repo#1: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/22/commits/1234567890",
-					Title: "org/repo#22: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/23/files",
-					Title: "org/repo#23: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}, {Number: 22, Merged: true}, {Number: 23, Merged: true}},
-			options: JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
- * [org/repo#22](https://github.com/org/repo/pull/22)
- * [org/repo#23](https://github.com/org/repo/pull/23)
+			name:           "bug with mismatched target version does not get needs information label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}, Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant, helpers.TargetVersionField: &v2}}}},
+			options:        JiraBranchOptions{TargetVersion: &v1Str, NeedsInformationLabel: &yes},
+			labels:         []string{},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to target either version "v1.*" or "openshift-v1.*", but it targets "v2" instead
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1392,46 +1397,18 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
 		},
 		{
-			name:   "valid bug on merged PR with unmerged external links does nothing",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
-				ID: 1,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/1",
-					Title: "org/repo#1: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/22",
-					Title: "org/repo#22: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			},
-			}},
-			prs:            []github.PullRequest{{Number: base.number, Merged: true}, {Number: 22, Merged: false, State: "open"}},
-			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): Some pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+			name:           "needs information label is removed once the target version is set",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant, helpers.TargetVersionField: &v1}}}},
+			options:        JiraBranchOptions{TargetVersion: &v1Str, NeedsInformationLabel: &yes},
+			labels:         []string{labels.JiraInvalidBug, labels.NeedsInformation},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-The following pull requests linked via external trackers have not merged:
- * [org/repo#22](https://github.com/org/repo/pull/22) is open
-
-These pull request must merge or be unlinked from the Jira bug in order for it to move to the next state. Once unlinked, request a bug refresh with <code>/jira refresh</code>.
+<details><summary>1 validation(s) were run on this bug</summary>
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has not been moved to the MODIFIED state.
+* bug target version (v1) matches configured target version for branch (v1)</details>
 
 <details>
 
@@ -1444,58 +1421,40 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:   "2 valid bugs on merged PR, one with unmerged external links and one with merged external links",
-			merged: true,
-			issues: []jira.Issue{
-				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: status.Modified}}},
-				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Status: &jira.Status{Name: status.Modified}}},
-			},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
-				ID: 1, Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/1",
-					Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/22",
-					Title: "org/repo#22: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			},
-			}, "OCPBUGS-124": {{ID: 2, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}}},
-			},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}, {Number: 22, Merged: false, State: "open"}},
-			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): Some pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+			name:           "bug without severity gets needs information label when configured",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+			options:        JiraBranchOptions{NeedsInformationLabel: &yes},
+			labels:         []string{},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.NeedsInformation},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-The following pull requests linked via external trackers have not merged:
- * [org/repo#22](https://github.com/org/repo/pull/22) is open
+<details><summary>No validations were run on this bug</summary></details>
 
-These pull request must merge or be unlinked from the Jira bug in order for it to move to the next state. Once unlinked, request a bug refresh with <code>/jira refresh</code>.
+<details>
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has not been moved to the CLOSED (MERGED) state.
+In response to [this](https://github.com/org/repo/pull/1):
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
+>This PR fixes OCPBUGS-123
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been moved to the CLOSED (MERGED) state.
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "valid bug with status publishing sets a successful commit status",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{PublishStatus: &yes},
+			prs:            []github.PullRequest{{Number: 1, Head: github.PullRequestBranch{SHA: "abcdef"}}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedStatuses: map[string][]github.Status{"abcdef": {{
+				State:       github.StatusSuccess,
+				Context:     "jira/valid-bug",
+				Description: "All Jira bugs referenced in the title of this pull request are valid.",
+			}}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -1506,34 +1465,23 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "MODIFIED"},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Status:     &jira.Status{Name: "CLOSED"},
-				Resolution: &jira.Resolution{Name: "MERGED"},
-				Unknowns:   tcontainer.MarshalMap{},
-			}}},
 		},
 		{
-			name:   "External bug on rep that is not in our config is ignored, bug gets set to MODIFIED",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/unreferenced/repo/pull/22",
-				Title: "unreferenced/repo#22: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:            []github.PullRequest{{Number: 22, Merged: false, State: "open"}},
-			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
-
+			name:           "invalid bug with status publishing sets a failed commit status",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, PublishStatus: &yes},
+			prs:            []github.PullRequest{{Number: 1, Head: github.PullRequestBranch{SHA: "abcdef"}}},
+			labels:         []string{labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedStatuses: map[string][]github.Status{"abcdef": {{
+				State:       github.StatusFailure,
+				Context:     "jira/valid-bug",
+				Description: "A Jira bug referenced in the title of this pull request is invalid.",
+			}}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1546,67 +1494,29 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:   "valid bug on merged PR with one external link but no status after merge configured does nothing",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-		},
-		{
-			name:    "valid bug on merged PR with one external link but no referenced bug in the title does nothing",
-			merged:  true,
-			missing: true,
-			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
-			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-		},
-		{
-			name:           "valid bug on merged PR with one external link fails to update bug and comments",
-			merged:         true,
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
-			issueGetErrors: map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
-			options: JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: An error was encountered searching for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+			name: "invalid bug with matching previous comment adds invalid label, removes valid label and comments",
+			prComments: map[int][]github.IssueComment{1: {{Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-<details><summary>Full error message.</summary>
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
-<code>
-injected error getting bug
-</code>
+<details>
 
-</details>
+In response to [this](https://github.com/org/repo/pull/1):
 
-Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`, User: github.User{Login: fakegithub.Bot}}}},
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open},
+			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1617,29 +1527,13 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
 		},
 		{
-			name:   "valid bug on merged PR with merged external links but unknown status does not migrate to new state and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
-			options: JiraBranchOptions{StateAfterValidation: &updated, StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in an unrecognized state (CLOSED) and will not be moved to the MODIFIED state.
+			name: "invalid bug with correct state and previous comment does not comment",
+			prComments: map[int][]github.IssueComment{1: {{Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1649,37 +1543,22 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`,
-
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
+</details>`, User: github.User{Login: fakegithub.Bot}}}},
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open},
+			labels:         []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
 		},
 		{
-			name:   "closed PR removes link and comments",
-			merged: false,
-			closed: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
-			options: JiraBranchOptions{AddExternalLink: &yes},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker.
+			name: "title referencing more bugs than allowed is rejected without validating the bugs",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{MaxReferencedBugs: &maxOneBug, StateAfterValidation: &updated},
+			labels:                []string{},
+			expectedComment: `org/repo#1:@user: This pull request references 2 Jira bugs (OCPBUGS-123, OCPBUGS-124), which is more than the 1 allowed for this branch. None of the bugs have been validated; edit the title of this pull request to reference fewer bugs.
 
 <details>
 
@@ -1690,66 +1569,28 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
+			expectedIssues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
 			},
 		},
 		{
-			name:   "closed PR without a link does nothing",
-			merged: false,
-			closed: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
-			options: JiraBranchOptions{AddExternalLink: &yes},
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-		},
-		{
-			name:   "closed PR removes link, changes bug state, and comments",
-			merged: false,
-			closed: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Status: &jira.Status{Name: "POST"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
-			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+			name: "title referencing as many bugs as allowed is validated",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{MaxReferencedBugs: &maxTwoBugs},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -1760,57 +1601,24 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "NEW"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}, {
-					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
-					Visibility: PrivateVisibility,
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			},
 		},
 		{
-			name:   "closed PR of premerge bug removes link, changes bug state, and comments",
-			merged: false,
-			closed: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Status: &jira.Status{Name: "POST"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
-				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			labels:         []string{labels.QEApproved},
-			expectedLabels: []string{labels.QEApproved},
-			prs:            []github.PullRequest{{Number: base.number, Merged: false}},
-			options:        JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, PreMergeStateAfterClose: &JiraBugState{Status: "NEW2"}},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW2 state.
+			name: "bugs targeting the same version are valid when consistent target versions are required",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.TargetVersionField: &v1}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.TargetVersionField: &v1}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{RequireConsistentTargetVersions: &yes},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -1821,74 +1629,26 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "NEW2"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}, {
-					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
-					Visibility: PrivateVisibility,
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
-				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
-			}}},
-			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			},
 		},
 		{
-			name:                  "closed PR for multiple bugs removes links, changes bug states, and comments",
-			merged:                false,
-			closed:                true,
+			name: "bugs targeting different versions are invalid when consistent target versions are required",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.TargetVersionField: &v1}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.TargetVersionField: &v2}}},
+			},
 			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Status: &jira.Status{Name: "POST"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is also a bug",
-				}}},
-				Status: &jira.Status{Name: "POST"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}, "OCPBUGS-124": {{ID: 2, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
-			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+			options:               JiraBranchOptions{RequireConsistentTargetVersions: &yes, StateAfterValidation: &updated},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected all bugs referenced by this pull request to target the same version, but they target: OCPBUGS-123 (v1), OCPBUGS-124 (v2)
 
-This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is invalid:
+ - expected all bugs referenced by this pull request to target the same version, but they target: OCPBUGS-123 (v1), OCPBUGS-124 (v2)
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -1899,88 +1659,53 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "NEW"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}, {
-					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
-					Visibility: PrivateVisibility,
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "NEW"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is also a bug",
-				}, {
-					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
-					Visibility: PrivateVisibility,
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}}, {ID: 2, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}}},
+			expectedIssues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.TargetVersionField: &v1}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical, helpers.TargetVersionField: &v2}}},
+			},
 		},
 		{
-			name:    "closed PR with missing bug does nothing",
-			merged:  false,
-			closed:  true,
-			missing: true,
-			issues:  []jira.Issue{},
-			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
-			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			name: "title referencing bugs from multiple projects is rejected when the same project is required",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "DFBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "DFBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "DFBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{RequireSameProject: &yes, StateAfterValidation: &updated},
+			labels:                []string{},
+			expectedComment: `org/repo#1:@user: This pull request references Jira issues from multiple projects (DFBUGS, OCPBUGS), but all referenced issues must belong to the same project on this branch. None of the issues have been validated; edit the title of this pull request to reference issues from a single project.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "DFBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "DFBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
 		},
 		{
-			name:   "closed PR with multiple external links removes link, does not change bug state, and comments",
-			merged: false,
-			closed: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "POST"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
-				ID: 1,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/1",
-					Title: "org/repo#1: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/issues/42",
-					Title: "org/repo#42: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				}},
-			}},
-			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
-			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker.
+			name: "title referencing bugs from the same project is validated when the same project is required",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{RequireSameProject: &yes},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -1991,47 +1716,22 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "POST"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField: severityCritical,
-				},
-			}}},
-			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
-				URL:   "https://github.com/org/repo/pull/1",
-				Title: "org/repo#1: OCPBUGS-123: fixed it!",
-				Icon: &jira.RemoteLinkIcon{
-					Url16x16: "https://github.com/favicon.ico",
-					Title:    "GitHub",
-				},
-			}},
-			},
 		},
 		{
-			name: "Cherrypick PR results in cloned bug creation",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Labels: []string{"good_label", "bad_label_1", "bad_label_2"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabels: []string{"bad_label_2", "bad_label_1"}},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
-/retitle [v1] OCPBUGS-124: fixed it!
+			name: "non-bug references from other projects are exempt from the same project requirement when configured",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "RFE-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "RFE"}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "RFE", ID: "1"}},
+			options:               JiraBranchOptions{RequireSameProject: &yes, SameProjectIgnoreNonBugs: &yes},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+This pull request references RFE-1 which is a valid jira issue.
 
 <details>
 
@@ -2042,51 +1742,27 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Assignee:    &jira.User{Name: "testUser"},
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Labels:     []string{"good_label"},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
 		},
 		{
+			name: "one invalid bug and one valid bug adds invalid/severity labels and comments",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{IsOpen: &open},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-			name: "Cherrypick PR with Sprint field results in cloned bug creation",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-					helpers.SprintField:        []any{active1, closed1},
-				},
-			}}},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
-/retitle [v1] OCPBUGS-124: fixed it!
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)</details>
 
 <details>
 
@@ -2097,66 +1773,27 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Assignee:    &jira.User{Name: "testUser"},
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-					helpers.SprintField:        float64(57955),
-				},
-			}}},
 		},
 		{
-			name: "Cherrypick PR for multiple bugs results in multiple cloned bug creation",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
+			name: "one valid bug and one invalid bug adds invalid/severity labels and comments",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
 			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			prs:                   []github.PullRequest{{Number: base.number, Body: base.body, Title: "OCPBUGS-123,OCPBUGS-124: fixed it!"}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!"}},
-			title:                 "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!",
-			cherrypick:            true,
-			cherryPickFromPRNum:   1,
-			options:               JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+			options:               JiraBranchOptions{IsOpen: &open},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
-/retitle [v1] OCPBUGS-125,OCPBUGS-126: fixed it!
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)</details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is invalid:
+ - expected the bug to be open, but it isn't
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
 
 <details>
 
@@ -2167,105 +1804,28 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{{
-					Type: jira.IssueLinkType{
-						Name:    "Cloners",
-						Inward:  "is cloned by",
-						Outward: "clones",
-					},
-					OutwardIssue: &jira.Issue{ID: "2"},
-				}, {
-					Type: jira.IssueLinkType{
-						Name:    "Blocks",
-						Inward:  "is blocked by",
-						Outward: "blocks",
-					},
-					InwardIssue: &jira.Issue{ID: "2"},
-				}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
 		},
 		{
-			name: "Cherrypick PR for multiple bugs with 1 failure still comments for both bugs",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
+			name: "two valid bugs valid/severity labels and comments",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+			},
 			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			issueUpdateErrors:     map[string]error{"OCPBUGS-125": errors.New("injected error updating bug OCPBUGS-125")},
-			prs:                   []github.PullRequest{{Number: base.number, Body: base.body, Title: "OCPBUGS-123,OCPBUGS-124: fixed it!"}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!"}},
-			title:                 "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!",
-			cherrypick:            true,
-			cherryPickFromPRNum:   1,
-			options:               JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+			options:               JiraBranchOptions{IsOpen: &open},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-WARNING: Failed to update the target version, assignee, and sprint for the clone. Please update these fields manually. Full error below:
-<details><summary>Full error message.</summary>
+<details><summary>1 validation(s) were run on this bug</summary>
 
-<code>
-injected error updating bug OCPBUGS-125
-</code>
+* bug is open, matching expected state (open)</details>
 
-</details>
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
-/retitle [v1] OCPBUGS-125,OCPBUGS-126: fixed it!
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)</details>
 
 <details>
 
@@ -2276,284 +1836,96 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "defaultAssignee"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v2Str}},
-				},
-			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{{
-					Type: jira.IssueLinkType{
-						Name:    "Cloners",
-						Inward:  "is cloned by",
-						Outward: "clones",
-					},
-					OutwardIssue: &jira.Issue{ID: "2"},
-				}, {
-					Type: jira.IssueLinkType{
-						Name:    "Blocks",
-						Inward:  "is blocked by",
-						Outward: "blocks",
-					},
-					InwardIssue: &jira.Issue{ID: "2"},
-				}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
 		},
 		{
-			name: "Cherrypick comment results in cloned bug creation",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
-			prs: []github.PullRequest{{Number: 2, Body: "This is a manually created cherrypick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira cherrypick OCPBUGS-123", title: "fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", cherrypick: true, cherrypickCmd: true, missing: true,
+			name: "two valid bugs with different severities set valid and higher severity labels and comments",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}},
 			},
-			cherrypick: true,
-			missing:    true,
-			options:    JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#2:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
-/retitle OCPBUGS-124: fixed it!
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{IsOpen: &open},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)</details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)</details>
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira cherrypick OCPBUGS-123
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
 		},
 		{
-			name: "Cherrypick comment for multiple bugs results in multiple cloned bug creation",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
-			prs:                   []github.PullRequest{{Number: 2, Body: "This is a manually created cherrypick of #1.\n\n/assign user", Title: "[v1] fixing stuff"}},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira cherrypick OCPBUGS-123,OCPBUGS-124", title: "fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", cherrypick: true, cherrypickCmd: true, missing: true,
-			},
-			cherrypick: true,
-			missing:    true,
-			options:    JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#2:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+			name:           "invalid bug adds keeps human-added valid bug label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open},
+			humanLabelled:  true,
+			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
 
-[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
-/retitle OCPBUGS-125,OCPBUGS-126: fixed it!
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+
+Retaining the jira/valid-bug label as it was manually added.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira cherrypick OCPBUGS-123,OCPBUGS-124
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{{
-					Type: jira.IssueLinkType{
-						Name:    "Cloners",
-						Inward:  "is cloned by",
-						Outward: "clones",
-					},
-					OutwardIssue: &jira.Issue{ID: "2"},
-				}, {
-					Type: jira.IssueLinkType{
-						Name:    "Blocks",
-						Inward:  "is blocked by",
-						Outward: "blocks",
-					},
-					InwardIssue: &jira.Issue{ID: "2"},
-				}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
 		},
 		{
-			name: "Cherrypick comment for multiple bugs results in cloned bug creation and comment about non-bug issue",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
-			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OTHER", ID: "124", IsBug: false}},
-			prs:                   []github.PullRequest{{Number: 2, Body: "This is a manually created cherrypick of #1.\n\n/assign user", Title: "[v1] fixing stuff"}},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OTHER", ID: "124", IsBug: false}}, body: "/jira cherrypick OCPBUGS-123,OTHER-124", title: "fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", cherrypick: true, cherrypickCmd: true, missing: true,
-			},
-			cherrypick: true,
-			missing:    true,
-			options:    JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#2:@user: Ignoring requests to cherry-pick non-bug issues: OTHER-124
- [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
-/retitle OCPBUGS-124: fixed it!
+			name:           "pull request by an ignored author gets no action",
+			missing:        true,
+			author:         "Dependabot[bot]",
+			labels:         []string{labels.JiraValidBug},
+			options:        JiraBranchOptions{IgnoreAuthors: []string{"dependabot[bot]"}},
+			expectedLabels: []string{labels.JiraValidBug},
+		},
+		{
+			name:    "pull request by an author that is not ignored gets comment on missing bug",
+			missing: true,
+			labels:  []string{labels.JiraValidBug},
+			author:  "user",
+			options: JiraBranchOptions{IgnoreAuthors: []string{"dependabot[bot]"}},
+			expectedComment: `org/repo#1:@user: No Jira issue is referenced in the title of this pull request.
+To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and request another refresh with <code>/jira refresh</code>.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira cherrypick OCPBUGS-123,OTHER-124
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
 		},
 		{
-			name: "parent PR of cherrypick not existing results in error",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
-			prs:                 []github.PullRequest{{Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: Error creating a cherry-pick bug in Jira: failed to check the state of cherrypicked pull request at https://github.com/org/repo/pull/1: pull request number 1 does not exist.
-Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+			name:    "no bug removes all labels and comments",
+			missing: true,
+			labels:  []string{labels.JiraValidBug, labels.JiraInvalidBug},
+			expectedComment: `org/repo#1:@user: No Jira issue is referenced in the title of this pull request.
+To reference a jira issue, add 'XYZ-NNN:' to the title of this pull request and request another refresh with <code>/jira refresh</code>.
 
 <details>
 
@@ -2566,34 +1938,26 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name: "failure to obtain parent bug for cherrypick results in error",
+			name: "valid premerge bug with status update comments and updates status",
 			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Status: &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
 			}}},
-			issueGetErrors:      map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: An error was encountered searching for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
-
-<details><summary>Full error message.</summary>
-
-<code>
-injected error getting bug
-</code>
-
-</details>
-
-Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated, PreMergeStateAfterValidation: &updated2}, // no requirements --> always valid
+			labels:         []string{labels.QEApproved},
+			expectedLabels: []string{labels.JiraValidRef, labels.QEApproved},
+			expectedComment: `org/repo#1:@user: This pull request references OCPBUGS-123 which is a valid jira issue. The bug has been moved to the UPDATED2 state.
 
 <details>
 
@@ -2604,40 +1968,35 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "failure to update bug retitles the PR and prints a warning in the comment",
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Status:          &jira.Status{Name: "UPDATED2"},
+				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+			}}},
+		},
+		{
+			name: "valid premerge bug with affects version requirement comments and updates status",
 			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
 			}}},
-			issueUpdateErrors:   map[string]error{"OCPBUGS-124": errors.New("injected error updating bug OCPBUGS-124")},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
-
-WARNING: Failed to update the target version, assignee, and sprint for the clone. Please update these fields manually. Full error below:
-<details><summary>Full error message.</summary>
-
-<code>
-injected error updating bug OCPBUGS-124
-</code>
-
-</details>
-/retitle [v1] OCPBUGS-124: fixed it!
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated, PreMergeStateAfterValidation: &updated2, RequireAffectsVersion: &yes},
+			labels:         []string{labels.QEApproved},
+			expectedLabels: []string{labels.JiraValidRef, labels.QEApproved},
+			expectedComment: `org/repo#1:@user: This pull request references OCPBUGS-123 which is a valid jira issue. The bug has been moved to the UPDATED2 state.
 
 <details>
 
@@ -2648,34 +2007,24 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "If bug clone with correct target version already exists, just retitle PR",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
-				Status:     &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
-				Status:     &jira.Status{Name: "NEW"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v1,
-				},
-			}},
-			},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: Detected clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) with correct target version. Will retitle the PR to link to the clone.
-/retitle [v1] OCPBUGS-124: fixed it!
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Status:          &jira.Status{Name: "UPDATED2"},
+				Unknowns:        tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+			}}},
+		},
+		{
+			name:           "valid bug on draft PR with drafts skipped adds labels and comments without updating status",
+			draft:          true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated, SkipDrafts: &yes},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug will be moved to the UPDATED state once this pull request is no longer a draft.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -2686,28 +2035,18 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "If bug clone with correct target version already is listed as an issue label, just retitle PR",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
-				Labels:     []string{"random-label", "random-label-2", "jlp-v2:OCPBUGS-125", "jlp-v1:OCPBUGS-124"},
-				Status:     &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			baseRef:             "v1",
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: Detected clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) with correct target version. Will retitle the PR to link to the clone.
-/retitle [v1] OCPBUGS-124: fixed it!
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+		},
+		{
+			name:           "valid bug on draft PR without drafts skipped updates status",
+			draft:          true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the UPDATED state.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -2718,49 +2057,26 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "If clone with correct target version already exists in multibug PR, retitle PR for correct clone and create clone for other bug",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee:   &jira.User{Name: "testUser"},
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
-				Status:     &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee:   &jira.User{Name: "testUser"},
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
-				Status:     &jira.Status{Name: "NEW"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v1,
-				},
-			}}, {ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v1,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "UPDATED"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+		},
+		{
+			name:   "valid bug with status update removes invalid label, adds valid label, comments and updates status",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
 				},
 			}},
-			},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: "OCPBUGS-123,OCPBUGS-125: fixed it!"}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] OCPBUGS-123,OCPBUGS-125: fixed it!"}},
-			title:               "[v1] OCPBUGS-123,OCPBUGS-125: fixed it!",
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: Detected clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) with correct target version. Will retitle the PR to link to the clone.
+			}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated}, // no requirements --> always valid
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the UPDATED state.
 
-[Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
-/retitle [v1] OCPBUGS-124,OCPBUGS-126: fixed it!
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -2771,66 +2087,19 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				IssueLinks: []*jira.IssueLink{{
-					Type: jira.IssueLinkType{
-						Name:    "Cloners",
-						Inward:  "is cloned by",
-						Outward: "clones",
-					},
-					OutwardIssue: &jira.Issue{ID: "3"},
-				}, {
-					Type: jira.IssueLinkType{
-						Name:    "Blocks",
-						Inward:  "is blocked by",
-						Outward: "blocks",
-					},
-					InwardIssue: &jira.Issue{ID: "3"},
-				}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "OCPBUGS"},
+				Status:   &jira.Status{Name: "UPDATED"},
+				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate},
 			}}},
-		}, {
-			name: "Clone for different version does not block creation of new clone",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "CLOSED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v2,
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "NEW"},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      severityCritical,
-					helpers.TargetVersionField: &v3,
-				},
-			}},
-			},
-			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
-			title:               "[v1] " + base.title,
-			cherrypick:          true,
-			cherryPickFromPRNum: 1,
-			options:             JiraBranchOptions{TargetVersion: &v1Str},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
-/retitle [v1] OCPBUGS-125: fixed it!
+		},
+		{
+			name:                  "valid jira removes invalid label, adds valid label, comments",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
 <details>
 
@@ -2841,112 +2110,115 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "CLOSED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
-				IssueLinks:  []*jira.IssueLink{&cloneOutward1, &blockInward1},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
-					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
-				},
-			}}},
-		}, {
-			name:    "Bug with non-allowed security level is ignored",
-			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
-			options: JiraBranchOptions{AllowedSecurityLevels: []string{"internal"}},
-			prs:     []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
-			// there should be no comment returned in this test case
-		}, {
-			name:           "Bug with security level on repo with no allowed security levels results in comment on /jira refresh",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
-			prs:            []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
-			refresh:        true,
-			body:           "/jira refresh",
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+		},
+		{
+			name:                  "reference to a project reachable in jira is treated as a jira issue in strict mode",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			options:               JiraBranchOptions{StrictProjectPrefixes: &yes},
+			expectedLabels:        []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
-<details><summary>No validations were run on this bug</summary></details>
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:                  "valid jira with incorrect version removes invalid label, adds valid label,comments",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Issue"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
+
+Warning: The referenced jira issue JIRA-123 has an invalid target version for the target branch this PR targets: expected the issue to target the "v1" version, but no target version was set.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira refresh
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "Bug matching previous bot comment still comments on /jira refresh with no changes",
-			prComments: map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+		},
+		{
+			name:                  "valid jira with disallowed type removes invalid label, adds valid label, comments with a warning",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Task"}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{AllowedIssueTypes: &[]string{"Story", "Bug"}},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
-<details><summary>No validations were run on this bug</summary></details>
+Warning: The referenced jira issue JIRA-123 has an invalid type for the target branch this PR targets: expected the issue to be of one of the following types: Story, Bug, but it is of type Task instead.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira refresh
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`, User: github.User{Login: fakegithub.Bot}}}},
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
-			prs:            []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
-			refresh:        true,
-			body:           "/jira refresh",
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+</details>`,
+		},
+		{
+			name:                  "valid story without an epic link comments with a warning when epic links are required",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{RequireEpicLink: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
-<details><summary>No validations were run on this bug</summary></details>
+Warning: The referenced jira issue JIRA-123 is not linked to an epic as required for the target branch this PR targets: expected the story to be linked to an epic, but no epic link was set.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira refresh
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name:    "Bug with non-allowed security level results in comment on /jira refresh",
-			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
-			prs:     []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
-			refresh: true,
-			body:    "/jira refresh",
-			options: JiraBranchOptions{AllowedSecurityLevels: []string{"internal"}},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in a security level that is not in the allowed security levels for this repo.
-Allowed security levels for this repo are:
-- internal
+		},
+		{
+			name:                  "valid story linked to an epic comments without a warning when epic links are required",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}, Unknowns: tcontainer.MarshalMap{helpers.EpicLinkField: "JIRA-1"}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{RequireEpicLink: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/jira refresh
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name:    "Bug with non-allowed security level results in comment on PR creation",
-			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
-			prs:     []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
-			opened:  true,
-			options: JiraBranchOptions{AllowedSecurityLevels: []string{"internal"}},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in a security level that is not in the allowed security levels for this repo.
-Allowed security levels for this repo are:
-- internal
+		},
+		{
+			name:                  "jira with story points is valid when story points are required",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}, Unknowns: tcontainer.MarshalMap{helpers.StoryPointsField: 3}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{RequireStoryPoints: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
 <details>
 
@@ -2957,18 +2229,17 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "Bug with allowed group is properly handled",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{
-				"security":            jiraclient.SecurityLevel{Name: "security"},
-				helpers.SeverityField: severityModerate,
-			}}}},
-			options:        JiraBranchOptions{StateAfterValidation: &updated, AllowedSecurityLevels: []string{"security"}},
-			labels:         []string{labels.JiraInvalidBug},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
-			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the UPDATED state.
+		},
+		{
+			name:                  "jira with zero story points is invalid when story points are required",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}, Unknowns: tcontainer.MarshalMap{helpers.StoryPointsField: 0}}}},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug},
+			options:               JiraBranchOptions{RequireStoryPoints: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123, which is invalid:
+ - expected the story to have story points, but its story points are zero
 
-<details><summary>No validations were run on this bug</summary></details>
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira issue are made, or edit the title of this pull request to link to a different issue.
 
 <details>
 
@@ -2979,106 +2250,36 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Unknowns: tcontainer.MarshalMap{
-					"security":            jiraclient.SecurityLevel{Name: "security"},
-					helpers.SeverityField: severityModerate,
-				}, Status: &jira.Status{Name: "UPDATED"},
-			}}},
-		}, {
-			name: "Bug with dependent bug not in OCPBUGS is invalid",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGSM-123", Fields: &jira.IssueFields{
-				Status:     &jira.Status{Name: "VERIFIED"},
-				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: &v2,
-				},
-			},
-			}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Project: jira.Project{Key: "OCPBUGS"},
-				Status:  &jira.Status{Name: "MODIFIED"},
-				IssueLinks: []*jira.IssueLink{{
-					Type: jira.IssueLinkType{
-						Name:    "Blocks",
-						Inward:  "is blocked by",
-						Outward: "blocks",
-					},
-					InwardIssue: &jira.Issue{ID: "1", Key: "OCPBUGSM-123"},
-				}},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: &v1,
-				},
-			}}},
-			overrideEvent: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "This PR fixes OCPBUGS-124", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
-			},
-			existingIssueLinks: []*jira.IssueLink{{
-				Type: jira.IssueLinkType{
-					Name:    "Blocks",
-					Inward:  "is blocked by",
-					Outward: "blocks",
-				},
-				InwardIssue:  &jira.Issue{ID: "1", Key: "OCPBUGSM-123"},
-				OutwardIssue: &jira.Issue{ID: "2", Key: "OCPBUGS-124"},
-			}},
-			options:        JiraBranchOptions{IsOpen: &yes, TargetVersion: &v1Str, DependentBugStates: &verified, DependentBugTargetVersions: &[]string{v2Str}},
-			labels:         []string{},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug},
-			expectedComment: `org/repo#2:@user: This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is invalid:
- - dependent bug OCPBUGSM-123 is not in the required ` + "`OCPBUGS`" + ` project
-
-Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+		},
+		{
+			name:                  "jira with zero story points is valid when zero story points are allowed",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}, Unknowns: tcontainer.MarshalMap{helpers.StoryPointsField: 0}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{RequireStoryPoints: &yes, AllowZeroStoryPoints: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
 <details>
 
-In response to [this](https://github.com/org/repo/pull/2):
+In response to [this](https://github.com/org/repo/pull/1):
 
->This PR fixes OCPBUGS-124
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-		}, {
-			name: "Backport with 4 version creates all issues and issue links and adds labels to parent",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "MODIFIED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: &v5,
-				},
-			}}},
-			backport:         true,
-			backportBranches: []string{"v1", "v2", "v3", "v4"},
-			options:          JiraBranchOptions{TargetVersion: &v5Str},
-			baseRef:          "v5",
-			fullConfig: Config{
-				Default: map[string]JiraBranchOptions{
-					"*":  {ValidateByDefault: &yes},
-					"v1": {TargetVersion: &v1zStr, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
-					"v2": {TargetVersion: &v2zStr, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
-					"v3": {TargetVersion: &v3zStr, DependentBugTargetVersions: &[]string{v4Str, v4zStr}},
-					"v4": {TargetVersion: &v4zStr, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
-					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
-				},
-			},
-			expectedComment: `org/repo#1:@user: The following backport issues have been created:
-- [OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) for branch v4
-- [OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125) for branch v3
-- [OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126) for branch v2
-- [OCPBUGS-127](https://my-jira.com/browse/OCPBUGS-127) for branch v1
+		},
+		{
+			name:                  "jira without story points is invalid when story points are required",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}}}},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug},
+			options:               JiraBranchOptions{RequireStoryPoints: &yes, AllowZeroStoryPoints: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123, which is invalid:
+ - expected the story to have story points, but no story points were set
 
-Queuing cherrypicks to the requested branches to be created after this PR merges:
-/cherrypick v1
-/cherrypick v2
-/cherrypick v3
-/cherrypick v4
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira issue are made, or edit the title of this pull request to link to a different issue.
 
 <details>
 
@@ -3089,115 +2290,15 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee:   &jira.User{Name: "testUser"},
-				Labels:     []string{"jlp-v1:OCPBUGS-127", "jlp-v2:OCPBUGS-126", "jlp-v3:OCPBUGS-125", "jlp-v4:OCPBUGS-124"},
-				Status:     &jira.Status{Name: "MODIFIED"},
-				IssueLinks: []*jira.IssueLink{&cloneInward2, &blockOutward2},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: []any{map[string]any{"name": v5Str}},
-				},
-			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
-				IssueLinks:  []*jira.IssueLink{&cloneOutward1, &blockInward1, &cloneInward3, &blockOutward3},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: []any{map[string]any{"name": v4zStr}},
-				},
-			}}, {ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
-				IssueLinks:  []*jira.IssueLink{&cloneOutward2, &blockInward2, &cloneInward4, &blockOutward4},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: []any{map[string]any{"name": v3zStr}},
-				},
-			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
-				IssueLinks:  []*jira.IssueLink{&cloneOutward3, &blockInward3, &cloneInward5, &blockOutward5},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: []any{map[string]any{"name": v2zStr}},
-				},
-			}}, {ID: "5", Key: "OCPBUGS-127", Fields: &jira.IssueFields{
-				Assignee:    &jira.User{Name: "testUser"},
-				Description: "This is a clone of issue OCPBUGS-126. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
-				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
-				IssueLinks:  []*jira.IssueLink{&cloneOutward4, &blockInward4},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: []any{map[string]any{"name": v1zStr}},
-				},
-			}},
-			},
-		}, {
-			name: "Backport with 4 versions missing one version in the config results in missingDependency error",
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Assignee: &jira.User{Name: "testUser"},
-				Status:   &jira.Status{Name: "MODIFIED"},
-				Comments: &jira.Comments{Comments: []*jira.Comment{{
-					Body: "This is a bug",
-				}}},
-				Project: jira.Project{
-					Name: "OCPBUGS",
-					Key:  "OCPBUGS",
-				},
-				Unknowns: tcontainer.MarshalMap{
-					helpers.TargetVersionField: &v5,
-				},
-			}}},
-			backport:         true,
-			backportBranches: []string{"v1", "v2", "v3", "v4"},
-			options:          JiraBranchOptions{TargetVersion: &v5Str},
-			baseRef:          "v5",
-			fullConfig: Config{
-				Default: map[string]JiraBranchOptions{
-					"*":  {ValidateByDefault: &yes},
-					"v1": {TargetVersion: &v1Str, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
-					"v2": {TargetVersion: &v2Str, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
-					"v4": {TargetVersion: &v4Str, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
-					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
-				},
-			},
-			expectedComment: `org/repo#1:@user: Missing required branches for backport chain:
-- branch with one of the following target versions: [` + "v3 v3z" + `]
-
+		},
+		{
+			name:                  "jira with an allowed non-bug type is valid",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}}}},
+			labels:                []string{labels.JiraInvalidBug},
+			expectedLabels:        []string{labels.JiraValidRef},
+			options:               JiraBranchOptions{NonBugIssueTypes: &[]string{"Story"}},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
 
 <details>
 
@@ -3210,132 +2311,106 @@ Instructions for interacting with me using PR comments are available [here](http
 </details>`,
 		},
 		{
-			name:     "verified comment results in verified label being added and bigquery data being uploaded",
-			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:     "/verified by @tester",
-			verified: []string{"@tester"},
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "@tester",
-				Type:   verifyMergeType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+			name:                  "jira with a disallowed non-bug type is invalid",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Task"}}}},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraInvalidBug},
+			options:               JiraBranchOptions{NonBugIssueTypes: &[]string{"Story"}},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123, which is invalid:
+ - expected the issue to be of one of the following types: Story, but it is of type Task instead
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira issue are made, or edit the title of this pull request to link to a different issue.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified by @tester
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:     "verified comment with multiple reasons results in verified label being added and multiple bigquery data being uploaded",
-			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:     "/verified by @tester,@tester2",
-			verified: []string{"@tester", "@tester2"},
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "@tester",
-				Type:   verifyMergeType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}, {
-				User:   "user",
-				Reason: "@tester2",
-				Type:   verifyMergeType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester,@tester2`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+			name:                  "bug is not restricted by the non-bug issue types",
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}},
+			issues:                []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Type: jira.IssueType{Name: "Bug"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			options:               JiraBranchOptions{NonBugIssueTypes: &[]string{"Story"}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified by @tester,@tester2
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:          "verified later comment results in verified later label being added and bigquery data being uploaded",
-			issues:        []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:          "/verified later @tester",
-			verifiedLater: []string{"@tester"},
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "@tester",
-				Type:   verifyLaterType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
+			name:   "valid bug comment lists the pull requests linked to the bug",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {
+				{ID: 1, Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/pull/1", Title: "org/repo#1: OCPBUGS-123: fixed it!"}},
+				{ID: 2, Object: &jira.RemoteLinkObject{URL: "https://github.com/org/other/pull/7", Title: "org/other#7: OCPBUGS-123: fixed it elsewhere!"}},
+				{ID: 3, Object: &jira.RemoteLinkObject{URL: "https://errata.example.com/advisory/42", Title: "RHBA-2024:42"}},
 			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
-			expectedComment: `org/repo#1:@user: This PR has been marked to be verified later by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will not be moved to the ` + "`VERIFIED`" + ` state on merge.
+			options:        JiraBranchOptions{ShowLinkedPRs: &yes},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+The following pull requests are linked to the bug via external trackers:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+ * [org/other#7](https://github.com/org/other/pull/7)
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified later @tester
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:           "verified later comment not referring to a user gives error",
+			name:           "valid bug gets a private comment linking the pull request",
 			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:           "/verified later my-test",
-			verifiedLater:  []string{"my-test"},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			options:        JiraBranchOptions{AddJiraCommentOnValidation: &yes},
 			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedComment: `org/repo#1:@user: Only users can be targets for the ` + "`/verified later`" + ` command.
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified later my-test
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{Body: "Linked to PR org/repo#1: https://github.com/org/repo/pull/1", Visibility: PrivateVisibility}}},
+			}}},
 		},
 		{
-			name:           "verified PR moves issue to VERIFIED on merge",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			merged:         true,
-			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
-			options:        JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
-
+			name: "valid bug is not commented on again when it already has a private comment linking the pull request",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{Body: "Linked to PR org/repo#1: https://github.com/org/repo/pull/1", Visibility: PrivateVisibility}}},
+			}}},
+			options:        JiraBranchOptions{AddJiraCommentOnValidation: &yes},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
 
-All linked pull requests have the ` + "`verified`" + ` tag. [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the ` + "`VERIFIED`" + ` state.
+<details><summary>No validations were run on this bug</summary></details>
 
 <details>
 
@@ -3346,221 +2421,130 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
-				Project:  jira.Project{Key: "OCPBUGS"},
-				Status:   &jira.Status{Name: "VERIFIED"},
-				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: struct{ Value string }{Value: `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`}},
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{Body: "Linked to PR org/repo#1: https://github.com/org/repo/pull/1", Visibility: PrivateVisibility}}},
 			}}},
 		},
 		{
-			name:           "verified comment without bigquery succeeds",
+			name:           "valid bug comment notes when no pull requests are linked to the bug",
 			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:           "/verified by @tester",
-			verified:       []string{"@tester"},
-			nilBigQuery:    true,
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+			options:        JiraBranchOptions{ShowLinkedPRs: &yes},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+No pull requests are linked to the bug via external trackers.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified by @tester
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:           "verified remove comment results in verified label being removed and bigquery data being uploaded",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:           "/verified remove",
-			verifiedRemove: true,
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "comment",
-				Type:   verifyRemoveType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedComment: `org/repo#1:@user: The ` + "`verified`" + ` label has been removed.
+			name:                  "valid jiras with several warnings list all warnings together in order",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}, {Project: "JIRA", ID: "124", IsBug: false}},
+			issues: []jira.Issue{
+				{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Task"}}},
+				{ID: "2", Key: "JIRA-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Type: jira.IssueType{Name: "Story"}}},
+			},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef},
+			options:        JiraBranchOptions{TargetVersion: &v1Str, AllowedIssueTypes: &[]string{"Story", "Bug"}},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
+
+This pull request references JIRA-124 which is a valid jira issue.
+
+Warnings:
+ * The referenced jira issue JIRA-123 has an invalid target version for the target branch this PR targets: expected the task to target the "v1" version, but no target version was set.
+ * The referenced jira issue JIRA-123 has an invalid type for the target branch this PR targets: expected the issue to be of one of the following types: Story, Bug, but it is of type Task instead.
+ * The referenced jira issue JIRA-124 has an invalid target version for the target branch this PR targets: expected the story to target the "v1" version, but no target version was set.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified remove
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:           "verified remove comment results in verified later label being removed and bigquery data being uploaded",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:           "/verified remove",
-			verifiedRemove: true,
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "comment",
-				Type:   verifyRemoveLaterType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedComment: `org/repo#1:@user: The ` + "`verified-later`" + ` label has been removed.
+			name: "valid bug and valid jira ref adds valid/severity labels and comments",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Post}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}},
+				{ID: "2", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "JIRA", ID: "123", IsBug: false}},
+			options:               JiraBranchOptions{IsOpen: &open},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>1 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)</details>
+
+This pull request references JIRA-123 which is a valid jira issue.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified remove
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:        "PR change results in verified label being removed and bigquery data being uploaded",
-			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			fileChanged: true,
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "modified",
-				Type:   verifyRemoveType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-		},
-		{
-			name:        "PR change results in verified-later label being removed and bigquery data being uploaded",
-			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			fileChanged: true,
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "modified",
-				Type:   verifyRemoveLaterType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-		},
-		{
-			name:     "verified comment with existing verified-later label results in verified label being added, verified-later label being removed, and bigquery data being uploaded",
-			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:     "/verified by @tester",
-			verified: []string{"@tester"},
-			verificationInfo: []VerificationInfo{{
-				User:   "user",
-				Reason: "@tester",
-				Type:   verifyMergeType,
-				Org:    "org",
-				Repo:   "repo",
-				PRNum:  1,
-				Branch: "branch",
-			}},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
-			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+			name:                  "invalid jira with status update removes valid label, comments",
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: false}},
+			labels:                []string{labels.JiraValidRef},
+			expectedLabels:        []string{},
+			expectedComment: `org/repo#1:@user: No Jira issue with key JIRA-123 exists in the tracker at https://my-jira.com.
+Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified by @tester
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:           "verified comment fails for non-collaborators",
-			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
-			body:           "/verified by @tester",
-			login:          "tester",
-			verified:       []string{"@tester"},
-			options:        JiraBranchOptions{}, // no requirements --> always valid
-			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
-			expectedComment: `org/repo#1:@tester: Jira verification commands are restricted to collaborators for this repo.
+			name:           "valid no-jira removes invalid label, adds valid label, comments",
+			noJira:         true,
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request explicitly references no jira issue.
 
 <details>
 
 In response to [this](https://github.com/org/repo/pull/1):
 
->/verified by @tester
+>This PR fixes OCPBUGS-123
 
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
 		},
 		{
-			name:   "valid bug on merged PR with many verified external links migrates to VERIFIED and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
-				ID: 1,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/1",
-					Title: "org/repo#1: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/22/commits/1234567890",
-					Title: "org/repo#22: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/23/files",
-					Title: "org/repo#23: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			},
-			}},
-			labels:         []string{"verified"},
-			expectedLabels: []string{"verified"},
-			prs:            []github.PullRequest{{Number: base.number, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 22, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 23, Merged: true, Labels: []github.Label{{Name: "verified"}}}},
-			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
- * [org/repo#22](https://github.com/org/repo/pull/22)
- * [org/repo#23](https://github.com/org/repo/pull/23)
-
-All linked pull requests have the ` + "`verified`" + ` tag. [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the ` + "`VERIFIED`" + ` state.
+			name:           "valid no-jira with no changes comments if there is no previous matching comment",
+			prComments:     map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: "Hello again", User: github.User{Login: fakegithub.Bot}}}},
+			noJira:         true,
+			labels:         []string{labels.JiraValidRef},
+			expectedLabels: []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request explicitly references no jira issue.
 
 <details>
 
@@ -3571,54 +2555,24 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "VERIFIED"}}}},
 		},
 		{
-			name:   "valid bug on merged PR with 2 verified and one verified-later external links migrates to MODIFIED and comments",
-			merged: true,
-			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
-			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
-				ID: 1,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/1",
-					Title: "org/repo#1: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/22/commits/1234567890",
-					Title: "org/repo#22: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			}, {
-				ID: 2,
-				Object: &jira.RemoteLinkObject{
-					URL:   "https://github.com/org/repo/pull/23/files",
-					Title: "org/repo#23: OCPBUGS-123: fixed it!",
-					Icon: &jira.RemoteLinkIcon{
-						Url16x16: "https://github.com/favicon.ico",
-						Title:    "GitHub",
-					},
-				},
-			},
-			}},
-			labels:         []string{"verified"},
-			expectedLabels: []string{"verified"},
-			prs:            []github.PullRequest{{Number: base.number, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 22, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 23, Merged: true, Labels: []github.Label{{Name: "verified-later"}}}},
-			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
-			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
- * [org/repo#1](https://github.com/org/repo/pull/1)
- * [org/repo#22](https://github.com/org/repo/pull/22)
- * [org/repo#23](https://github.com/org/repo/pull/23)
+			name: "valid no-jira with no changes comments if there if latest bot comment does not match",
+			prComments: map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: `org/repo#1:@user: This pull request explicitly references no jira issue.
 
-[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`, User: github.User{Login: fakegithub.Bot}}, {Body: "different comment", User: github.User{Login: fakegithub.Bot}}}},
+			noJira:         true,
+			labels:         []string{labels.JiraValidRef},
+			expectedLabels: []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request explicitly references no jira issue.
 
 <details>
 
@@ -3629,413 +2583,6797 @@ In response to [this](https://github.com/org/repo/pull/1):
 
 Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
 </details>`,
-			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
 		},
-	}
+		{
+			name: "valid no-jira with no changes does not comments if latest bot comment matches",
+			prComments: map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: `org/repo#1:@user: This pull request explicitly references no jira issue.
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			var ptrIssues []*jira.Issue
-			for index := range tc.issues {
-				ptrIssues = append(ptrIssues, &tc.issues[index])
-			}
-			jc := &fakejira.FakeClient{
-				Issues:           ptrIssues,
-				ExistingLinks:    tc.remoteLinks,
-				GetIssueError:    tc.issueGetErrors,
-				CreateIssueError: tc.issueCreateErrors,
-				UpdateIssueError: tc.issueUpdateErrors,
-				Transitions:      jiraTransitions,
-			}
-			jiraClient := fakeJiraClient{jc}
-			var testEvent event // copy so parallel tests don't collide
-			if tc.overrideEvent != nil {
-				testEvent = *tc.overrideEvent
-			} else {
-				testEvent = *base // copy so parallel tests don't collide
-			}
-			testEvent.refresh = tc.refresh
-			testEvent.backport = tc.backport
-			testEvent.backportBranches = tc.backportBranches
-			testEvent.missing = tc.missing
-			testEvent.merged = tc.merged
-			testEvent.closed = tc.closed || tc.merged
-			testEvent.opened = tc.opened
-			testEvent.verify = tc.verified
-			testEvent.verifyLater = tc.verifiedLater
-			testEvent.verifiedRemove = tc.verifiedRemove
-			testEvent.fileChanged = tc.fileChanged
-			if tc.login != "" {
-				testEvent.login = tc.login
-			}
-			if tc.replaceReferencedBugs != nil {
-				newEvent := testEvent
-				newEvent.issues = []referencedIssue{}
-				testEvent.issues = tc.replaceReferencedBugs
-			}
-			if tc.noJira {
-				testEvent.noJira = true
-				testEvent.issues = nil
-			}
-			testEvent.cherrypick = tc.cherrypick
-			testEvent.cherrypickFromPRNum = tc.cherryPickFromPRNum
-			if tc.body != "" {
-				testEvent.body = tc.body
-			}
-			if tc.title != "" {
-				testEvent.title = tc.title
-			}
-			if tc.baseRef != "" {
-				testEvent.baseRef = tc.baseRef
-			}
+<details>
 
-			gc := fakegithub.NewFakeClient()
-			gc.IssueLabelsExisting = []string{}
-			gc.IssueComments = map[int][]github.IssueComment{}
-			maps.Copy(gc.IssueComments, tc.prComments)
-			gc.PullRequests = map[int]*github.PullRequest{}
-			gc.WasLabelAddedByHumanVal = tc.humanLabelled
-			for _, label := range tc.labels {
-				gc.IssueLabelsExisting = append(gc.IssueLabelsExisting, fmt.Sprintf("%s/%s#%d:%s", testEvent.org, testEvent.repo, testEvent.number, label))
-			}
-			for _, pr := range tc.prs {
-				pr := pr
-				gc.PullRequests[pr.Number] = &pr
-			}
-			gc.Collaborators = []string{"user"}
-			// the test-infra fake github client does not implement a Query function; we don't test the query functionality here, so we can just wrap the test-infra
-			// client with a custom one that has an empty Query function
-			// TODO: implement a basic fake query function in test-infra fakegithub library and start unit testing the query path
-			fakeClient := fakeGHClient{gc}
-			// create separate inserter variable to test nil inserter case
-			var inserter BigQueryInserter
-			fakeInserter := fakeBigQueryInserter{}
-			if !tc.nilBigQuery {
-				inserter = &fakeInserter
-			}
-			if err := handle(&jiraClient, fakeClient, inserter, tc.fullConfig.OptionsForRepo("org", "repo"), tc.options, logrus.WithField("testCase", tc.name), testEvent, sets.New("org/repo")); err != nil {
-				t.Fatalf("handle failed: %v", err)
-			}
+In response to [this](https://github.com/org/repo/pull/1):
 
-			if diff := cmp.Diff(jiraClient.NewLinks, tc.expectedNewRemoteLinks); diff != "" {
-				t.Errorf("new links differs from expected new links: %s", diff)
-			}
+>This PR fixes OCPBUGS-123
 
-			if diff := cmp.Diff(jiraClient.RemovedLinks, tc.expectedRemovedRemoteLinks); diff != "" {
-				t.Errorf("removed links differs from expected removed links: %s", diff)
-			}
 
-			if diff := cmp.Diff(gc.IssueCommentsEdited, tc.expectedCommentUpdates); diff != "" {
-				t.Errorf("comment updates differ from expected: %s", diff)
-			}
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`, User: github.User{Login: fakegithub.Bot}}}},
+			noJira:         true,
+			labels:         []string{labels.JiraValidRef},
+			expectedLabels: []string{labels.JiraValidRef},
+		},
+		{
+			name:           "low severity bug is handled as a reference when validating by default requires a higher severity",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}}},
+			options:        JiraBranchOptions{ValidateByDefault: &yes, ValidateByDefaultMinimumSeverity: &minimumSeverity, StateAfterValidation: &JiraBugState{Status: "MODIFIED"}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request references OCPBUGS-123 which is a valid jira issue. Bugs with a severity below Important are not validated by default; comment <code>/jira refresh</code> to validate it.
 
-			checkComments(gc, tc.name, tc.expectedComment, t)
+<details>
 
-			expected := sets.NewString()
-			for _, label := range tc.expectedLabels {
-				expected.Insert(fmt.Sprintf("%s/%s#%d:%s", testEvent.org, testEvent.repo, testEvent.number, label))
-			}
+In response to [this](https://github.com/org/repo/pull/1):
 
-			actual := sets.NewString(gc.IssueLabelsExisting...)
-			actual.Insert(gc.IssueLabelsAdded...)
-			actual.Delete(gc.IssueLabelsRemoved...)
+>This PR fixes OCPBUGS-123
 
-			if missing := expected.Difference(actual); missing.Len() > 0 {
-				t.Errorf("%s: missing expected labels: %v", tc.name, missing.List())
-			}
-			if extra := actual.Difference(expected); extra.Len() > 0 {
-				t.Errorf("%s: unexpected labels: %v", tc.name, extra.List())
-			}
 
-			// reset errors on client for verification
-			jiraClient.CreateIssueError = nil
-			jiraClient.GetIssueError = nil
-			for _, expectedIssue := range tc.expectedIssues {
-				actual, err := jiraClient.GetIssue(expectedIssue.ID)
-				if err != nil {
-					t.Errorf("%s: failed to get expected bug during test: %v", tc.name, err)
-				}
-				if !reflect.DeepEqual(actual, expectedIssue) {
-					t.Errorf("%s: got incorrect bug after update: %s", tc.name, cmp.Diff(actual, expectedIssue, allowEventAndDate))
-				}
-			}
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "critical severity bug is validated when validating by default requires a lower severity",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{ValidateByDefault: &yes, ValidateByDefaultMinimumSeverity: &minimumSeverity, StateAfterValidation: &JiraBugState{Status: "MODIFIED"}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the MODIFIED state.
 
-			if !tc.nilBigQuery {
-				if len(tc.verificationInfo) != len(fakeInserter.insertedData) {
-					t.Errorf("%s: length of verification info does not match: %d != %d", tc.name, len(fakeInserter.insertedData), len(tc.verificationInfo))
-				} else {
-					for index, verificationInfo := range tc.verificationInfo {
-						if !reflect.DeepEqual(fakeInserter.insertedData[index], verificationInfo) {
-							t.Errorf("%s: Got incorrect verification info: %s", tc.name, cmp.Diff(fakeInserter.insertedData[index], verificationInfo, allowEventAndDate))
-						}
-					}
-				}
-			}
-		})
-	}
-}
+<details><summary>No validations were run on this bug</summary></details>
 
-func checkComments(client *fakegithub.FakeClient, name, expectedComment string, t *testing.T) {
-	wantedComments := 0
-	if expectedComment != "" {
-		wantedComments = 1
-	}
-	if len(client.IssueCommentsAdded) != wantedComments {
-		t.Errorf("%s: wanted %d comment, got %d: %v", name, wantedComments, len(client.IssueCommentsAdded), client.IssueCommentsAdded)
-	}
+<details>
 
-	if expectedComment != "" && len(client.IssueCommentsAdded) == 1 {
-		if expectedComment != client.IssueCommentsAdded[0] {
-			t.Errorf("%s: got incorrect comment: %v", name, cmp.Diff(expectedComment, client.IssueCommentsAdded[0]))
-		}
-	}
-}
+In response to [this](https://github.com/org/repo/pull/1):
 
-func TestInsertLinksIntoComment(t *testing.T) {
-	t.Parallel()
-	const issueName = "ABC-123"
-	testCases := []struct {
-		name     string
-		body     string
-		expected string
-	}{
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
 		{
-			name: "Multiline body starting with issue name",
-			body: `ABC-123: Fix problems:
-* First problem
-* Second problem`,
-			expected: `[ABC-123](https://my-jira.com/browse/ABC-123): Fix problems:
-* First problem
-* Second problem`,
+			name:           "low severity bug is validated on refresh when validating by default requires a higher severity",
+			body:           "/jira refresh",
+			refresh:        true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}}},
+			options:        JiraBranchOptions{ValidateByDefault: &yes, ValidateByDefaultMinimumSeverity: &minimumSeverity, StateAfterValidation: &JiraBugState{Status: "MODIFIED"}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityLow},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the MODIFIED state.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
 		},
 		{
-			name: "Multiline body starting with already replaced issue name",
-			body: `[ABC-123](https://my-jira.com/browse/ABC-123): Fix problems:
-* First problem
-* Second problem`,
-			expected: `[ABC-123](https://my-jira.com/browse/ABC-123): Fix problems:
-* First problem
-* Second problem`,
+			name:           "valid bug with status update removes invalid label, adds valid label, comments and updates status with resolution",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &JiraBugState{Status: "CLOSED", Resolution: "VALIDATED"}}, // no requirements --> always valid
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityLow},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the CLOSED (VALIDATED) state.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status: &jira.Status{
+					Name: "CLOSED",
+				},
+				Resolution: &jira.Resolution{
+					Name: "VALIDATED",
+				},
+				// due to the way `Unknowns` works, this has to be provided as a map[string]interface{}
+				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: map[string]any{"Value": string(`<img alt="" src="/images/icons/priorities/low.svg" width="16" height="16"> Low`)}},
+			}}},
 		},
 		{
-			name: "Multiline body with multiple occurrence in the middle",
-			body: `This change:
-* Does stuff related to ABC-123
-* And even more stuff related to ABC-123
-* But also something else`,
-			expected: `This change:
-* Does stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
-* And even more stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
-* But also something else`,
+			name:           "valid bug with status update summarizes the changed fields when configured",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &JiraBugState{Status: "CLOSED", Resolution: "VALIDATED"}, CommentFieldChanges: &yes},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityLow},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the CLOSED (VALIDATED) state.
+
+Fields changed on OCPBUGS-123:
+ * Status: NEW → CLOSED
+ * Resolution: (none) → VALIDATED
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
 		},
 		{
-			name: "Multiline body with multiple occurrence in the middle, some already replaced",
-			body: `This change:
-* Does stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
-* And even more stuff related to ABC-123
-* But also something else`,
-			expected: `This change:
-* Does stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
-* And even more stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
-* But also something else`,
+			name:           "valid bug with status update removes invalid label, adds valid label, comments and does not update status when it is already correct",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "UPDATED"}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated}, // no requirements --> always valid
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "UPDATED"}}}},
 		},
 		{
-			name: "Multiline body with issue name at the end",
-			body: `This change:
-is very important
-because of ABC-123`,
-			expected: `This change:
-is very important
-because of [ABC-123](https://my-jira.com/browse/ABC-123)`,
+			name:           "valid bug with external link removes invalid label, adds valid label, comments, makes an external bug link",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			options:        JiraBranchOptions{AddExternalLink: &yes}, // no requirements --> always valid
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+The bug has been updated to refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			expectedNewRemoteLinks: []jira.RemoteLink{{Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			},
+			}},
 		},
 		{
-			name: "Multiline body with already replaced issue name at the end",
-			body: `This change:
-is very important
-because of [ABC-123](https://my-jira.com/browse/ABC-123)`,
-			expected: `This change:
-is very important
-because of [ABC-123](https://my-jira.com/browse/ABC-123)`,
+			name:   "valid bug with already existing external link removes invalid label, adds valid label, comments to say nothing changed",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options:        JiraBranchOptions{AddExternalLink: &yes}, // no requirements --> always valid
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
 		},
 		{
-			name:     "Pasted links are not replaced, as they are already clickable",
-			body:     "https://my-jira.com/browse/ABC-123",
-			expected: "https://my-jira.com/browse/ABC-123",
+			name:    "refresh on valid bug with an external link with a stale title updates the link title and comments",
+			body:    "/jira refresh",
+			refresh: true,
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			remoteLinks: map[string][]jira.RemoteLink{"1": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: an old title",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options:        JiraBranchOptions{AddExternalLink: &yes}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+The title of the external bug tracker link on the bug has been updated to match the title of this pull request.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			expectedNewRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			},
+			}},
+		},
+		{
+			name:           "valid bug with external link icon makes an external bug link with the configured icon",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			options:        JiraBranchOptions{AddExternalLink: &yes, RemoteLinkIcon: &linkIcon},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+The bug has been updated to refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			expectedNewRemoteLinks: []jira.RemoteLink{{Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://gitlab.com/favicon.ico",
+					Title:    "GitLab",
+				},
+			},
+			}},
+		},
+		{
+			name:   "valid bug with external link icon and already existing external link with a different icon comments to say nothing changed",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options:        JiraBranchOptions{AddExternalLink: &yes, RemoteLinkIcon: &linkIcon},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+		},
+		{
+			name:           "reopened PR restores the external bug link removed on close and moves the bug back",
+			reopened:       true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+			options:        JiraBranchOptions{AddExternalLink: &yes, StateAfterValidation: &updated, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the UPDATED state.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+The bug has been updated to refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "UPDATED"}}}},
+			expectedNewRemoteLinks: []jira.RemoteLink{{Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			},
+			}},
+		},
+		{
+			name:           "valid bug with external link title template makes an external bug link with the rendered title",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			options:        JiraBranchOptions{AddExternalLink: &yes, RemoteLinkTitleTemplate: &linkTitleTemplate},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+The bug has been updated to refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			expectedNewRemoteLinks: []jira.RemoteLink{{Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "repo PR 1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			},
+			}},
+		},
+		{
+			name:   "valid bug with external link title template and already existing rendered external link comments to say nothing changed",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "repo PR 1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options:        JiraBranchOptions{AddExternalLink: &yes, RemoteLinkTitleTemplate: &linkTitleTemplate},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+		},
+		{
+			name: "failure to fetch dependent bug results in a comment",
+			issues: []jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
+			}}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "This PR fixes OCPBUGS-124", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			},
+			existingIssueLinks: []*jira.IssueLink{&cloneBetween123to124, &blocksBetween123to124},
+			issueGetErrors:     map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
+			options:            JiraBranchOptions{DependentBugStates: &verified},
+			expectedComment: `org/repo#2:@user: An error was encountered searching for dependent bug OCPBUGS-123 for bug OCPBUGS-124 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+
+<details><summary>Full error message.</summary>
+
+<code>
+injected error getting bug
+</code>
+
+</details>
+
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/2):
+
+>This PR fixes OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "valid bug with dependent bugs removes invalid label, adds valid label, comments",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:    jira.Project{Key: "OCPBUGS"},
+				Status:     &jira.Status{Name: "VERIFIED"},
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v2,
+				},
+			},
+			}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Project:    jira.Project{Key: "OCPBUGS"},
+				Status:     &jira.Status{Name: "MODIFIED"},
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v1,
+				},
+			}}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "This PR fixes OCPBUGS-124", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			},
+			existingIssueLinks: []*jira.IssueLink{&cloneBetween123to124, &blocksBetween123to124},
+			options:            JiraBranchOptions{IsOpen: &yes, TargetVersion: &v1Str, DependentBugStates: &verified, DependentBugTargetVersions: &[]string{v2Str}},
+			labels:             []string{labels.JiraInvalidBug},
+			expectedLabels:     []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#2:@user: This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>5 validation(s) were run on this bug</summary>
+
+* bug is open, matching expected state (open)
+* bug target version (v1) matches configured target version for branch (v1)
+* dependent bug [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in the state VERIFIED, which is one of the valid states (VERIFIED)
+* dependent [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) targets the "v2" version, which is one of the valid target versions: v2.*, openshift-v2.*
+* bug has dependents</details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/2):
+
+>This PR fixes OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "valid bug is not moved to the state after validation when transitions are disabled",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated, DisableTransitions: &yes},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+		},
+		{
+			name:   "valid bug on merged PR is not moved to the state after merge when transitions are disabled",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}, DisableTransitions: &yes},
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+		},
+		{
+			name:   "closed PR removes the external link but does not change the bug state when transitions are disabled",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, DisableTransitions: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}},
+		},
+		{
+			name:   "valid bug on merged PR records the time spent in the previous state when configured",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}, Changelog: &jira.Changelog{Histories: []jira.ChangelogHistory{
+				{Id: "1", Created: "2024-01-10T09:00:00.000+0000", Items: []jira.ChangelogItems{{Field: "status", FromString: "NEW", ToString: "POST"}}},
+				{Id: "2", Created: "2024-01-15T09:00:00.000+0000", Items: []jira.ChangelogItems{{Field: "assignee", ToString: "user"}, {Field: "status", FromString: "POST", ToString: "MODIFIED"}}},
+			}}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED"}, RecordTimeInState: &yes},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedTimeInState: []TimeInState{{Org: "org", Repo: "repo", PRNum: 1, Branch: "branch", Issue: "OCPBUGS-123", State: "MODIFIED", NewState: "CLOSED", EnteredState: enteredModified}},
+		},
+		{
+			name:   "valid bug on merged PR summarizes the changed fields when configured",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}, CommentFieldChanges: &yes},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED (MERGED) state.
+
+Fields changed on OCPBUGS-123:
+ * Status: MODIFIED → CLOSED
+ * Resolution: (none) → MERGED
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:   "valid bug on merged PR with one external link migrates to new state with resolution and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED (MERGED) state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:    jira.Project{Key: "OCPBUGS"},
+				Status:     &jira.Status{Name: "CLOSED"},
+				Resolution: &jira.Resolution{Name: "MERGED"},
+				Unknowns:   tcontainer.MarshalMap{},
+			}}},
+		},
+		{
+			name:     "finalize on merged PR moves bug still in MODIFIED to the state after merge",
+			body:     "/jira finalize",
+			finalize: true,
+			merged:   true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options: JiraBranchOptions{ValidStates: &[]JiraBugState{{Status: "MODIFIED"}}, StateAfterMerge: &JiraBugState{Status: "CLOSED"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira finalize
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "CLOSED"},
+			}}},
+		},
+		{
+			name:     "finalize on merged PR with bug already in the state after merge has nothing to finalize",
+			body:     "/jira finalize",
+			finalize: true,
+			merged:   true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "CLOSED"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			options: JiraBranchOptions{ValidStates: &[]JiraBugState{{Status: "MODIFIED"}}, StateAfterMerge: &JiraBugState{Status: "CLOSED"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is already in the CLOSED state, so there is nothing to finalize.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira finalize
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "CLOSED"},
+			}}},
+		},
+		{
+			name:     "finalize on unmerged PR does not move the bug",
+			body:     "/jira finalize",
+			finalize: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+			options: JiraBranchOptions{ValidStates: &[]JiraBugState{{Status: "MODIFIED"}}, StateAfterMerge: &JiraBugState{Status: "CLOSED"}},
+			expectedComment: `org/repo#1:@user: This pull request has not merged yet. Bugs are moved to their state after merge once it merges; ` + "`/jira finalize`" + ` is only needed if that did not happen.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira finalize
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+			}}},
+		},
+		{
+			name:   "valid premerge bug on merged PR with one external link migrates to new state with resolution and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Status:          &jira.Status{Name: "MODIFIED"},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			labels:         []string{labels.QEApproved},
+			expectedLabels: []string{labels.QEApproved},
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}, PreMergeStateAfterMerge: &JiraBugState{Status: "UPDATED2", Resolution: "MERGED2"}}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the UPDATED2 (MERGED2) state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:         jira.Project{Key: "OCPBUGS"},
+				Status:          &jira.Status{Name: "UPDATED2"},
+				Resolution:      &jira.Resolution{Name: "MERGED2"},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+				Unknowns:        tcontainer.MarshalMap{},
+			}}},
+		},
+		{
+			name:   "2 valid bugs on merged PR with one external link migrates to new state with resolution and comments",
+			merged: true,
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Modified}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: status.Modified}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}}}, "OCPBUGS-124": {{ID: 2, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}}},
+			},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED (MERGED) state.
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been moved to the CLOSED (MERGED) state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:    jira.Project{Key: "OCPBUGS"},
+				Status:     &jira.Status{Name: "CLOSED"},
+				Resolution: &jira.Resolution{Name: "MERGED"},
+				Unknowns:   tcontainer.MarshalMap{},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Project:    jira.Project{Key: "OCPBUGS"},
+				Status:     &jira.Status{Name: "CLOSED"},
+				Resolution: &jira.Resolution{Name: "MERGED"},
+				Unknowns:   tcontainer.MarshalMap{},
+			}}},
+		},
+		{
+			name:   "valid bug on merged PR with one external link migrates to new state and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
+		},
+		{
+			name:   "valid bug on merged PR with many external links migrates to new state and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
+				ID: 1,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/1",
+					Title: "org/repo#1: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/22/commits/1234567890",
+					Title: "org/repo#22: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/23/files",
+					Title: "org/repo#23: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}, {Number: 22, Merged: true}, {Number: 23, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+ * [org/repo#22](https://github.com/org/repo/pull/22)
+ * [org/repo#23](https://github.com/org/repo/pull/23)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
+		},
+		{
+			name:   "valid bug on merged PR with unmerged external links does nothing",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
+				ID: 1,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/1",
+					Title: "org/repo#1: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/22",
+					Title: "org/repo#22: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			},
+			}},
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}, {Number: 22, Merged: false, State: "open"}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): Some pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+The following pull requests linked via external trackers have not merged:
+ * [org/repo#22](https://github.com/org/repo/pull/22) is open
+
+These pull request must merge or be unlinked from the Jira bug in order for it to move to the next state. Once unlinked, request a bug refresh with <code>/jira refresh</code>.
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has not been moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:   "2 valid bugs on merged PR, one with unmerged external links and one with merged external links",
+			merged: true,
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: status.Modified}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Status: &jira.Status{Name: status.Modified}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
+				ID: 1, Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/1",
+					Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/22",
+					Title: "org/repo#22: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			},
+			}, "OCPBUGS-124": {{ID: 2, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}}},
+			},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}, {Number: 22, Merged: false, State: "open"}},
+			options: JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): Some pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+The following pull requests linked via external trackers have not merged:
+ * [org/repo#22](https://github.com/org/repo/pull/22) is open
+
+These pull request must merge or be unlinked from the Jira bug in order for it to move to the next state. Once unlinked, request a bug refresh with <code>/jira refresh</code>.
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has not been moved to the CLOSED (MERGED) state.
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been moved to the CLOSED (MERGED) state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "MODIFIED"},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Status:     &jira.Status{Name: "CLOSED"},
+				Resolution: &jira.Resolution{Name: "MERGED"},
+				Unknowns:   tcontainer.MarshalMap{},
+			}}},
+		},
+		{
+			name:   "External bug on rep that is not in our config is ignored, bug gets set to MODIFIED",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/unreferenced/repo/pull/22",
+				Title: "unreferenced/repo#22: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:            []github.PullRequest{{Number: 22, Merged: false, State: "open"}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:   "valid bug on merged PR with one external link but no status after merge configured does nothing",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+		},
+		{
+			name:    "valid bug on merged PR with one external link but no referenced bug in the title does nothing",
+			merged:  true,
+			missing: true,
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+		},
+		{
+			name:           "valid bug on merged PR with one external link fails to update bug and comments",
+			merged:         true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+			issueGetErrors: map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: An error was encountered searching for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+
+<details><summary>Full error message.</summary>
+
+<code>
+injected error getting bug
+</code>
+
+</details>
+
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123"}},
+		},
+		{
+			name:   "valid bug on merged PR with merged external links but unknown status does not migrate to new state and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: true}},
+			options: JiraBranchOptions{StateAfterValidation: &updated, StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in an unrecognized state (CLOSED) and will not be moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+		},
+		{
+			name:   "closed PR removes link and comments",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			},
+		},
+		{
+			name:   "closed PR without a link does nothing",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes},
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+		},
+		{
+			name:   "closed PR removes link, changes bug state, and comments",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Status: &jira.Status{Name: "POST"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "NEW"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}, {
+					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
+					Visibility: PrivateVisibility,
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			},
+		},
+		{
+			name:   "closed PR summarizes the changed fields when configured",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, CommentFieldChanges: &yes},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+
+Fields changed on OCPBUGS-123:
+ * Status: POST → NEW
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}},
+		},
+		{
+			name:   "closed PR of recently updated bug within the close grace period does not change bug state",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:  &jira.Status{Name: "POST"},
+				Updated: recentlyUpdated,
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, CloseGracePeriod: &metav1.Duration{Duration: time.Hour}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has not been moved to the NEW state as it was updated within the last 1h0m0s.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:  &jira.Status{Name: "POST"},
+				Updated: recentlyUpdated,
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body:       "Bug status not changed to NEW after previous linked PR https://github.com/org/repo/pull/1 was closed, as the bug was updated within the last 1h0m0s",
+					Visibility: PrivateVisibility,
+				}}},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}},
+		},
+		{
+			name:   "closed PR of bug updated before the close grace period changes bug state",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:  &jira.Status{Name: "POST"},
+				Updated: updatedLongAgo,
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, CloseGracePeriod: &metav1.Duration{Duration: time.Hour}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:  &jira.Status{Name: "NEW"},
+				Updated: updatedLongAgo,
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
+					Visibility: PrivateVisibility,
+				}}},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}},
+		},
+		{
+			name:   "closed PR restricts the bug status comment to the configured visibility",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, PrivateCommentVisibility: &JiraCommentVisibility{Type: "role", Value: "Developers"}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "NEW"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
+					Visibility: jira.CommentVisibility{Type: "role", Value: "Developers"},
+				}}},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}},
+		},
+		{
+			name:   "closed PR of premerge bug removes link, changes bug state, and comments",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Status: &jira.Status{Name: "POST"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			labels:         []string{labels.QEApproved},
+			expectedLabels: []string{labels.QEApproved},
+			prs:            []github.PullRequest{{Number: base.number, Merged: false}},
+			options:        JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}, PreMergeStateAfterClose: &JiraBugState{Status: "NEW2"}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW2 state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "NEW2"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}, {
+					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
+					Visibility: PrivateVisibility,
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+				FixVersions:     []*jira.FixVersion{{Name: "premerge"}},
+				AffectsVersions: []*jira.AffectsVersion{{Name: "premerge"}},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			},
+		},
+		{
+			name:                  "closed PR for multiple bugs removes links, changes bug states, and comments",
+			merged:                false,
+			closed:                true,
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Status: &jira.Status{Name: "POST"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is also a bug",
+				}}},
+				Status: &jira.Status{Name: "POST"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}, "OCPBUGS-124": {{ID: 2, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). The bug has been updated to no longer refer to the pull request using the external bug tracker. All external bug links have been closed. The bug has been moved to the NEW state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "NEW"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}, {
+					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
+					Visibility: PrivateVisibility,
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "NEW"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is also a bug",
+				}, {
+					Body:       "Bug status changed to NEW as previous linked PR https://github.com/org/repo/pull/1 has been closed",
+					Visibility: PrivateVisibility,
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}}, {ID: 2, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123,OCPBUGS-124: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}}},
+		},
+		{
+			name:    "closed PR with missing bug does nothing",
+			merged:  false,
+			closed:  true,
+			missing: true,
+			issues:  []jira.Issue{},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
+		},
+		{
+			name:   "closed PR with multiple external links removes link, does not change bug state, and comments",
+			merged: false,
+			closed: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
+				ID: 1,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/1",
+					Title: "org/repo#1: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/issues/42",
+					Title: "org/repo#42: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				}},
+			}},
+			prs:     []github.PullRequest{{Number: base.number, Merged: false}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). The bug has been updated to no longer refer to the pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField: severityCritical,
+				},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}},
+			},
+		},
+		{
+			name: "Cherrypick PR results in cloned bug creation",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels: []string{"good_label", "bad_label_1", "bad_label_2"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabels: []string{"bad_label_2", "bad_label_1"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels:     []string{"good_label"},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR adds a label referencing the clone when configured",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels: []string{"good_label", "bad_label_1", "bad_label_2"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabels: []string{"bad_label_2", "bad_label_1"}, AddCloneLabel: &yes},
+			expectedLabels:      []string{labels.ClonePrefix + "OCPBUGS-124"},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels:     []string{"good_label"},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR assigns the clone to the configured default when the parent has no assignee",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, CloneDefaultAssignee: &cloneAssignee},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: cloneAssignee},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR uses the configured retitle command",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels: []string{"good_label", "bad_label_1", "bad_label_2"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabels: []string{"bad_label_2", "bad_label_1"}, RetitleCommand: &titleCommand},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/title [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels:     []string{"good_label"},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR asks for a manual retitle when the retitle command is disabled",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels: []string{"good_label", "bad_label_1", "bad_label_2"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabels: []string{"bad_label_2", "bad_label_1"}, RetitleCommand: &noRetitleCommand},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+Please retitle the pull request to: [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels:     []string{"good_label"},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR drops labels matching ignored prefixes from the clone",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels: []string{"sprint-12", "good_label", "sprint-13"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabelPrefixes: []string{"sprint-"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels:     []string{"good_label"},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR drops both exact and prefix ignored labels from the clone",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels: []string{"sprint-12", "good_label", "bad_label_1", "team-a", "other_label"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, IgnoreCloneLabels: []string{"bad_label_1"}, IgnoreCloneLabelPrefixes: []string{"sprint-", "team-"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Labels:     []string{"good_label", "other_label"},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR copies configured custom fields onto the clone",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+					"customfield_12310243":     float64(5),
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str, CloneCopyFields: []string{"customfield_12310243", "customfield_00000000"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+					"customfield_12310243":     float64(5),
+				},
+			}}},
+		},
+		{
+
+			name: "Cherrypick PR with Sprint field results in cloned bug creation",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+					helpers.SprintField:        []any{active1, closed1},
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Assignee:    &jira.User{Name: "testUser"},
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+					helpers.SprintField:        float64(57955),
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR for multiple bugs results in multiple cloned bug creation",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			prs:                   []github.PullRequest{{Number: base.number, Body: base.body, Title: "OCPBUGS-123,OCPBUGS-124: fixed it!"}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!"}},
+			title:                 "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!",
+			cherrypick:            true,
+			cherryPickFromPRNum:   1,
+			options:               JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-125,OCPBUGS-126: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{{
+					Type: jira.IssueLinkType{
+						Name:    "Cloners",
+						Inward:  "is cloned by",
+						Outward: "clones",
+					},
+					OutwardIssue: &jira.Issue{ID: "2"},
+				}, {
+					Type: jira.IssueLinkType{
+						Name:    "Blocks",
+						Inward:  "is blocked by",
+						Outward: "blocks",
+					},
+					InwardIssue: &jira.Issue{ID: "2"},
+				}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick PR for multiple bugs with 1 failure still comments for both bugs",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			issueUpdateErrors:     map[string]error{"OCPBUGS-125": errors.New("injected error updating bug OCPBUGS-125")},
+			prs:                   []github.PullRequest{{Number: base.number, Body: base.body, Title: "OCPBUGS-123,OCPBUGS-124: fixed it!"}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!"}},
+			title:                 "[v1] OCPBUGS-123,OCPBUGS-124: fixed it!",
+			cherrypick:            true,
+			cherryPickFromPRNum:   1,
+			options:               JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+
+WARNING: Failed to update the target version, assignee, and sprint for the clone. Please update these fields manually. Full error below:
+<details><summary>Full error message.</summary>
+
+<code>
+injected error updating bug OCPBUGS-125
+</code>
+
+</details>
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-125,OCPBUGS-126: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "defaultAssignee"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v2Str}},
+				},
+			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{{
+					Type: jira.IssueLinkType{
+						Name:    "Cloners",
+						Inward:  "is cloned by",
+						Outward: "clones",
+					},
+					OutwardIssue: &jira.Issue{ID: "2"},
+				}, {
+					Type: jira.IssueLinkType{
+						Name:    "Blocks",
+						Inward:  "is blocked by",
+						Outward: "blocks",
+					},
+					InwardIssue: &jira.Issue{ID: "2"},
+				}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick comment results in cloned bug creation",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs: []github.PullRequest{{Number: 2, Body: "This is a manually created cherrypick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira cherrypick OCPBUGS-123", title: "fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", cherrypick: true, cherrypickCmd: true, missing: true,
+			},
+			cherrypick: true,
+			missing:    true,
+			options:    JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#2:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira cherrypick OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick comment for multiple bugs results in multiple cloned bug creation",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			prs:                   []github.PullRequest{{Number: 2, Body: "This is a manually created cherrypick of #1.\n\n/assign user", Title: "[v1] fixing stuff"}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira cherrypick OCPBUGS-123,OCPBUGS-124", title: "fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", cherrypick: true, cherrypickCmd: true, missing: true,
+			},
+			cherrypick: true,
+			missing:    true,
+			options:    JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#2:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+
+[Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
+/retitle OCPBUGS-125,OCPBUGS-126: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira cherrypick OCPBUGS-123,OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{{
+					Type: jira.IssueLinkType{
+						Name:    "Cloners",
+						Inward:  "is cloned by",
+						Outward: "clones",
+					},
+					OutwardIssue: &jira.Issue{ID: "2"},
+				}, {
+					Type: jira.IssueLinkType{
+						Name:    "Blocks",
+						Inward:  "is blocked by",
+						Outward: "blocks",
+					},
+					InwardIssue: &jira.Issue{ID: "2"},
+				}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "Cherrypick comment for multiple bugs results in cloned bug creation and comment about non-bug issue",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OTHER", ID: "124", IsBug: false}},
+			prs:                   []github.PullRequest{{Number: 2, Body: "This is a manually created cherrypick of #1.\n\n/assign user", Title: "[v1] fixing stuff"}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OTHER", ID: "124", IsBug: false}}, body: "/jira cherrypick OCPBUGS-123,OTHER-124", title: "fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", cherrypick: true, cherrypickCmd: true, missing: true,
+			},
+			cherrypick: true,
+			missing:    true,
+			options:    JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#2:@user: Ignoring requests to cherry-pick non-bug issues: OTHER-124
+ [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+/retitle OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira cherrypick OCPBUGS-123,OTHER-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		},
+		{
+			name: "parent PR of cherrypick not existing results in error",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: Error creating a cherry-pick bug in Jira: failed to check the state of cherrypicked pull request at https://github.com/org/repo/pull/1: pull request number 1 does not exist.
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "failure to obtain parent bug for cherrypick results in error",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			issueGetErrors:      map[string]error{"OCPBUGS-123": errors.New("injected error getting bug")},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: An error was encountered searching for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+
+<details><summary>Full error message.</summary>
+
+<code>
+injected error getting bug
+</code>
+
+</details>
+
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "failure to update bug retitles the PR and prints a warning in the comment",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			issueUpdateErrors:   map[string]error{"OCPBUGS-124": errors.New("injected error updating bug OCPBUGS-124")},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124). Will retitle bug to link to clone.
+
+WARNING: Failed to update the target version, assignee, and sprint for the clone. Please update these fields manually. Full error below:
+<details><summary>Full error message.</summary>
+
+<code>
+injected error updating bug OCPBUGS-124
+</code>
+
+</details>
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "If bug clone with correct target version already exists, just retitle PR",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
+				Status:     &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
+				Status:     &jira.Status{Name: "NEW"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v1,
+				},
+			}},
+			},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: Detected clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) with correct target version. Will retitle the PR to link to the clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "If bug clone with correct target version already is listed as an issue label, just retitle PR",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
+				Labels:     []string{"random-label", "random-label-2", "jlp-v2:OCPBUGS-125", "jlp-v1:OCPBUGS-124"},
+				Status:     &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			baseRef:             "v1",
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: Detected clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) with correct target version. Will retitle the PR to link to the clone.
+/retitle [v1] OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "If clone with correct target version already exists in multibug PR, retitle PR for correct clone and create clone for other bug",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee:   &jira.User{Name: "testUser"},
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
+				Status:     &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee:   &jira.User{Name: "testUser"},
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123},
+				Status:     &jira.Status{Name: "NEW"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v1,
+				},
+			}}, {ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v1,
+				},
+			}},
+			},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: "OCPBUGS-123,OCPBUGS-125: fixed it!"}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] OCPBUGS-123,OCPBUGS-125: fixed it!"}},
+			title:               "[v1] OCPBUGS-123,OCPBUGS-125: fixed it!",
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: Detected clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) with correct target version. Will retitle the PR to link to the clone.
+
+[Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125) has been cloned as [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-124,OCPBUGS-126: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				IssueLinks: []*jira.IssueLink{{
+					Type: jira.IssueLinkType{
+						Name:    "Cloners",
+						Inward:  "is cloned by",
+						Outward: "clones",
+					},
+					OutwardIssue: &jira.Issue{ID: "3"},
+				}, {
+					Type: jira.IssueLinkType{
+						Name:    "Blocks",
+						Inward:  "is blocked by",
+						Outward: "blocks",
+					},
+					InwardIssue: &jira.Issue{ID: "3"},
+				}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		}, {
+			name: "Clone for different version does not block creation of new clone",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "CLOSED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v2,
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "NEW"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      severityCritical,
+					helpers.TargetVersionField: &v3,
+				},
+			}},
+			},
+			prs:                 []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}, {Number: 2, Body: "This is an automated cherry-pick of #1.\n\n/assign user", Title: "[v1] " + base.title}},
+			title:               "[v1] " + base.title,
+			cherrypick:          true,
+			cherryPickFromPRNum: 1,
+			options:             JiraBranchOptions{TargetVersion: &v1Str},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been cloned as [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125). Will retitle bug to link to clone.
+/retitle [v1] OCPBUGS-125: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "CLOSED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward1, &blockInward1},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.SeverityField:      map[string]any{"Value": `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`},
+					helpers.TargetVersionField: []any{map[string]any{"name": v1Str}},
+				},
+			}}},
+		}, {
+			name:    "Bug with non-allowed security level is ignored",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
+			options: JiraBranchOptions{AllowedSecurityLevels: []string{"internal"}},
+			prs:     []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
+			// there should be no comment returned in this test case
+		}, {
+			name:           "Bug with security level on repo with no allowed security levels results in comment on /jira refresh",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
+			prs:            []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
+			refresh:        true,
+			body:           "/jira refresh",
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "Bug matching previous bot comment still comments on /jira refresh with no changes",
+			prComments: map[int][]github.IssueComment{1: {{Body: "Hello", User: github.User{Login: "alex"}}, {Body: `@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`, User: github.User{Login: fakegithub.Bot}}}},
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
+			prs:            []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
+			refresh:        true,
+			body:           "/jira refresh",
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name:    "Bug with non-allowed security level results in comment on /jira refresh",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
+			prs:     []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
+			refresh: true,
+			body:    "/jira refresh",
+			options: JiraBranchOptions{AllowedSecurityLevels: []string{"internal"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in a security level that is not in the allowed security levels for this repo.
+Allowed security levels for this repo are:
+- internal
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name:    "Bug with non-allowed security level results in comment on PR creation",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{"security": jiraclient.SecurityLevel{Name: "security"}}}}},
+			prs:     []github.PullRequest{{Number: base.number, Body: base.body, Title: base.title}},
+			opened:  true,
+			options: JiraBranchOptions{AllowedSecurityLevels: []string{"internal"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is in a security level that is not in the allowed security levels for this repo.
+Allowed security levels for this repo are:
+- internal
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "Bug with allowed group is properly handled",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{
+				"security":            jiraclient.SecurityLevel{Name: "security"},
+				helpers.SeverityField: severityModerate,
+			}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated, AllowedSecurityLevels: []string{"security"}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid. The bug has been moved to the UPDATED state.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Unknowns: tcontainer.MarshalMap{
+					"security":            jiraclient.SecurityLevel{Name: "security"},
+					helpers.SeverityField: severityModerate,
+				}, Status: &jira.Status{Name: "UPDATED"},
+			}}},
+		}, {
+			name: "Bug with dependent bug not in OCPBUGS is invalid",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGSM-123", Fields: &jira.IssueFields{
+				Status:     &jira.Status{Name: "VERIFIED"},
+				IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v2,
+				},
+			},
+			}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Status:  &jira.Status{Name: "MODIFIED"},
+				IssueLinks: []*jira.IssueLink{{
+					Type: jira.IssueLinkType{
+						Name:    "Blocks",
+						Inward:  "is blocked by",
+						Outward: "blocks",
+					},
+					InwardIssue: &jira.Issue{ID: "1", Key: "OCPBUGSM-123"},
+				}},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v1,
+				},
+			}}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "This PR fixes OCPBUGS-124", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			},
+			existingIssueLinks: []*jira.IssueLink{{
+				Type: jira.IssueLinkType{
+					Name:    "Blocks",
+					Inward:  "is blocked by",
+					Outward: "blocks",
+				},
+				InwardIssue:  &jira.Issue{ID: "1", Key: "OCPBUGSM-123"},
+				OutwardIssue: &jira.Issue{ID: "2", Key: "OCPBUGS-124"},
+			}},
+			options:        JiraBranchOptions{IsOpen: &yes, TargetVersion: &v1Str, DependentBugStates: &verified, DependentBugTargetVersions: &[]string{v2Str}},
+			labels:         []string{},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug},
+			expectedComment: `org/repo#2:@user: This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is invalid:
+ - dependent bug OCPBUGSM-123 is not in the required ` + "`OCPBUGS`" + ` project
+
+Comment <code>/jira refresh</code> to re-evaluate validity if changes to the Jira bug are made, or edit the title of this pull request to link to a different bug.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/2):
+
+>This PR fixes OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		}, {
+			name: "Backport with 4 version creates all issues and issue links and adds labels to parent",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "MODIFIED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v5,
+				},
+			}}},
+			backport:         true,
+			backportBranches: []string{"v1", "v2", "v3", "v4"},
+			options:          JiraBranchOptions{TargetVersion: &v5Str},
+			baseRef:          "v5",
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":  {ValidateByDefault: &yes},
+					"v1": {TargetVersion: &v1zStr, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
+					"v2": {TargetVersion: &v2zStr, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
+					"v3": {TargetVersion: &v3zStr, DependentBugTargetVersions: &[]string{v4Str, v4zStr}},
+					"v4": {TargetVersion: &v4zStr, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
+					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
+				},
+			},
+			expectedComment: `org/repo#1:@user: The following backport issues have been created:
+- [OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) for branch v4
+- [OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125) for branch v3
+- [OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126) for branch v2
+- [OCPBUGS-127](https://my-jira.com/browse/OCPBUGS-127) for branch v1
+
+Queuing cherrypicks to the requested branches to be created after this PR merges:
+/cherrypick v1
+/cherrypick v2
+/cherrypick v3
+/cherrypick v4
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee:   &jira.User{Name: "testUser"},
+				Labels:     []string{"jlp-v1:OCPBUGS-127", "jlp-v2:OCPBUGS-126", "jlp-v3:OCPBUGS-125", "jlp-v4:OCPBUGS-124"},
+				Status:     &jira.Status{Name: "MODIFIED"},
+				IssueLinks: []*jira.IssueLink{&cloneInward2, &blockOutward2},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v5Str}},
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward1, &blockInward1, &cloneInward3, &blockOutward3},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v4zStr}},
+				},
+			}}, {ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward2, &blockInward2, &cloneInward4, &blockOutward4},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v3zStr}},
+				},
+			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward3, &blockInward3, &cloneInward5, &blockOutward5},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v2zStr}},
+				},
+			}}, {ID: "5", Key: "OCPBUGS-127", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-126. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward4, &blockInward4},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v1zStr}},
+				},
+			}},
+			},
+		}, {
+			name: "Backport to target versions resolves them to branches and creates all issues",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "MODIFIED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v5,
+				},
+			}}},
+			backport:         true,
+			backportBranches: []string{"v1z", "v2z", "v3z", "v4z"},
+			options:          JiraBranchOptions{TargetVersion: &v5Str},
+			baseRef:          "v5",
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":  {ValidateByDefault: &yes},
+					"v1": {TargetVersion: &v1zStr, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
+					"v2": {TargetVersion: &v2zStr, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
+					"v3": {TargetVersion: &v3zStr, DependentBugTargetVersions: &[]string{v4Str, v4zStr}},
+					"v4": {TargetVersion: &v4zStr, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
+					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
+				},
+			},
+			expectedComment: `org/repo#1:@user: The following backport issues have been created:
+- [OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) for branch v4
+- [OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125) for branch v3
+- [OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126) for branch v2
+- [OCPBUGS-127](https://my-jira.com/browse/OCPBUGS-127) for branch v1
+
+Queuing cherrypicks to the requested branches to be created after this PR merges:
+/cherrypick v1
+/cherrypick v2
+/cherrypick v3
+/cherrypick v4
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee:   &jira.User{Name: "testUser"},
+				Labels:     []string{"jlp-v1:OCPBUGS-127", "jlp-v2:OCPBUGS-126", "jlp-v3:OCPBUGS-125", "jlp-v4:OCPBUGS-124"},
+				Status:     &jira.Status{Name: "MODIFIED"},
+				IssueLinks: []*jira.IssueLink{&cloneInward2, &blockOutward2},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v5Str}},
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward1, &blockInward1, &cloneInward3, &blockOutward3},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v4zStr}},
+				},
+			}}, {ID: "3", Key: "OCPBUGS-125", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward2, &blockInward2, &cloneInward4, &blockOutward4},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v3zStr}},
+				},
+			}}, {ID: "4", Key: "OCPBUGS-126", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward3, &blockInward3, &cloneInward5, &blockOutward5},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v2zStr}},
+				},
+			}}, {ID: "5", Key: "OCPBUGS-127", Fields: &jira.IssueFields{
+				Assignee:    &jira.User{Name: "testUser"},
+				Description: "This is a clone of issue OCPBUGS-126. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-125. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-124. The following is the description of the original issue: \n---\nThis is a clone of issue OCPBUGS-123. The following is the description of the original issue: \n---\n",
+				Status:      &jira.Status{Name: "MODIFIED"}, // during a clone on a real jira server, this field would get unset/reset; the fake client copies
+				IssueLinks:  []*jira.IssueLink{&cloneOutward4, &blockInward4},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: []any{map[string]any{"name": v1zStr}},
+				},
+			}},
+			},
+		}, {
+			name: "Backport with 4 versions missing one version in the config results in missingDependency error",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Assignee: &jira.User{Name: "testUser"},
+				Status:   &jira.Status{Name: "MODIFIED"},
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "This is a bug",
+				}}},
+				Project: jira.Project{
+					Name: "OCPBUGS",
+					Key:  "OCPBUGS",
+				},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v5,
+				},
+			}}},
+			backport:         true,
+			backportBranches: []string{"v1", "v2", "v3", "v4"},
+			options:          JiraBranchOptions{TargetVersion: &v5Str},
+			baseRef:          "v5",
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":  {ValidateByDefault: &yes},
+					"v1": {TargetVersion: &v1Str, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
+					"v2": {TargetVersion: &v2Str, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
+					"v4": {TargetVersion: &v4Str, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
+					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
+				},
+			},
+			expectedComment: `org/repo#1:@user: Missing required branches for backport chain:
+- branch with one of the following target versions: [` + "v3 v3z" + `]
+
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "Backport check with a complete chain lists the issues that would be created without creating them",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:  &jira.Status{Name: "MODIFIED"},
+				Project: jira.Project{Name: "OCPBUGS", Key: "OCPBUGS"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v5,
+				},
+			}}},
+			backportCheck:    true,
+			backportBranches: []string{"v1", "v2", "v3", "v4"},
+			options:          JiraBranchOptions{TargetVersion: &v5Str},
+			baseRef:          "v5",
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":  {ValidateByDefault: &yes},
+					"v1": {TargetVersion: &v1zStr, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
+					"v2": {TargetVersion: &v2zStr, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
+					"v3": {TargetVersion: &v3zStr, DependentBugTargetVersions: &[]string{v4Str, v4zStr}},
+					"v4": {TargetVersion: &v4zStr, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
+					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
+				},
+			},
+			expectedComment: `org/repo#1:@user: The backport chain is complete. Running ` + "`/jira backport`" + ` would create the following issues for each of OCPBUGS-123:
+- a clone for branch v1, blocking the issue for branch v2
+- a clone for branch v2, blocking the issue for branch v3
+- a clone for branch v3, blocking the issue for branch v4
+- a clone for branch v4, blocking the issue for branch v5
+
+No issues have been created.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "Backport check with an incomplete chain lists the missing branches",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:  &jira.Status{Name: "MODIFIED"},
+				Project: jira.Project{Name: "OCPBUGS", Key: "OCPBUGS"},
+				Unknowns: tcontainer.MarshalMap{
+					helpers.TargetVersionField: &v5,
+				},
+			}}},
+			backportCheck:    true,
+			backportBranches: []string{"v1", "v2", "v3", "v4"},
+			options:          JiraBranchOptions{TargetVersion: &v5Str},
+			baseRef:          "v5",
+			fullConfig: Config{
+				Default: map[string]JiraBranchOptions{
+					"*":  {ValidateByDefault: &yes},
+					"v1": {TargetVersion: &v1Str, DependentBugTargetVersions: &[]string{v2Str, v2zStr}},
+					"v2": {TargetVersion: &v2Str, DependentBugTargetVersions: &[]string{v3Str, v3zStr}},
+					"v4": {TargetVersion: &v4Str, DependentBugTargetVersions: &[]string{v5Str, v5zStr}},
+					"v5": {TargetVersion: &v5Str, DependentBugTargetVersions: nil},
+				},
+			},
+			expectedComment: `org/repo#1:@user: Missing required branches for backport chain:
+- branch with one of the following target versions: [` + "v3 v3z" + `]
+
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:     "verified comment results in verified label being added and bigquery data being uploaded",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:     "/verified by @tester",
+			verified: []string{"@tester"},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "@tester",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:     "verified comment with multiple reasons results in verified label being added and multiple bigquery data being uploaded",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:     "/verified by @tester,@tester2",
+			verified: []string{"@tester", "@tester2"},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "@tester",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}, {
+				User:   "user",
+				Reason: "@tester2",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester,@tester2`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester,@tester2
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:     "verified comment with a free-form reason results in verified label being added and the reason being uploaded verbatim",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:     "/verified by ran the e2e suite on a 4.16 cluster",
+			verified: []string{"ran the e2e suite on a 4.16 cluster"},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "ran the e2e suite on a 4.16 cluster",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`ran the e2e suite on a 4.16 cluster`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by ran the e2e suite on a 4.16 cluster
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:          "verified later comment results in verified later label being added and bigquery data being uploaded",
+			issues:        []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:          "/verified later @tester",
+			verifiedLater: []string{"@tester"},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "@tester",
+				Type:   verifyLaterType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
+			expectedComment: `org/repo#1:@user: This PR has been marked to be verified later by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will not be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified later @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "verified later comment not referring to a user gives error",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:           "/verified later my-test",
+			verifiedLater:  []string{"my-test"},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: Only users can be targets for the ` + "`/verified later`" + ` command.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified later my-test
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "verified PR moves issue to VERIFIED on merge",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			merged:         true,
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+
+
+All linked pull requests have the ` + "`verified`" + ` tag. [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the ` + "`VERIFIED`" + ` state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "OCPBUGS"},
+				Status:   &jira.Status{Name: "VERIFIED"},
+				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: struct{ Value string }{Value: `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`}},
+			}}},
+		},
+		{
+			name:     "verified comment results in the configured verified label being added",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:     "/verified by @tester",
+			verified: []string{"@tester"},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "@tester",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{VerifiedLabel: &customVerified},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, customVerified},
+			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "PR with the configured verified label moves issue to VERIFIED on merge",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			merged:         true,
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED", Resolution: "MERGED"}, VerifiedLabel: &customVerified},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, customVerified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, customVerified},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+
+
+All linked pull requests have the ` + "`qe-approved`" + ` tag. [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the ` + "`VERIFIED`" + ` state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "OCPBUGS"},
+				Status:   &jira.Status{Name: "VERIFIED"},
+				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: struct{ Value string }{Value: `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`}},
+			}}},
+		},
+		{
+			name:           "verified PR without approval stays in MODIFIED on merge when approval is required",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			merged:         true,
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified, RequireApprovalForVerified: &yes},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified, labels.LGTM},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified, labels.LGTM},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "OCPBUGS"},
+				Status:   &jira.Status{Name: "MODIFIED"},
+				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: struct{ Value string }{Value: `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`}},
+			}}},
+		},
+		{
+			name:           "verified and approved PR moves issue to VERIFIED on merge when approval is required",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			merged:         true,
+			prs:            []github.PullRequest{{Number: base.number, Merged: true}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified, RequireApprovalForVerified: &yes},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified, labels.LGTM, labels.Approved},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified, labels.LGTM, labels.Approved},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+
+
+All linked pull requests have the ` + "`verified`" + ` tag. [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the ` + "`VERIFIED`" + ` state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "OCPBUGS"},
+				Status:   &jira.Status{Name: "VERIFIED"},
+				Unknowns: tcontainer.MarshalMap{helpers.SeverityField: struct{ Value string }{Value: `<img alt="" src="/images/icons/priorities/critical.svg" width="16" height="16"> Critical`}},
+			}}},
+		},
+		{
+			name:           "verified comment without bigquery succeeds",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:           "/verified by @tester",
+			verified:       []string{"@tester"},
+			nilBigQuery:    true,
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "verified remove comment results in verified label being removed and bigquery data being uploaded",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:           "/verified remove",
+			verifiedRemove: true,
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "comment",
+				Type:   verifyRemoveType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: The ` + "`verified`" + ` label has been removed.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified remove
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "verified remove comment results in verified later label being removed and bigquery data being uploaded",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:           "/verified remove",
+			verifiedRemove: true,
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "comment",
+				Type:   verifyRemoveLaterType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: The ` + "`verified-later`" + ` label has been removed.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified remove
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:        "PR change results in verified label being removed and bigquery data being uploaded",
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			fileChanged: true,
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "modified",
+				Type:   verifyRemoveType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+		},
+		{
+			name:           "PR change to only ignored paths retains verified label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			fileChanged:    true,
+			prChanges:      []github.PullRequestChange{{Filename: "docs/README.md"}, {Filename: "CHANGELOG.md"}},
+			options:        JiraBranchOptions{VerifiedResetIgnorePaths: []string{"docs/*", "*.md"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+		},
+		{
+			name:        "PR push to only ignored paths retains verified label even when the PR changes other paths",
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			fileChanged: true,
+			beforeSHA:   "abc",
+			afterSHA:    "def",
+			prChanges:   []github.PullRequestChange{{Filename: "pkg/server.go"}, {Filename: "docs/README.md"}},
+			prCommits: []github.RepositoryCommit{
+				{SHA: "abc", Files: []github.CommitFile{{Filename: "pkg/server.go"}}},
+				{SHA: "def", Files: []github.CommitFile{{Filename: "docs/README.md"}}},
+			},
+			options:        JiraBranchOptions{VerifiedResetIgnorePaths: []string{"docs/*", "*.md"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+		},
+		{
+			name:        "PR push to a path that is not ignored results in verified label being removed",
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			fileChanged: true,
+			beforeSHA:   "abc",
+			afterSHA:    "ghi",
+			prChanges:   []github.PullRequestChange{{Filename: "pkg/server.go"}, {Filename: "docs/README.md"}},
+			prCommits: []github.RepositoryCommit{
+				{SHA: "abc", Files: []github.CommitFile{{Filename: "docs/README.md"}}},
+				{SHA: "def", Files: []github.CommitFile{{Filename: "pkg/server.go"}}},
+				{SHA: "ghi", Files: []github.CommitFile{{Filename: "docs/README.md"}}},
+			},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "modified",
+				Type:   verifyRemoveType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{VerifiedResetIgnorePaths: []string{"docs/*", "*.md"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+		},
+		{
+			name:        "PR change to a path that is not ignored results in verified label being removed",
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			fileChanged: true,
+			prChanges:   []github.PullRequestChange{{Filename: "docs/README.md"}, {Filename: "pkg/server.go"}},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "modified",
+				Type:   verifyRemoveType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{VerifiedResetIgnorePaths: []string{"docs/*", "*.md"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+		},
+		{
+			name:        "PR change results in verified-later label being removed and bigquery data being uploaded",
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			fileChanged: true,
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "modified",
+				Type:   verifyRemoveLaterType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+		},
+		{
+			name:     "verified comment with existing verified-later label results in verified label being added, verified-later label being removed, and bigquery data being uploaded",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:     "/verified by @tester",
+			verified: []string{"@tester"},
+			verificationInfo: []VerificationInfo{{
+				User:   "user",
+				Reason: "@tester",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.VerifiedLater},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@user: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "verified comment fails for non-collaborators",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:           "/verified by @tester",
+			login:          "tester",
+			verified:       []string{"@tester"},
+			options:        JiraBranchOptions{}, // no requirements --> always valid
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@tester: Jira verification commands are restricted to collaborators for this repo.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:     "verified comment succeeds for non-collaborator in verified command users",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:     "/verified by @tester",
+			login:    "tester",
+			verified: []string{"@tester"},
+			verificationInfo: []VerificationInfo{{
+				User:   "tester",
+				Reason: "@tester",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{VerifiedCommandUsers: []string{"Tester"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@tester: This PR has been marked as verified by ` + "`@tester`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:                  "reference parsed as a non-bug is validated as a bug when the fetched issue belongs to a bug project",
+			issues:                []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: false}},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:                  "reference parsed as a bug is handled as a non-bug when the fetched issue belongs to another project",
+			issues:                []jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			replaceReferencedBugs: []referencedIssue{{Project: "JIRA", ID: "123", IsBug: true}},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef},
+			expectedComment: `org/repo#1:@user: This pull request references JIRA-123 which is a valid jira issue.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:             "approving review from QE reviewer marks PR as verified",
+			issues:           []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:             "Tested on a 4.16 cluster",
+			login:            "qe-user",
+			verified:         []string{"@qe-user"},
+			verifiedByReview: true,
+			verificationInfo: []VerificationInfo{{
+				User:   "qe-user",
+				Reason: "@qe-user",
+				Type:   verifyMergeType,
+				Org:    "org",
+				Repo:   "repo",
+				PRNum:  1,
+				Branch: "branch",
+			}},
+			options:        JiraBranchOptions{QEReviewers: []string{"qe-user"}, VerifiedCommandUsers: []string{"tester"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical, labels.Verified},
+			expectedComment: `org/repo#1:@qe-user: This PR has been marked as verified by ` + "`@qe-user`" + `. Jira issue(s) in the title of this PR will be moved to the ` + "`VERIFIED`" + ` state on merge.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>Tested on a 4.16 cluster
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "verified comment fails for collaborator not in verified command users",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			body:           "/verified by @tester",
+			verified:       []string{"@tester"},
+			options:        JiraBranchOptions{VerifiedCommandUsers: []string{"tester", "tester2"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: Jira verification commands are restricted to collaborators for this repo. For this branch, that is limited to the following users: tester, tester2.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/verified by @tester
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:   "valid bug on merged PR with many verified external links migrates to VERIFIED and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
+				ID: 1,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/1",
+					Title: "org/repo#1: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/22/commits/1234567890",
+					Title: "org/repo#22: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/23/files",
+					Title: "org/repo#23: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			},
+			}},
+			labels:         []string{"verified"},
+			expectedLabels: []string{"verified"},
+			prs:            []github.PullRequest{{Number: base.number, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 22, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 23, Merged: true, Labels: []github.Label{{Name: "verified"}}}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+ * [org/repo#22](https://github.com/org/repo/pull/22)
+ * [org/repo#23](https://github.com/org/repo/pull/23)
+
+All linked pull requests have the ` + "`verified`" + ` tag. [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the ` + "`VERIFIED`" + ` state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "VERIFIED"}}}},
+		},
+		{
+			name:   "valid bug on merged PR with 2 verified and one verified-later external links migrates to MODIFIED and comments",
+			merged: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{
+				ID: 1,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/1",
+					Title: "org/repo#1: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/22/commits/1234567890",
+					Title: "org/repo#22: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			}, {
+				ID: 2,
+				Object: &jira.RemoteLinkObject{
+					URL:   "https://github.com/org/repo/pull/23/files",
+					Title: "org/repo#23: OCPBUGS-123: fixed it!",
+					Icon: &jira.RemoteLinkIcon{
+						Url16x16: "https://github.com/favicon.ico",
+						Title:    "GitHub",
+					},
+				},
+			},
+			}},
+			labels:         []string{"verified"},
+			expectedLabels: []string{"verified"},
+			prs:            []github.PullRequest{{Number: base.number, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 22, Merged: true, Labels: []github.Label{{Name: "verified"}}}, {Number: 23, Merged: true, Labels: []github.Label{{Name: "verified-later"}}}},
+			options:        JiraBranchOptions{StateAfterMerge: &modified}, // no requirements --> always valid
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+ * [org/repo#22](https://github.com/org/repo/pull/22)
+ * [org/repo#23](https://github.com/org/repo/pull/23)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the MODIFIED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "MODIFIED"}}}},
+		},
+		{
+			name:     "set-priority command updates the priority of referenced bugs",
+			body:     "/jira set-priority critical",
+			priority: "critical",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Priority: &jira.Priority{Name: "Normal"}}}},
+			expectedComment: `org/repo#1:@user: The priority of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been set to Critical.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-priority critical
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Priority: &jira.Priority{Name: "Critical"}, Unknowns: tcontainer.MarshalMap{}}}},
+		},
+		{
+			name:     "set-priority command with unknown priority comments with valid priorities",
+			body:     "/jira set-priority urgent",
+			priority: "urgent",
+			issues:   []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Priority: &jira.Priority{Name: "Normal"}}}},
+			expectedComment: `org/repo#1:@user: "urgent" is not a valid Jira priority. Valid priorities are: Blocker, Critical, Major, Normal, Minor, Undefined
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-priority urgent
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Priority: &jira.Priority{Name: "Normal"}}}},
+		},
+		{
+			name:          "set-version command updates the target version of referenced bugs",
+			body:          "/jira set-version v1",
+			targetVersion: "v1",
+			issues:        []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &v2}}}},
+			expectedComment: `org/repo#1:@user: The target version of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been set to v1.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-version v1
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: []any{map[string]any{"name": v1Str}}}}}},
+		},
+		{
+			name:          "set-version command with the current target version does not update the bug",
+			body:          "/jira set-version v1",
+			targetVersion: "v1",
+			issues:        []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &v1}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) already targets version v1.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-version v1
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &v1}}}},
+		},
+		{
+			name:          "set-version command fails for non-collaborators",
+			body:          "/jira set-version v1",
+			login:         "tester",
+			targetVersion: "v1",
+			issues:        []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &v2}}}},
+			expectedComment: `org/repo#1:@tester: The ` + "`/jira set-version`" + ` command is restricted to collaborators for this repo.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-version v1
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &v2}}}},
+		},
+		{
+			name:       "set-fixversion command replaces the fix versions of referenced bugs",
+			body:       "/jira set-fixversion v1",
+			fixVersion: "v1",
+			issues:     []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v2"}, {Name: "v3"}}}}},
+			expectedComment: `org/repo#1:@user: The fix version of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been set to v1.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-fixversion v1
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v1"}}, Unknowns: tcontainer.MarshalMap{}}}},
+		},
+		{
+			name:       "set-fixversion command with the current fix version does not update the bug",
+			body:       "/jira set-fixversion v1",
+			fixVersion: "v1",
+			issues:     []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v1"}}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) already has fix version v1.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-fixversion v1
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v1"}}}}},
+		},
+		{
+			name:       "set-fixversion command fails for non-collaborators",
+			body:       "/jira set-fixversion v1",
+			login:      "tester",
+			fixVersion: "v1",
+			issues:     []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v2"}}}}},
+			expectedComment: `org/repo#1:@tester: The ` + "`/jira set-fixversion`" + ` command is restricted to collaborators for this repo.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira set-fixversion v1
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v2"}}}}},
+		},
+		{
+			name:   "move command transitions the referenced bug to the requested state",
+			body:   "/jira move verified",
+			move:   &JiraBugState{Status: "verified"},
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "ON_QA"}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the verified state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira move verified
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "VERIFIED"}}}},
+		},
+		{
+			name:   "move command with a resolution transitions the referenced bug and sets the resolution",
+			body:   "/jira move CLOSED:Done",
+			move:   &JiraBugState{Status: "CLOSED", Resolution: "Done"},
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "VERIFIED"}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED (Done) state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira move CLOSED:Done
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "CLOSED"}, Resolution: &jira.Resolution{Name: "Done"}, Unknowns: tcontainer.MarshalMap{}}}},
+		},
+		{
+			name:   "move command to a state without an available transition does not update the bug",
+			body:   "/jira move ON_QA",
+			move:   &JiraBugState{Status: "ON_QA"},
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) cannot be moved to the ON_QA state from the NEW state. Available states are: NEW, MODIFIED, UPDATED, VERIFIED, CLOSED, UPDATED2, NEW2
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira move ON_QA
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}}},
+		},
+		{
+			name:   "move command fails for non-collaborators",
+			body:   "/jira move VERIFIED",
+			login:  "tester",
+			move:   &JiraBugState{Status: "VERIFIED"},
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "ON_QA"}}}},
+			expectedComment: `org/repo#1:@tester: The ` + "`/jira move`" + ` command is restricted to collaborators for this repo.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira move VERIFIED
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "ON_QA"}}}},
+		},
+		{
+			name:      "status command reports the state of the referenced bug",
+			body:      "/jira status",
+			bugStatus: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:     &jira.Status{Name: "ON_QA"},
+				Resolution: &jira.Resolution{Name: "Done"},
+				Assignee:   &jira.User{DisplayName: "Jane Doe"},
+				Unknowns:   tcontainer.MarshalMap{helpers.TargetVersionField: &v1},
+			}}},
+			expectedComment: `org/repo#1:@user: | Bug | Status | Resolution | Target Version | Assignee |
+| --- | --- | --- | --- | --- |
+| [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) | ON_QA | Done | v1 | Jane Doe |
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira status
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status:     &jira.Status{Name: "ON_QA"},
+				Resolution: &jira.Resolution{Name: "Done"},
+				Assignee:   &jira.User{DisplayName: "Jane Doe"},
+				Unknowns:   tcontainer.MarshalMap{helpers.TargetVersionField: &v1},
+			}}},
+		},
+		{
+			name:      "status command reports the state of multiple referenced bugs",
+			body:      "/jira status",
+			bugStatus: true,
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "POST"}, Assignee: &jira.User{DisplayName: "Jane Doe"}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			expectedComment: `org/repo#1:@user: | Bug | Status | Resolution | Target Version | Assignee |
+| --- | --- | --- | --- | --- |
+| [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) | POST | unset | unset | Jane Doe |
+| [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) | NEW | unset | unset | unassigned |
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira status
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:      "status command for a missing bug leaves a comment",
+			body:      "/jira status",
+			bugStatus: true,
+			expectedComment: `org/repo#1:@user: No Jira issue with key OCPBUGS-123 exists in the tracker at https://my-jira.com.
+Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira status
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "dry-run mode does not update the bug, labels, remote links, or comment",
+			dryRun:         true,
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}, Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{StateAfterValidation: &updated, AddExternalLink: &yes},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraInvalidBug},
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Status: &jira.Status{Name: "NEW"}, Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+		},
+		{
+			name:           "valid bug with a comment template uses the template for the comment",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{CommentTemplates: map[string]string{"valid": "[{{.Key}}]({{.URL}}) is good to go."}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityCritical},
+			expectedComment: `org/repo#1:@user: [OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) is good to go.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "invalid bug with a comment template uses the template for the comment",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{IsOpen: &open, CommentTemplates: map[string]string{"invalid": "{{.Key}} needs work:{{range .Validations}}\n* {{.}}{{end}}"}},
+			labels:         []string{labels.JiraValidBug, labels.SeverityCritical},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug, labels.SeverityImportant},
+			expectedComment: `org/repo#1:@user: OCPBUGS-123 needs work:
+* expected the bug to be open, but it isn't
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:        "comment command adds a public comment to the referenced issues",
+			body:        "/jira comment Verified on the nightly build.",
+			jiraComment: "Verified on the nightly build.",
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			expectedComment: `org/repo#1:@user: Added comment to [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123).
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira comment Verified on the nightly build.
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body: "user commented on https://github.com/org/repo/pull/1:\n\nVerified on the nightly build.",
+				}}},
+			}}},
+		},
+		{
+			name:        "comment command adds a private comment to the referenced issues when configured",
+			body:        "/jira comment Verified on the nightly build.",
+			jiraComment: "Verified on the nightly build.",
+			options:     JiraBranchOptions{PrivateComments: &yes},
+			issues:      []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{}}},
+			expectedComment: `org/repo#1:@user: Added comment to [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123).
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira comment Verified on the nightly build.
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Comments: &jira.Comments{Comments: []*jira.Comment{{
+					Body:       "user commented on https://github.com/org/repo/pull/1:\n\nVerified on the nightly build.",
+					Visibility: PrivateVisibility,
+				}}},
+			}}},
+		},
+		{
+			name:           "valid bug with a configured severity label adds the configured label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityCritical}}}},
+			options:        JiraBranchOptions{SeverityLabels: map[string]string{"Critical": "severity/p0"}},
+			labels:         []string{labels.JiraInvalidBug},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, "severity/p0"},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "valid bug with a configured severity label replaces the default severity label",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}}},
+			options:        JiraBranchOptions{SeverityLabels: map[string]string{"Important": "severity/p1"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityImportant},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, "severity/p1"},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "valid bug with a lower severity replaces the configured label of the previous severity",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityModerate}}}},
+			options:        JiraBranchOptions{SeverityLabels: map[string]string{"Critical": "severity/p0"}},
+			labels:         []string{labels.JiraValidRef, labels.JiraValidBug, "severity/p0"},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug, labels.SeverityModerate},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "multiple bugs with configured severity labels add the label of the highest severity",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{SeverityLabels: map[string]string{"Important": "severity/p1", "Low": "severity/p3"}},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, "severity/p1"},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "multiple bugs with a configured severity order add the label of the severity ranked highest in that order",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityLow}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Unknowns: tcontainer.MarshalMap{helpers.SeverityField: severityImportant}}},
+			},
+			replaceReferencedBugs: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}},
+			options:               JiraBranchOptions{SeverityLabels: map[string]string{"Important": "severity/p1", "Low": "severity/p3"}, SeverityOrder: []string{"Low", "Important"}},
+			labels:                []string{},
+			expectedLabels:        []string{labels.JiraValidRef, labels.JiraValidBug, "severity/p3"},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+This pull request references [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "uncherrypick removes the links between the clone and its parent and the backport label of the clone",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Labels: []string{"random-label", "jlp-v1:OCPBUGS-124", "jlp-v2:OCPBUGS-125"},
+				IssueLinks: []*jira.IssueLink{
+					{ID: "10", Type: cloneBetween123to124.Type, InwardIssue: cloneBetween123to124.InwardIssue},
+					{ID: "11", Type: blocksBetween123to124.Type, OutwardIssue: blocksBetween123to124.OutwardIssue},
+				},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				IssueLinks: []*jira.IssueLink{
+					{ID: "10", Type: cloneBetween123to124.Type, OutwardIssue: cloneBetween123to124.OutwardIssue},
+					{ID: "11", Type: blocksBetween123to124.Type, InwardIssue: blocksBetween123to124.InwardIssue},
+				},
+			}}},
+			existingIssueLinks: []*jira.IssueLink{
+				{ID: "10", Type: cloneBetween123to124.Type, InwardIssue: cloneBetween123to124.InwardIssue, OutwardIssue: cloneBetween123to124.OutwardIssue},
+				{ID: "11", Type: blocksBetween123to124.Type, InwardIssue: blocksBetween123to124.InwardIssue, OutwardIssue: blocksBetween123to124.OutwardIssue},
+			},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira uncherrypick", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			},
+			uncherrypick: true,
+			expectedComment: `org/repo#2:@user: Removed the links between [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) and the bug it was cloned from, [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). Removed the ` + "`jlp-v1:OCPBUGS-124`" + ` label(s) from [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123).
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/2):
+
+>/jira uncherrypick
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Labels: []string{"random-label", "jlp-v2:OCPBUGS-125"}, IssueLinks: []*jira.IssueLink{}, Unknowns: tcontainer.MarshalMap{}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{IssueLinks: []*jira.IssueLink{}}},
+			},
+		},
+		{
+			name: "uncherrypick clears the labels of the parent when only the backport label of the clone was set",
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Labels: []string{"jlp-v1:OCPBUGS-124"},
+			}}, {ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{
+				IssueLinks: []*jira.IssueLink{{ID: "10", Type: cloneBetween123to124.Type, OutwardIssue: cloneBetween123to124.OutwardIssue}},
+			}}},
+			existingIssueLinks: []*jira.IssueLink{{ID: "10", Type: cloneBetween123to124.Type, InwardIssue: cloneBetween123to124.InwardIssue, OutwardIssue: cloneBetween123to124.OutwardIssue}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira uncherrypick", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			},
+			uncherrypick: true,
+			expectedComment: `org/repo#2:@user: Removed the links between [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) and the bug it was cloned from, [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). Removed the ` + "`jlp-v1:OCPBUGS-124`" + ` label(s) from [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123).
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/2):
+
+>/jira uncherrypick
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Labels: []string{}, Unknowns: tcontainer.MarshalMap{}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{IssueLinks: []*jira.IssueLink{}}},
+			},
+		},
+		{
+			name:   "uncherrypick on a bug that is not a clone does not unlink anything",
+			issues: []jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{IssueLinks: []*jira.IssueLink{&blocksLinkTo123}}}},
+			overrideEvent: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira uncherrypick", title: "OCPBUGS-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/2", login: "user",
+			},
+			uncherrypick: true,
+			expectedComment: `org/repo#2:@user: [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) is not a clone of another bug; nothing was unlinked.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/2):
+
+>/jira uncherrypick
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{IssueLinks: []*jira.IssueLink{&blocksLinkTo123}}}},
+		},
+		{
+			name:   "unlink removes only the external link to the current PR and leaves the bug state alone",
+			body:   "/jira unlink",
+			unlink: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/2",
+				Title: "org/repo#2: OCPBUGS-123: fixed it too!",
+			}}, {ID: 2, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}}},
+			options: JiraBranchOptions{AddExternalLink: &yes, StateAfterClose: &JiraBugState{Status: "NEW"}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been updated to no longer refer to this pull request using the external bug tracker.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira unlink
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			expectedRemovedRemoteLinks: []jira.RemoteLink{{ID: 2, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-123: fixed it!",
+			}}},
+		},
+		{
+			name:   "unlink on a bug that does not link to the current PR removes nothing",
+			body:   "/jira unlink",
+			unlink: true,
+			issues: []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+				Status: &jira.Status{Name: "POST"},
+			}}},
+			remoteLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": {{ID: 1, Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/2",
+				Title: "org/repo#2: OCPBUGS-123: fixed it too!",
+			}}}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) does not refer to this pull request using the external bug tracker; nothing was unlinked.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira unlink
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "invalid bug comment uses the configured refresh hint",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+			options:        JiraBranchOptions{IsOpen: &open, RefreshHint: &refreshHintStr},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraInvalidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is invalid:
+ - expected the bug to be open, but it isn't
+
+Ask the release team to re-run the bug validation job.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:    "valid bug comment reporting a failed transition uses the configured refresh hint",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}}}},
+			options: JiraBranchOptions{StateAfterValidation: &JiraBugState{Status: "POST"}, RefreshHint: &refreshHintStr},
+			expectedComment: `org/repo#1:@user: An error was encountered updating to the POST state for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+
+<details><summary>Full error message.</summary>
+
+<code>
+No transition status with name ` + "`POST`" + ` could be found. Please select from the following list: [NEW MODIFIED UPDATED VERIFIED CLOSED UPDATED2 NEW2]
+</code>
+
+</details>
+
+Ask the release team to re-run the bug validation job.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:           "valid bug comment includes the configured comment footer",
+			issues:         []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+			options:        JiraBranchOptions{CommentFooter: &commentFooterStr},
+			expectedLabels: []string{labels.JiraValidRef, labels.JiraValidBug},
+			expectedComment: `org/repo#1:@user: This pull request references [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), which is valid.
+
+<details><summary>No validations were run on this bug</summary></details>
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+Reach out in #forum-my-team on Slack.
+</details>`,
+		},
+		{
+			name:    "error comment includes the configured comment footer",
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}}}},
+			options: JiraBranchOptions{StateAfterValidation: &JiraBugState{Status: "POST"}, CommentFooter: &commentFooterStr},
+			expectedComment: `org/repo#1:@user: An error was encountered updating to the POST state for bug OCPBUGS-123 on the Jira server at https://my-jira.com. No known errors were detected, please see the full error message for details.
+
+<details><summary>Full error message.</summary>
+
+<code>
+No transition status with name ` + "`POST`" + ` could be found. Please select from the following list: [NEW MODIFIED UPDATED VERIFIED CLOSED UPDATED2 NEW2]
+</code>
+
+</details>
+
+Please contact an administrator to resolve this issue, then request a bug refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+Reach out in #forum-my-team on Slack.
+</details>`,
+		},
+		{
+			name: "link-clone command links the clone to the bug in the title and retitles the PR",
+			body: "/jira link-clone OCPBUGS-124",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}},
+			},
+			linkClone: "OCPBUGS-124",
+			expectedIssueLinks: []*jira.IssueLink{{
+				Type:         jira.IssueLinkType{Name: "Cloners", Inward: "is cloned by", Outward: "clones"},
+				OutwardIssue: &jira.Issue{ID: "1"},
+				InwardIssue:  &jira.Issue{ID: "2"},
+			}, {
+				Type:         jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+				OutwardIssue: &jira.Issue{ID: "2"},
+				InwardIssue:  &jira.Issue{ID: "1"},
+			}},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) has been linked as a clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). Will retitle the PR to link to the clone.
+/retitle OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira link-clone OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "link-clone command for an already linked clone only retitles the PR",
+			body: "/jira link-clone OCPBUGS-124",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, IssueLinks: []*jira.IssueLink{&cloneLinkTo124, &blocksLinkTo124}}},
+				{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, IssueLinks: []*jira.IssueLink{&cloneLinkTo123, &blocksLinkTo123}}},
+			},
+			linkClone:          "OCPBUGS-124",
+			existingIssueLinks: []*jira.IssueLink{&cloneBetween123to124, &blocksBetween123to124},
+			expectedIssueLinks: []*jira.IssueLink{&cloneBetween123to124, &blocksBetween123to124},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) is already linked as a clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123). Will retitle the PR to link to the clone.
+/retitle OCPBUGS-124: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira link-clone OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "link-clone command for an issue in another project comments without linking",
+			body: "/jira link-clone JIRA-124",
+			issues: []jira.Issue{
+				{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}},
+				{ID: "2", Key: "JIRA-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}}},
+			},
+			linkClone: "JIRA-124",
+			expectedComment: `org/repo#1:@user: [Jira Issue JIRA-124](https://my-jira.com/browse/JIRA-124) cannot be linked as a clone of [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123), as it is in the JIRA project instead of the OCPBUGS project.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira link-clone JIRA-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:    "create command creates a bug for a NO-ISSUE PR and retitles the PR",
+			body:    "/jira create",
+			title:   "NO-ISSUE: fixed it!",
+			noJira:  true,
+			create:  true,
+			prs:     []github.PullRequest{{Number: 1, Title: "NO-ISSUE: fixed it!", Body: "This fixes a crash."}},
+			options: JiraBranchOptions{CreateIssueProject: &createProject, CreateIssueAssignee: &createAssignee, AddExternalLink: &yes},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-1](https://my-jira.com/browse/OCPBUGS-1) has been created and linked to this pull request. Will retitle the PR to link to the bug.
+/retitle OCPBUGS-1: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira create
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-1", Fields: &jira.IssueFields{
+				Project:     jira.Project{Key: "OCPBUGS"},
+				Type:        jira.IssueType{Name: "Bug"},
+				Summary:     "fixed it!",
+				Description: "This bug was created from https://github.com/org/repo/pull/1.\n\nThis fixes a crash.",
+				Assignee:    &jira.User{Name: "testUser"},
+			}}},
+			expectedNewRemoteLinks: []jira.RemoteLink{{Object: &jira.RemoteLinkObject{
+				URL:   "https://github.com/org/repo/pull/1",
+				Title: "org/repo#1: OCPBUGS-1: fixed it!",
+				Icon: &jira.RemoteLinkIcon{
+					Url16x16: "https://github.com/favicon.ico",
+					Title:    "GitHub",
+				},
+			}}},
+		},
+		{
+			name:    "create command without external links creates a bug without linking it to the PR",
+			body:    "/jira create",
+			title:   "NO-ISSUE: fixed it!",
+			noJira:  true,
+			create:  true,
+			prs:     []github.PullRequest{{Number: 1, Title: "NO-ISSUE: fixed it!", Body: "This fixes a crash."}},
+			options: JiraBranchOptions{CreateIssueProject: &createProject, CreateIssueAssignee: &createAssignee},
+			expectedComment: `org/repo#1:@user: [Jira Issue OCPBUGS-1](https://my-jira.com/browse/OCPBUGS-1) has been created for this pull request. Will retitle the PR to link to the bug.
+/retitle OCPBUGS-1: fixed it!
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira create
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-1", Fields: &jira.IssueFields{
+				Project:     jira.Project{Key: "OCPBUGS"},
+				Type:        jira.IssueType{Name: "Bug"},
+				Summary:     "fixed it!",
+				Description: "This bug was created from https://github.com/org/repo/pull/1.\n\nThis fixes a crash.",
+				Assignee:    &jira.User{Name: "testUser"},
+			}}},
+		},
+		{
+			name:    "create command fails for non-collaborators",
+			body:    "/jira create",
+			login:   "tester",
+			title:   "NO-ISSUE: fixed it!",
+			noJira:  true,
+			create:  true,
+			prs:     []github.PullRequest{{Number: 1, Title: "NO-ISSUE: fixed it!", Body: "This fixes a crash."}},
+			options: JiraBranchOptions{CreateIssueProject: &createProject, CreateIssueAssignee: &createAssignee, AddExternalLink: &yes},
+			expectedComment: `org/repo#1:@tester: The ` + "`/jira create`" + ` command is restricted to collaborators for this repo.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira create
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:    "create command without a configured project comments without creating a bug",
+			body:    "/jira create",
+			title:   "fixed it!",
+			missing: true,
+			create:  true,
+			prs:     []github.PullRequest{{Number: 1, Title: "fixed it!"}},
+			options: JiraBranchOptions{CreateIssueAssignee: &createAssignee},
+			expectedComment: `org/repo#1:@user: The ` + "`/jira create`" + ` command requires a project and an assignee for created bugs to be configured for this branch.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira create
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name:    "create command for a PR referencing an issue comments without creating a bug",
+			body:    "/jira create",
+			create:  true,
+			issues:  []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+			options: JiraBranchOptions{CreateIssueProject: &createProject, CreateIssueAssignee: &createAssignee},
+			expectedComment: `org/repo#1:@user: The ` + "`/jira create`" + ` command can only be used on pull requests that do not reference a Jira issue in their title.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira create
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+			expectedIssues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+		},
+		{
+			name:      "link-clone command for a missing issue comments without linking",
+			body:      "/jira link-clone OCPBUGS-124",
+			issues:    []jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}}},
+			linkClone: "OCPBUGS-124",
+			expectedComment: `org/repo#1:@user: Failed to link the clone in Jira: No Jira issue with key OCPBUGS-124 exists in the tracker at https://my-jira.com.
+Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>/jira link-clone OCPBUGS-124
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ptrIssues []*jira.Issue
+			for index := range tc.issues {
+				ptrIssues = append(ptrIssues, &tc.issues[index])
+			}
+			jc := &fakejira.FakeClient{
+				Issues:           ptrIssues,
+				IssueLinks:       tc.existingIssueLinks,
+				ExistingLinks:    tc.remoteLinks,
+				GetIssueError:    tc.issueGetErrors,
+				CreateIssueError: tc.issueCreateErrors,
+				UpdateIssueError: tc.issueUpdateErrors,
+				Transitions:      jiraTransitions,
+			}
+			jiraClient := fakeJiraClient{jc}
+			var testEvent event // copy so parallel tests don't collide
+			if tc.overrideEvent != nil {
+				testEvent = *tc.overrideEvent
+			} else {
+				testEvent = *base // copy so parallel tests don't collide
+			}
+			testEvent.refresh = tc.refresh
+			testEvent.backport = tc.backport
+			testEvent.backportCheck = tc.backportCheck
+			testEvent.backportBranches = tc.backportBranches
+			testEvent.missing = tc.missing
+			testEvent.merged = tc.merged
+			testEvent.closed = tc.closed || tc.merged
+			testEvent.opened = tc.opened
+			testEvent.reopened = tc.reopened
+			testEvent.verify = tc.verified
+			testEvent.verifyLater = tc.verifiedLater
+			testEvent.verifiedRemove = tc.verifiedRemove
+			testEvent.fileChanged = tc.fileChanged
+			testEvent.beforeSHA = tc.beforeSHA
+			testEvent.afterSHA = tc.afterSHA
+			testEvent.verifiedByReview = tc.verifiedByReview
+			testEvent.finalize = tc.finalize
+			testEvent.priority = tc.priority
+			testEvent.targetVersion = tc.targetVersion
+			testEvent.fixVersion = tc.fixVersion
+			testEvent.bugStatus = tc.bugStatus
+			testEvent.move = tc.move
+			testEvent.draft = tc.draft
+			testEvent.jiraComment = tc.jiraComment
+			testEvent.uncherrypick = tc.uncherrypick
+			testEvent.unlink = tc.unlink
+			testEvent.linkClone = tc.linkClone
+			testEvent.create = tc.create
+			testEvent.cc = tc.cc
+			testEvent.author = tc.author
+			if tc.login != "" {
+				testEvent.login = tc.login
+			}
+			if tc.replaceReferencedBugs != nil {
+				newEvent := testEvent
+				newEvent.issues = []referencedIssue{}
+				testEvent.issues = tc.replaceReferencedBugs
+			}
+			if tc.noJira {
+				testEvent.noJira = true
+				testEvent.issues = nil
+			}
+			testEvent.cherrypick = tc.cherrypick
+			testEvent.cherrypickFromPRNum = tc.cherryPickFromPRNum
+			if tc.body != "" {
+				testEvent.body = tc.body
+			}
+			if tc.title != "" {
+				testEvent.title = tc.title
+			}
+			if tc.baseRef != "" {
+				testEvent.baseRef = tc.baseRef
+			}
+
+			gc := fakegithub.NewFakeClient()
+			gc.IssueLabelsExisting = []string{}
+			gc.IssueComments = map[int][]github.IssueComment{}
+			maps.Copy(gc.IssueComments, tc.prComments)
+			gc.PullRequestChanges = map[int][]github.PullRequestChange{testEvent.number: tc.prChanges}
+			gc.CommitMap[fmt.Sprintf("%s/%s#%d", testEvent.org, testEvent.repo, testEvent.number)] = tc.prCommits
+			for _, commit := range tc.prCommits {
+				gc.Commits[commit.SHA] = commit
+			}
+			gc.PullRequests = map[int]*github.PullRequest{}
+			gc.WasLabelAddedByHumanVal = tc.humanLabelled
+			for _, label := range tc.labels {
+				gc.IssueLabelsExisting = append(gc.IssueLabelsExisting, fmt.Sprintf("%s/%s#%d:%s", testEvent.org, testEvent.repo, testEvent.number, label))
+			}
+			for _, pr := range tc.prs {
+				pr := pr
+				gc.PullRequests[pr.Number] = &pr
+			}
+			gc.Collaborators = []string{"user"}
+			// the test-infra fake github client does not implement a Query function; we don't test the query functionality here, so we can just wrap the test-infra
+			// client with a custom one that has an empty Query function
+			// TODO: implement a basic fake query function in test-infra fakegithub library and start unit testing the query path
+			fakeClient := fakeGHClient{gc}
+			// create separate inserter variable to test nil inserter case
+			var inserter BigQueryInserter
+			fakeInserter := fakeBigQueryInserter{}
+			if !tc.nilBigQuery {
+				inserter = &fakeInserter
+			}
+			notifier := &fakeSlackNotifier{}
+			if err := handle(&jiraClient, fakeClient, inserter, notifier, tc.fullConfig.OptionsForRepo("org", "repo"), tc.options, logrus.WithField("testCase", tc.name), testEvent, sets.New("org/repo"), defaultBugProjects, tc.dryRun); err != nil {
+				t.Fatalf("handle failed: %v", err)
+			}
+
+			if diff := cmp.Diff(jiraClient.NewLinks, tc.expectedNewRemoteLinks); diff != "" {
+				t.Errorf("new links differs from expected new links: %s", diff)
+			}
+
+			if diff := cmp.Diff(jiraClient.RemovedLinks, tc.expectedRemovedRemoteLinks); diff != "" {
+				t.Errorf("removed links differs from expected removed links: %s", diff)
+			}
+
+			if diff := cmp.Diff(gc.IssueCommentsEdited, tc.expectedCommentUpdates); diff != "" {
+				t.Errorf("comment updates differ from expected: %s", diff)
+			}
+
+			if diff := cmp.Diff(gc.IssueCommentsDeleted, tc.expectedDeletedComments); diff != "" {
+				t.Errorf("deleted comments differ from expected: %s", diff)
+			}
+
+			if tc.linkClone != "" {
+				if diff := cmp.Diff(jiraClient.IssueLinks, tc.expectedIssueLinks); diff != "" {
+					t.Errorf("issue links differ from expected: %s", diff)
+				}
+			}
+
+			if len(gc.CreatedStatuses) != 0 || tc.expectedStatuses != nil {
+				if diff := cmp.Diff(gc.CreatedStatuses, tc.expectedStatuses); diff != "" {
+					t.Errorf("created statuses differ from expected: %s", diff)
+				}
+			}
+
+			checkComments(gc, tc.name, tc.expectedComment, t)
+
+			if diff := cmp.Diff(notifier.messages, tc.expectedSlackMessages, cmp.AllowUnexported(slackMessage{})); diff != "" {
+				t.Errorf("slack messages differ from expected: %s", diff)
+			}
+
+			expected := sets.NewString()
+			for _, label := range tc.expectedLabels {
+				expected.Insert(fmt.Sprintf("%s/%s#%d:%s", testEvent.org, testEvent.repo, testEvent.number, label))
+			}
+
+			actual := sets.NewString(gc.IssueLabelsExisting...)
+			actual.Insert(gc.IssueLabelsAdded...)
+			actual.Delete(gc.IssueLabelsRemoved...)
+
+			if missing := expected.Difference(actual); missing.Len() > 0 {
+				t.Errorf("%s: missing expected labels: %v", tc.name, missing.List())
+			}
+			if extra := actual.Difference(expected); extra.Len() > 0 {
+				t.Errorf("%s: unexpected labels: %v", tc.name, extra.List())
+			}
+
+			// reset errors on client for verification
+			jiraClient.CreateIssueError = nil
+			jiraClient.GetIssueError = nil
+			for _, expectedIssue := range tc.expectedIssues {
+				actual, err := jiraClient.GetIssue(expectedIssue.ID)
+				if err != nil {
+					t.Errorf("%s: failed to get expected bug during test: %v", tc.name, err)
+				}
+				if !reflect.DeepEqual(actual, expectedIssue) {
+					t.Errorf("%s: got incorrect bug after update: %s", tc.name, cmp.Diff(actual, expectedIssue, allowEventAndDate))
+				}
+			}
+
+			if !tc.nilBigQuery {
+				if len(tc.verificationInfo) != len(fakeInserter.insertedData) {
+					t.Errorf("%s: length of verification info does not match: %d != %d", tc.name, len(fakeInserter.insertedData), len(tc.verificationInfo))
+				} else {
+					for index, verificationInfo := range tc.verificationInfo {
+						if !reflect.DeepEqual(fakeInserter.insertedData[index], verificationInfo) {
+							t.Errorf("%s: Got incorrect verification info: %s", tc.name, cmp.Diff(fakeInserter.insertedData[index], verificationInfo, allowEventAndDate))
+						}
+					}
+				}
+				if diff := cmp.Diff(tc.expectedTimeInState, fakeInserter.insertedTimeInState); diff != "" {
+					t.Errorf("%s: Got incorrect time in state: %s", tc.name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleMergeBatchesPullRequestFetches(t *testing.T) {
+	t.Parallel()
+	var remoteLinks []jira.RemoteLink
+	for index, url := range []string{
+		"https://github.com/org/repo/pull/1",
+		"https://github.com/org/repo/pull/2",
+		"https://github.com/org/repo/pull/3",
+		"https://github.com/org/repo/pull/3/files",
+		"https://github.com/org/repo/pull/4",
+		"https://github.com/org/repo/pull/5",
+	} {
+		remoteLinks = append(remoteLinks, jira.RemoteLink{ID: index + 1, Object: &jira.RemoteLinkObject{URL: url}})
+	}
+	jc := &fakejira.FakeClient{
+		Issues: []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{
+			Project: jira.Project{Key: "OCPBUGS"},
+			Status:  &jira.Status{Name: "MODIFIED"},
+		}}},
+		ExistingLinks: map[string][]jira.RemoteLink{"OCPBUGS-123": remoteLinks},
+		Transitions:   []jira.Transition{{ID: "1", Name: "CLOSED", To: jira.Status{Name: "CLOSED"}}},
+	}
+	gc := fakegithub.NewFakeClient()
+	gc.PullRequests = map[int]*github.PullRequest{}
+	for number := 2; number <= 5; number++ {
+		gc.PullRequests[number] = &github.PullRequest{Number: number, Merged: true}
+	}
+	client := &countingGHClient{fakeGHClient: fakeGHClient{gc}}
+	e := event{
+		org: "org", repo: "repo", baseRef: "branch", number: 1, merged: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This PR fixes OCPBUGS-123", title: "OCPBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+	}
+	options := JiraBranchOptions{StateAfterMerge: &JiraBugState{Status: "CLOSED"}}
+	if err := handleMerge(e, client, &fakeJiraClient{jc}, &fakeBigQueryInserter{}, options, logrus.WithField("test", t.Name()), sets.New("org/repo")); err != nil {
+		t.Fatalf("handleMerge failed: %v", err)
+	}
+	// the merged pull request is known from the event and the pull request linked twice is only fetched once
+	if fetches := client.pullRequestFetches.Load(); fetches != 4 {
+		t.Errorf("expected 4 pull request fetches, got %d", fetches)
+	}
+	checkComments(gc, t.Name(), `org/repo#1:@user: [Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123): All pull requests linked via external trackers have merged:
+ * [org/repo#1](https://github.com/org/repo/pull/1)
+ * [org/repo#2](https://github.com/org/repo/pull/2)
+ * [org/repo#3](https://github.com/org/repo/pull/3)
+ * [org/repo#3](https://github.com/org/repo/pull/3)
+ * [org/repo#4](https://github.com/org/repo/pull/4)
+ * [org/repo#5](https://github.com/org/repo/pull/5)
+
+[Jira Issue OCPBUGS-123](https://my-jira.com/browse/OCPBUGS-123) has been moved to the CLOSED state.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUGS-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`, t)
+}
+
+// projectListingJiraClient counts the calls made to list the projects in Jira
+type projectListingJiraClient struct {
+	*fakeJiraClient
+	lists int
+}
+
+func (f *projectListingJiraClient) ListProjects() (*jira.ProjectList, error) {
+	f.lists++
+	return f.fakeJiraClient.ListProjects()
+}
+
+func TestHandleUnknownProjectHint(t *testing.T) {
+	t.Parallel()
+	yes := true
+	jc := &projectListingJiraClient{fakeJiraClient: &fakeJiraClient{&fakejira.FakeClient{
+		Issues: []*jira.Issue{{ID: "1", Key: "JIRA-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "JIRA"}}}},
+	}}}
+	gc := fakegithub.NewFakeClient()
+	e := event{
+		org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", body: "This PR fixes OCPBUG-123", title: "OCPBUG-123,OCPBUG-124: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+		issues: []referencedIssue{{Project: "OCPBUG", ID: "123"}, {Project: "OCPBUG", ID: "124"}},
+	}
+	options := JiraBranchOptions{StrictProjectPrefixes: &yes}
+	if err := handle(jc, fakeGHClient{gc}, nil, nil, nil, options, logrus.WithField("test", t.Name()), e, sets.New("org/repo"), defaultBugProjects, false); err != nil {
+		t.Fatalf("handle failed: %v", err)
+	}
+	// the projects are listed once for all of the references to unknown projects
+	if jc.lists != 1 {
+		t.Errorf("expected the projects to be listed once, got %d", jc.lists)
+	}
+	expected := []string{
+		`org/repo#1:@user: No Jira issue with key OCPBUG-123 exists in the tracker at https://my-jira.com.
+Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUG-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		`org/repo#1:@user: No Jira issue with key OCPBUG-124 exists in the tracker at https://my-jira.com.
+Once a valid jira issue is referenced in the title of this pull request, request a refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUG-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		`org/repo#1:@user: The title of this pull request references OCPBUG-123, but OCPBUG is neither a known bug project nor a project in the tracker at https://my-jira.com.
+Bugs are referenced by adding one of DFBUGS, OCPBUGS followed by the bug number (e.g. 'DFBUGS-123:') to the title of this pull request. Once the title is corrected, request a refresh with <code>/jira refresh</code>.
+
+The title of this pull request references OCPBUG-124, but OCPBUG is neither a known bug project nor a project in the tracker at https://my-jira.com.
+Bugs are referenced by adding one of DFBUGS, OCPBUGS followed by the bug number (e.g. 'DFBUGS-123:') to the title of this pull request. Once the title is corrected, request a refresh with <code>/jira refresh</code>.
+
+<details>
+
+In response to [this](https://github.com/org/repo/pull/1):
+
+>This PR fixes OCPBUG-123
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+	}
+	if diff := cmp.Diff(expected, gc.IssueCommentsAdded); diff != "" {
+		t.Errorf("comments differ from expected: %s", diff)
+	}
+}
+
+func TestHandleCommentOnlyOnRefreshReportsUnknownIssues(t *testing.T) {
+	t.Parallel()
+	yes := true
+	jc := &fakeJiraClient{&fakejira.FakeClient{}}
+	gc := fakegithub.NewFakeClient()
+	gc.IssueLabelsExisting = []string{"org/repo#1:" + labels.JiraValidBug}
+	e := event{
+		org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, body: "This PR fixes JIRA-123", title: "JIRA-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+		issues: []referencedIssue{{Project: "JIRA", ID: "123"}},
+	}
+	options := JiraBranchOptions{CommentOnlyOnRefresh: &yes}
+	if err := handle(jc, fakeGHClient{gc}, nil, nil, nil, options, logrus.WithField("test", t.Name()), e, sets.New("org/repo"), defaultBugProjects, false); err != nil {
+		t.Fatalf("handle failed: %v", err)
+	}
+	// the issue that could not be found is reported even though automatic events do not otherwise comment
+	if !slices.ContainsFunc(gc.IssueCommentsAdded, func(comment string) bool {
+		return strings.Contains(comment, "The referenced Jira(s) [JIRA-123] could not be located")
+	}) {
+		t.Errorf("expected the unknown issue to be reported, got comments: %v", gc.IssueCommentsAdded)
+	}
+}
+
+// issueFetchingJiraClient counts the calls made to get each issue from Jira
+type issueFetchingJiraClient struct {
+	*fakeJiraClient
+	gets map[string]int
+}
+
+func (f *issueFetchingJiraClient) GetIssue(id string) (*jira.Issue, error) {
+	f.gets[id]++
+	return f.fakeJiraClient.GetIssue(id)
+}
+
+func TestHandleFetchesLinkedIssuesOnce(t *testing.T) {
+	t.Parallel()
+	yes := true
+	jc := &issueFetchingJiraClient{fakeJiraClient: &fakeJiraClient{&fakejira.FakeClient{
+		Issues: []*jira.Issue{
+			{ID: "1", Key: "OCPBUGS-123", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, IssueLinks: []*jira.IssueLink{{
+				Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+				InwardIssue: &jira.Issue{Key: "OCPBUGS-124"},
+			}}}},
+			{ID: "2", Key: "OCPBUGS-124", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "CLOSED"}, Resolution: &jira.Resolution{Name: "Done"}}},
+		},
+	}}, gets: map[string]int{}}
+	gc := fakegithub.NewFakeClient()
+	e := event{
+		org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", body: "This PR fixes OCPBUGS-123", title: "OCPBUGS-123: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user",
+		issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}},
+	}
+	options := JiraBranchOptions{DependentBugStates: &[]JiraBugState{{Status: "CLOSED"}}, RequireBlockersResolved: &yes}
+	if err := handle(jc, fakeGHClient{gc}, nil, nil, nil, options, logrus.WithField("test", t.Name()), e, sets.New("org/repo"), defaultBugProjects, false); err != nil {
+		t.Fatalf("handle failed: %v", err)
+	}
+	// the blocker is also a dependent of the bug, so it is only fetched once for both validations
+	if gets := jc.gets["OCPBUGS-124"]; gets != 1 {
+		t.Errorf("expected the blocking dependent to be fetched once, got %d", gets)
+	}
+}
+
+func TestOpenBugPullRequestEvents(t *testing.T) {
+	t.Parallel()
+	gc := fakegithub.NewFakeClient()
+	gc.PullRequests = map[int]*github.PullRequest{
+		1: {Number: 1, State: github.PullRequestStateOpen, Title: "OCPBUGS-1: fixed it!", Body: "fixes", HTMLURL: "https://github.com/org/repo/pull/1", User: github.User{Login: "user"}, Base: github.PullRequestBranch{Ref: "main"}},
+		2: {Number: 2, State: github.PullRequestStateOpen, Title: "NO-JIRA: chore"},
+		3: {Number: 3, State: github.PullRequestStateOpen, Title: "JIRA-3: feature"},
+		4: {Number: 4, State: github.PullRequestStateClosed, Title: "OCPBUGS-4: already merged"},
+		5: {Number: 5, State: github.PullRequestStateOpen, Title: "no reference"},
+		6: {Number: 6, State: github.PullRequestStateOpen, Title: "JIRA-6,OCPBUGS-6: feature and fix", Draft: true, HTMLURL: "https://github.com/org/repo/pull/6", User: github.User{Login: "other"}, Base: github.PullRequestBranch{Ref: "release-4.10"}},
+		7: {Number: 7, State: github.PullRequestStateOpen, Title: "chore: cleanup", Body: "Fixes OCPBUGS-7", HTMLURL: "https://github.com/org/repo/pull/7", User: github.User{Login: "user"}, Base: github.PullRequestBranch{Ref: "main"}},
+		8: {Number: 8, State: github.PullRequestStateOpen, Title: "chore: cleanup", Body: "Fixes OCPBUGS-8", Base: github.PullRequestBranch{Ref: "release-4.10"}},
+	}
+	yes := true
+	cfg := &Config{Default: map[string]JiraBranchOptions{"main": {ReferencesFromBody: &yes}}}
+	events, err := OpenBugPullRequestEvents(fakeGHClient{gc}, "org", "repo", cfg)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	expected := []event{
+		{org: "org", repo: "repo", baseRef: "main", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1", IsBug: true}}, state: "open", body: "fixes", title: "OCPBUGS-1: fixed it!", htmlUrl: "https://github.com/org/repo/pull/1", login: "user", author: "user"},
+		{org: "org", repo: "repo", baseRef: "release-4.10", number: 6, issues: []referencedIssue{{Project: "JIRA", ID: "6"}, {Project: "OCPBUGS", ID: "6", IsBug: true}}, draft: true, state: "open", title: "JIRA-6,OCPBUGS-6: feature and fix", htmlUrl: "https://github.com/org/repo/pull/6", login: "other", author: "other"},
+		{org: "org", repo: "repo", baseRef: "main", number: 7, issues: []referencedIssue{{Project: "OCPBUGS", ID: "7", IsBug: true}}, state: "open", body: "Fixes OCPBUGS-7", title: "chore: cleanup", htmlUrl: "https://github.com/org/repo/pull/7", login: "user", author: "user"},
+	}
+	if diff := cmp.Diff(expected, events, allowEventAndDate); diff != "" {
+		t.Errorf("incorrect events: %s", diff)
+	}
+}
+
+func TestRefreshAll(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name            string
+		collaborators   []string
+		prs             map[int]*github.PullRequest
+		expectedLabels  []string
+		expectedSummary string
+	}{
+		{
+			name: "non-collaborator cannot refresh all pull requests",
+			prs: map[int]*github.PullRequest{
+				1: {Number: 1, State: github.PullRequestStateOpen, Title: "OCPBUGS-1: fixed it!"},
+			},
+			expectedSummary: "The `/jira refresh-all` command is restricted to collaborators for this repo.",
+		},
+		{
+			name:            "repo without pull requests referencing bugs",
+			collaborators:   []string{"maintainer"},
+			prs:             map[int]*github.PullRequest{1: {Number: 1, State: github.PullRequestStateOpen, Title: "NO-JIRA: chore"}},
+			expectedSummary: "No open pull requests in org/repo reference a Jira bug; nothing was refreshed.",
+		},
+		{
+			name:          "every open pull request referencing a bug is refreshed",
+			collaborators: []string{"maintainer"},
+			prs: map[int]*github.PullRequest{
+				1: {Number: 1, State: github.PullRequestStateOpen, Title: "OCPBUGS-1: fixed it!", User: github.User{Login: "user"}},
+				2: {Number: 2, State: github.PullRequestStateOpen, Title: "OCPBUGS-2: fixed it too!", User: github.User{Login: "user"}},
+				3: {Number: 3, State: github.PullRequestStateOpen, Title: "NO-JIRA: chore", User: github.User{Login: "user"}},
+				4: {Number: 4, State: github.PullRequestStateOpen, Title: "OCPBUGS-404: missing bug", User: github.User{Login: "user"}},
+			},
+			expectedLabels:  []string{"org/repo#1:jira/valid-bug", "org/repo#1:jira/valid-reference", "org/repo#2:jira/valid-bug", "org/repo#2:jira/valid-reference"},
+			expectedSummary: "Refreshed 3 of 3 open pull requests in org/repo that reference a Jira bug.",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gc := fakegithub.NewFakeClient()
+			gc.PullRequests = tc.prs
+			gc.Collaborators = tc.collaborators
+			jc := &fakejira.FakeClient{Issues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}}},
+				{ID: "2", Key: "OCPBUGS-2", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}}},
+			}}
+			e := event{org: "org", repo: "repo", number: 10, body: "/jira refresh-all", htmlUrl: "https://github.com/org/repo/issues/10", login: "maintainer", refreshAll: true}
+			if err := refreshAll(&fakeJiraClient{jc}, fakeGHClient{gc}, &fakeBigQueryInserter{}, nil, &Config{}, logrus.WithField("testCase", tc.name), e, sets.New("org/repo"), false); err != nil {
+				t.Fatalf("refreshAll failed: %v", err)
+			}
+			var summary string
+			for _, comment := range gc.IssueCommentsAdded {
+				if strings.HasPrefix(comment, "org/repo#10:") {
+					summary = comment
+				}
+			}
+			if !strings.Contains(summary, tc.expectedSummary) {
+				t.Errorf("expected summary comment to contain %q, got %q", tc.expectedSummary, summary)
+			}
+			sort.Strings(gc.IssueLabelsAdded)
+			if diff := cmp.Diff(tc.expectedLabels, gc.IssueLabelsAdded); diff != "" {
+				t.Errorf("incorrect labels added: %s", diff)
+			}
+		})
+	}
+}
+
+func checkComments(client *fakegithub.FakeClient, name, expectedComment string, t *testing.T) {
+	wantedComments := 0
+	if expectedComment != "" {
+		wantedComments = 1
+	}
+	if len(client.IssueCommentsAdded) != wantedComments {
+		t.Errorf("%s: wanted %d comment, got %d: %v", name, wantedComments, len(client.IssueCommentsAdded), client.IssueCommentsAdded)
+	}
+
+	if expectedComment != "" && len(client.IssueCommentsAdded) == 1 {
+		if expectedComment != client.IssueCommentsAdded[0] {
+			t.Errorf("%s: got incorrect comment: %v", name, cmp.Diff(expectedComment, client.IssueCommentsAdded[0]))
+		}
+	}
+}
+
+func TestAcknowledgeCommand(t *testing.T) {
+	t.Parallel()
+	eyes := "eyes"
+	var testCases = []struct {
+		name              string
+		options           JiraBranchOptions
+		e                 event
+		expectedReactions []string
+	}{
+		{
+			name:              "refresh is reacted to",
+			options:           JiraBranchOptions{CommandReaction: &eyes},
+			e:                 event{refresh: true},
+			expectedReactions: []string{"org/repo#5:eyes"},
+		},
+		{
+			name:              "verified is reacted to",
+			options:           JiraBranchOptions{CommandReaction: &eyes},
+			e:                 event{verify: []string{"tester"}},
+			expectedReactions: []string{"org/repo#5:eyes"},
+		},
+		{
+			name: "refresh is not reacted to without a configured reaction",
+			e:    event{refresh: true},
+		},
+		{
+			name:    "other commands are not reacted to",
+			options: JiraBranchOptions{CommandReaction: &eyes},
+			e:       event{cc: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gc := fakegithub.NewFakeClient()
+			e := tc.e
+			e.org, e.repo, e.number, e.commentID = "org", "repo", 1, 5
+			acknowledgeCommand(fakeGHClient{gc}, e, tc.options, logrus.WithField("testCase", tc.name))
+			if diff := cmp.Diff(tc.expectedReactions, gc.CommentReactionsAdded); diff != "" {
+				t.Errorf("reactions differ from expected: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	t.Parallel()
+	pre := github.PullRequestEvent{
+		Action: github.PullRequestActionOpened,
+		PullRequest: github.PullRequest{
+			Base:   github.PullRequestBranch{Ref: "main", Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}},
+			Number: 1,
+			Title:  "OCPBUGS-1: fixed it!",
+			User:   github.User{Login: "user"},
+		},
+	}
+	ice := github.IssueCommentEvent{
+		Action:  github.IssueCommentActionCreated,
+		Issue:   github.Issue{Number: 1, PullRequest: &struct{}{}},
+		Comment: github.IssueComment{Body: "/jira refresh", User: github.User{Login: "user"}},
+		Repo:    github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+	}
+	var testCases = []struct {
+		name             string
+		notice           string
+		expectedComments int
+	}{
+		{
+			name: "no mutations occur in maintenance mode",
+		},
+		{
+			name:             "the maintenance notice is only posted once",
+			notice:           "Jira is undergoing maintenance; this pull request will be re-evaluated later.",
+			expectedComments: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gc := fakegithub.NewFakeClient()
+			gc.PullRequests = map[int]*github.PullRequest{1: &pre.PullRequest}
+			jc := &fakejira.FakeClient{Issues: []*jira.Issue{
+				{ID: "1", Key: "OCPBUGS-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}, Status: &jira.Status{Name: "NEW"}}},
+			}}
+			open := true
+			s := &server{
+				config: func() *Config {
+					return &Config{
+						Default:           map[string]JiraBranchOptions{JiraOptionsWildcard: {IsOpen: &open, StateAfterValidation: &JiraBugState{Status: "POST"}, AddExternalLink: &open}},
+						MaintenanceMode:   true,
+						MaintenanceNotice: tc.notice,
+					}
+				},
+				ghc:             fakeGHClient{gc},
+				jc:              &fakeJiraClient{jc},
+				refreshCooldown: newRefreshCooldown(),
+			}
+			log := logrus.WithField("testCase", tc.name)
+			s.handlePullRequest(log, pre)
+			s.handleIssueComment(log, ice)
+			s.handlePullRequest(log, pre)
+
+			if len(gc.IssueLabelsAdded) != 0 || len(gc.IssueLabelsRemoved) != 0 || len(gc.CommentReactionsAdded) != 0 {
+				t.Errorf("expected no changes on GitHub, got labels added %v, labels removed %v, reactions %v", gc.IssueLabelsAdded, gc.IssueLabelsRemoved, gc.CommentReactionsAdded)
+			}
+			if len(gc.IssueCommentsAdded) != tc.expectedComments {
+				t.Errorf("expected %d comments, got %d: %v", tc.expectedComments, len(gc.IssueCommentsAdded), gc.IssueCommentsAdded)
+			}
+			if tc.notice != "" && len(gc.IssueCommentsAdded) == 1 && !strings.Contains(gc.IssueCommentsAdded[0], tc.notice) {
+				t.Errorf("expected the comment to contain the notice, got %q", gc.IssueCommentsAdded[0])
+			}
+			if jc.Issues[0].Fields.Status.Name != "NEW" || len(jc.NewLinks) != 0 {
+				t.Errorf("expected no changes in Jira, got status %s and remote links %v", jc.Issues[0].Fields.Status.Name, jc.NewLinks)
+			}
+		})
+	}
+}
+
+func TestInsertLinksIntoComment(t *testing.T) {
+	t.Parallel()
+	const issueName = "ABC-123"
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name: "Multiline body starting with issue name",
+			body: `ABC-123: Fix problems:
+* First problem
+* Second problem`,
+			expected: `[ABC-123](https://my-jira.com/browse/ABC-123): Fix problems:
+* First problem
+* Second problem`,
+		},
+		{
+			name: "Multiline body starting with already replaced issue name",
+			body: `[ABC-123](https://my-jira.com/browse/ABC-123): Fix problems:
+* First problem
+* Second problem`,
+			expected: `[ABC-123](https://my-jira.com/browse/ABC-123): Fix problems:
+* First problem
+* Second problem`,
+		},
+		{
+			name: "Multiline body with multiple occurrence in the middle",
+			body: `This change:
+* Does stuff related to ABC-123
+* And even more stuff related to ABC-123
+* But also something else`,
+			expected: `This change:
+* Does stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
+* And even more stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
+* But also something else`,
+		},
+		{
+			name: "Multiline body with multiple occurrence in the middle, some already replaced",
+			body: `This change:
+* Does stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
+* And even more stuff related to ABC-123
+* But also something else`,
+			expected: `This change:
+* Does stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
+* And even more stuff related to [ABC-123](https://my-jira.com/browse/ABC-123)
+* But also something else`,
+		},
+		{
+			name: "Multiline body with issue name at the end",
+			body: `This change:
+is very important
+because of ABC-123`,
+			expected: `This change:
+is very important
+because of [ABC-123](https://my-jira.com/browse/ABC-123)`,
+		},
+		{
+			name: "Multiline body with already replaced issue name at the end",
+			body: `This change:
+is very important
+because of [ABC-123](https://my-jira.com/browse/ABC-123)`,
+			expected: `This change:
+is very important
+because of [ABC-123](https://my-jira.com/browse/ABC-123)`,
+		},
+		{
+			name:     "Pasted links are not replaced, as they are already clickable",
+			body:     "https://my-jira.com/browse/ABC-123",
+			expected: "https://my-jira.com/browse/ABC-123",
+		},
+		{
+			name: "code section is not replaced",
+			body: `This change:
+is very important` + "\n```bash\n" +
+				`ABC-123` +
+				"\n```\n" + `ABC-123
+`,
+			expected: `This change:
+is very important` + "\n```bash\n" +
+				`ABC-123` +
+				"\n```\n" + `[ABC-123](https://my-jira.com/browse/ABC-123)
+`,
+		},
+		{
+			name: "inline code is not replaced",
+			body: `This change:
+is very important` + "\n``ABC-123`` and `ABC-123` shouldn't be replaced, as well as ``ABC-123: text text``. " +
+				`ABC-123 should be replaced.
+`,
+			expected: `This change:
+is very important` + "\n``ABC-123`` and `ABC-123` shouldn't be replaced, as well as ``ABC-123: text text``. " +
+				`[ABC-123](https://my-jira.com/browse/ABC-123) should be replaced.
+`,
+		},
+		{
+			name:     "Multiline codeblock that is denoted through four leading spaces",
+			body:     "I meant to do this test:\r\n\r\n    operator_test.go:1914: failed to read output from pod unique-id-header-test-1: container \"curl\" in pod \"unique-id-header-ABC-123\" is waiting to start: ContainerCreating\r\n\r\n",
+			expected: "I meant to do this test:\r\n\r\n    operator_test.go:1914: failed to read output from pod unique-id-header-test-1: container \"curl\" in pod \"unique-id-header-ABC-123\" is waiting to start: ContainerCreating\r\n\r\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(insertLinksIntoComment(tc.body, []string{issueName}, fakejira.FakeJiraUrl), tc.expected); diff != "" {
+				t.Errorf("actual result differs from expected result: %s", diff)
+			}
+		})
+	}
+}
+
+// multiLayerConfig sets options at the global, org, repo and branch layers of the configuration
+const multiLayerConfig = `disabled_jira_projects:
+- "private-project"
+default:
+  "*":
+    target_version: global-default
+  "global-branch":
+    is_open: false
+    target_version: global-branch-default
+orgs:
+  my-org:
+    default:
+      "*":
+        is_open: true
+        target_version: my-org-default
+        state_after_validation:
+          status: "PRE"
+      "my-org-branch":
+        target_version: my-org-branch-default
+        state_after_validation:
+          status: "POST"
+        add_external_link: true
+    repos:
+      my-repo:
+        branches:
+          "*":
+            is_open: false
+            target_version: my-repo-default
+            valid_states:
+            - status: VALIDATED
+          "my-repo-branch":
+            target_version: my-repo-branch
+            valid_states:
+            - status: MODIFIED
+            add_external_link: true
+            state_after_merge:
+              status: MODIFIED
+            allowed_security_levels:
+            - default
+          "branch-that-likes-closed-bugs":
+            valid_states:
+            - status: VERIFIED
+            - status: CLOSED
+              resolution: ERRATA
+            dependent_bug_states:
+            - status: CLOSED
+              resolution: ERRATA
+            state_after_merge:
+              status: CLOSED
+              resolution: FIXED
+            state_after_validation:
+              status: CLOSED
+              resolution: VALIDATED`
+
+func TestHelpProvider(t *testing.T) {
+	var config Config
+	if err := yaml.Unmarshal([]byte(multiLayerConfig), &config); err != nil {
+		t.Fatalf("couldn't unmarshal config: %v", err)
+	}
+	enabledRepos := []prowconfig.OrgRepo{
+		{Org: "some-org", Repo: "some-repo"},
+		{Org: "my-org", Repo: "some-repo"},
+		{Org: "my-org", Repo: "my-repo"},
+	}
+	serv := &server{
+		config: func() *Config {
+			return &config
+		},
+	}
+	help, err := serv.helpProvider(enabledRepos)
+	if err != nil {
+		t.Fatalf("unexpected error creating help provider: %v", err)
+	}
+	// don't check snippet
+	help.Snippet = ""
+
+	expected := &pluginhelp.PluginHelp{
+		Description: "The jira plugin ensures that pull requests reference a valid Jira bug in their title.",
+		Config: map[string]string{
+			"some-org/some-repo": `The plugin has the following configuration:<ul>
+<li>by default, valid bugs must target the "global-default" version.</li>
+<li>on the "global-branch" branch, valid bugs must be closed and target the "global-branch-default" version.</li>
+</ul>`,
+			"my-org/some-repo": `The plugin has the following configuration:<ul>
+<li>by default, valid bugs must be open and target the "my-org-default" version. After being linked to a pull request, bugs will be moved to the PRE state.</li>
+<li>on the "my-org-branch" branch, valid bugs must be open and target the "my-org-branch-default" version. After being linked to a pull request, bugs will be moved to the POST state and updated to refer to the pull request using the external bug tracker.</li>
+</ul>`,
+			"my-org/my-repo": `The plugin has the following configuration:<ul>
+<li>by default, valid bugs must be closed, target the "my-repo-default" version, and be in one of the following states: VALIDATED. After being linked to a pull request, bugs will be moved to the PRE state.</li>
+<li>on the "branch-that-likes-closed-bugs" branch, valid bugs must be closed, target the "my-repo-default" version, be in one of the following states: VERIFIED, CLOSED (ERRATA), depend on at least one other bug, and have all dependent bugs in one of the following states: CLOSED (ERRATA). After being linked to a pull request, bugs will be moved to the CLOSED (VALIDATED) state and moved to the CLOSED (FIXED) state when all linked pull requests are merged.</li>
+<li>on the "my-org-branch" branch, valid bugs must be closed, target the "my-repo-default" version, and be in one of the following states: VALIDATED. After being linked to a pull request, bugs will be moved to the POST state and updated to refer to the pull request using the external bug tracker.</li>
+<li>on the "my-repo-branch" branch, valid bugs must be closed, target the "my-repo-branch" version, and be in one of the following states: MODIFIED. After being linked to a pull request, bugs will be moved to the PRE state, updated to refer to the pull request using the external bug tracker, and moved to the MODIFIED state when all linked pull requests are merged.</li>
+</ul>`,
+		},
+		Commands: []pluginhelp.Command{
+			{
+				Usage:       "/jira refresh",
+				Description: "Check Jira for a valid bug referenced in the PR title",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira refresh"},
+			}, {
+				Usage:       "/jira refresh-all",
+				Description: "Re-evaluate every open PR in the repo that references a Jira bug in its title",
+				Featured:    false,
+				WhoCanUse:   "Collaborators on the repository",
+				Examples:    []string{"/jira refresh-all"},
+			}, {
+				Usage:       "/jira cc-qa",
+				Description: "Request PR review from QA contact specified in Jira",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira cc-qa"},
+			}, {
+				Usage:       "/jira cherrypick jiraBugKey",
+				Description: "Cherrypick a jira bug and link it to the current PR",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira cherrypick OCPBUGS-1234"},
+			}, {
+				Usage:       "/jira uncherrypick",
+				Description: "Undo a cherrypick of the jira bugs referenced in the PR title by removing the links to the bugs they were cloned from",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira uncherrypick"},
+			}, {
+				Usage:       "/jira unlink",
+				Description: "Remove the external link to the current PR from the jira bugs referenced in the PR title without changing their state",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira unlink"},
+			}, {
+				Usage:       "/jira link-clone jiraBugKey",
+				Description: "Link an existing jira bug as a clone of the jira bug referenced in the PR title, the same way a cherrypick would, and retitle the PR to reference it",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira link-clone OCPBUGS-1234"},
+			}, {
+				Usage:       "/jira create",
+				Description: "Create a jira bug for a PR that does not reference a jira issue, using the PR title and description, and retitle the PR to reference it",
+				Featured:    false,
+				WhoCanUse:   "Collaborators on the repository",
+				Examples:    []string{"/jira create"},
+			}, {
+				Usage:       "/jira set-priority priority",
+				Description: "Set the priority of the jira bugs referenced in the PR title. Valid priorities are: Blocker, Critical, Major, Normal, Minor, Undefined",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira set-priority Critical"},
+			}, {
+				Usage:       "/jira set-version version",
+				Description: "Set the target version of the jira bugs referenced in the PR title",
+				Featured:    false,
+				WhoCanUse:   "Collaborators on the repository",
+				Examples:    []string{"/jira set-version 4.16.0"},
+			}, {
+				Usage:       "/jira set-fixversion version",
+				Description: "Set the fix version of the jira bugs referenced in the PR title",
+				Featured:    false,
+				WhoCanUse:   "Collaborators on the repository",
+				Examples:    []string{"/jira set-fixversion 4.16.0"},
+			}, {
+				Usage:       "/jira move state[:resolution]",
+				Description: "Transition the jira bugs referenced in the PR title to the provided state, optionally setting the provided resolution",
+				Featured:    false,
+				WhoCanUse:   "Collaborators on the repository",
+				Examples:    []string{"/jira move VERIFIED", "/jira move CLOSED:DONE"},
+			}, {
+				Usage:       "/jira status",
+				Description: "Show the current status, resolution, target version, and assignee of the jira bugs referenced in the PR title",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira status"},
+			}, {
+				Usage:       "/jira comment text",
+				Description: "Add a comment containing the provided text to the jira issues referenced in the PR title. All text following the command, including further lines, is included in the comment",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira comment This was verified on the nightly build."},
+			},
+			{
+				Usage:       "/jira finalize",
+				Description: "Re-run the handling of the merge of the PR, moving the jira bugs referenced in the PR title to the state after merge once all linked PRs have merged. Useful when the merge event was missed",
+				Featured:    false,
+				WhoCanUse:   "Anyone",
+				Examples:    []string{"/jira finalize"},
+			},
+		},
+	}
+
+	if actual := help; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("resolved incorrect plugin help: %v", cmp.Diff(actual, expected, allowEventAndDate))
+	}
+}
+
+func TestDigestPR(t *testing.T) {
+	yes := true
+	minimumSeverity := importantSeverity
+	var testCases = []struct {
+		name                             string
+		pre                              github.PullRequestEvent
+		validateByDefault                *bool
+		validateByDefaultMinimumSeverity *string
+		referencesFromBody               *bool
+		pullRequestActions               *[]string
+		skipDrafts                       *bool
+		expected                         *event
+		expectedErr                      bool
+	}{
+		{
+			name: "unrelated event gets ignored",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestFileAdded,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number: 1,
+					Title:  "OCPBUGS-123: fixed it!",
+					State:  "open",
+				},
+			},
+		},
+		{
+			name: "unrelated title gets ignored",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number: 1,
+					Title:  "fixing a typo",
+					State:  "open",
+				},
+			},
+		},
+		{
+			name: "unrelated title gets handled when validating by default",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "fixing a typo",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			validateByDefault: &yes,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", missing: true, opened: true, issues: nil, title: "fixing a typo", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "unrelated title gets ignored when validating by default depends on severity",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "fixing a typo",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			validateByDefault:                &yes,
+			validateByDefaultMinimumSeverity: &minimumSeverity,
+		},
+		{
+			name: "body reference gets an event when the title has none and body references are enabled",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "fixing a crash",
+					Body:    "This change Fixes OCPBUGS-123 and fixes ocpbugs-123.",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			referencesFromBody: &yes,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "fixing a crash", body: "This change Fixes OCPBUGS-123 and fixes ocpbugs-123.", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "body reference gets ignored when body references are disabled",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "fixing a crash",
+					Body:    "Fixes OCPBUGS-123",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+		},
+		{
+			name: "title reference takes precedence over body reference",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-456: fixing a crash",
+					Body:    "Fixes OCPBUGS-123",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			referencesFromBody: &yes,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "456", IsBug: true}}, title: "OCPBUGS-456: fixing a crash", body: "Fixes OCPBUGS-123", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "reopened PR referencing bug gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionReopened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+				Changes: []byte(`{}`),
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", reopened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing bug gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "action that is not one of the configured pull request actions gets ignored",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			pullRequestActions: &[]string{"opened", "edited", "closed"},
+		},
+		{
+			name: "action that is one of the configured pull request actions gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			pullRequestActions: &[]string{"opened", "edited", "closed"},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "draft PR marked ready for review gets an event when drafts are skipped",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionReadyForReview,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			skipDrafts: &yes,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "draft PR marked ready for review is ignored when drafts are not skipped",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionReadyForReview,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+		},
+		{
+			name: "draft PR gets an event marked as a draft",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					State:   "open",
+					Draft:   true,
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", draft: true, opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing DFBUGS bug gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "DFBUGS-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "DFBUGS", ID: "123", IsBug: true}}, title: "DFBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing issue with alphanumeric project name gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCP123BUGS-456: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCP123BUGS", ID: "456"}}, title: "OCP123BUGS-456: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing multiple bugs gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123,OCPBUGS-124: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}}, title: "OCPBUGS-123,OCPBUGS-124: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing bug and jira issue gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123,JIRA-123: fixed it!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "JIRA", ID: "123", IsBug: false}}, title: "OCPBUGS-123,JIRA-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing non-bug jira gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "SOMEJIRA-123: implement feature!",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "SOMEJIRA", ID: "123", IsBug: false}}, title: "SOMEJIRA-123: implement feature!", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title explicitly referencing no-issue gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "NO-ISSUE: typo fixup",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: nil, noJira: true, title: "NO-ISSUE: typo fixup", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing no-jira gets an event",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "NO-JIRA: typo fixup",
+					State:   "open",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: nil, noJira: true, title: "NO-JIRA: typo fixup", htmlUrl: "http.com", login: "user", author: "user",
+			},
+		},
+		{
+			name: "title referencing bug gets an event on PR merge",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionClosed,
+				PullRequest: github.PullRequest{
+					Merged: true,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, merged: true, closed: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
 		},
 		{
-			name: "code section is not replaced",
-			body: `This change:
-is very important` + "\n```bash\n" +
-				`ABC-123` +
-				"\n```\n" + `ABC-123
-`,
-			expected: `This change:
-is very important` + "\n```bash\n" +
-				`ABC-123` +
-				"\n```\n" + `[ABC-123](https://my-jira.com/browse/ABC-123)
-`,
+			name: "title referencing bug gets an event on PR close",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionClosed,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123: fixed it!",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, merged: false, closed: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
 		},
 		{
-			name: "inline code is not replaced",
-			body: `This change:
-is very important` + "\n``ABC-123`` and `ABC-123` shouldn't be replaced, as well as ``ABC-123: text text``. " +
-				`ABC-123 should be replaced.
-`,
-			expected: `This change:
-is very important` + "\n``ABC-123`` and `ABC-123` shouldn't be replaced, as well as ``ABC-123: text text``. " +
-				`[ABC-123](https://my-jira.com/browse/ABC-123) should be replaced.
-`,
+			name: "title referencing the same bug twice gets an event for the bug once",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionClosed,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "branch",
+					},
+					Number:  1,
+					Title:   "OCPBUGS-123,OCPBUGS-123: fixed it!",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, merged: false, closed: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123,OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
 		},
 		{
-			name:     "Multiline codeblock that is denoted through four leading spaces",
-			body:     "I meant to do this test:\r\n\r\n    operator_test.go:1914: failed to read output from pod unique-id-header-test-1: container \"curl\" in pod \"unique-id-header-ABC-123\" is waiting to start: ContainerCreating\r\n\r\n",
-			expected: "I meant to do this test:\r\n\r\n    operator_test.go:1914: failed to read output from pod unique-id-header-test-1: container \"curl\" in pod \"unique-id-header-ABC-123\" is waiting to start: ContainerCreating\r\n\r\n",
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			if diff := cmp.Diff(insertLinksIntoComment(tc.body, []string{issueName}, fakejira.FakeJiraUrl), tc.expected); diff != "" {
-				t.Errorf("actual result differs from expected result: %s", diff)
-			}
-		})
-	}
-}
-
-func TestHelpProvider(t *testing.T) {
-	rawConfig := `disabled_jira_projects:
-- "private-project"
-default:
-  "*":
-    target_version: global-default
-  "global-branch":
-    is_open: false
-    target_version: global-branch-default
-orgs:
-  my-org:
-    default:
-      "*":
-        is_open: true
-        target_version: my-org-default
-        state_after_validation:
-          status: "PRE"
-      "my-org-branch":
-        target_version: my-org-branch-default
-        state_after_validation:
-          status: "POST"
-        add_external_link: true
-    repos:
-      my-repo:
-        branches:
-          "*":
-            is_open: false
-            target_version: my-repo-default
-            valid_states:
-            - status: VALIDATED
-          "my-repo-branch":
-            target_version: my-repo-branch
-            valid_states:
-            - status: MODIFIED
-            add_external_link: true
-            state_after_merge:
-              status: MODIFIED
-            allowed_security_levels:
-            - default
-          "branch-that-likes-closed-bugs":
-            valid_states:
-            - status: VERIFIED
-            - status: CLOSED
-              resolution: ERRATA
-            dependent_bug_states:
-            - status: CLOSED
-              resolution: ERRATA
-            state_after_merge:
-              status: CLOSED
-              resolution: FIXED
-            state_after_validation:
-              status: CLOSED
-              resolution: VALIDATED`
-
-	var config Config
-	if err := yaml.Unmarshal([]byte(rawConfig), &config); err != nil {
-		t.Fatalf("couldn't unmarshal config: %v", err)
-	}
-	enabledRepos := []prowconfig.OrgRepo{
-		{Org: "some-org", Repo: "some-repo"},
-		{Org: "my-org", Repo: "some-repo"},
-		{Org: "my-org", Repo: "my-repo"},
-	}
-	serv := &server{
-		config: func() *Config {
-			return &config
-		},
-	}
-	help, err := serv.helpProvider(enabledRepos)
-	if err != nil {
-		t.Fatalf("unexpected error creating help provider: %v", err)
-	}
-	// don't check snippet
-	help.Snippet = ""
+			name: "non-jira cherrypick PR sets e.missing to true",
+			pre: github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				PullRequest: github.PullRequest{
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "org",
+							},
+							Name: "repo",
+						},
+						Ref: "release-4.4",
+					},
+					Number:  3,
+					Title:   "[release-4.4] fixing a typo",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+					Body: `This is an automated cherry-pick of #2
 
-	expected := &pluginhelp.PluginHelp{
-		Description: "The jira plugin ensures that pull requests reference a valid Jira bug in their title.",
-		Config: map[string]string{
-			"some-org/some-repo": `The plugin has the following configuration:<ul>
-<li>by default, valid bugs must target the "global-default" version.</li>
-<li>on the "global-branch" branch, valid bugs must be closed and target the "global-branch-default" version.</li>
-</ul>`,
-			"my-org/some-repo": `The plugin has the following configuration:<ul>
-<li>by default, valid bugs must be open and target the "my-org-default" version. After being linked to a pull request, bugs will be moved to the PRE state.</li>
-<li>on the "my-org-branch" branch, valid bugs must be open and target the "my-org-branch-default" version. After being linked to a pull request, bugs will be moved to the POST state and updated to refer to the pull request using the external bug tracker.</li>
-</ul>`,
-			"my-org/my-repo": `The plugin has the following configuration:<ul>
-<li>by default, valid bugs must be closed, target the "my-repo-default" version, and be in one of the following states: VALIDATED. After being linked to a pull request, bugs will be moved to the PRE state.</li>
-<li>on the "branch-that-likes-closed-bugs" branch, valid bugs must be closed, target the "my-repo-default" version, be in one of the following states: VERIFIED, CLOSED (ERRATA), depend on at least one other bug, and have all dependent bugs in one of the following states: CLOSED (ERRATA). After being linked to a pull request, bugs will be moved to the CLOSED (VALIDATED) state and moved to the CLOSED (FIXED) state when all linked pull requests are merged.</li>
-<li>on the "my-org-branch" branch, valid bugs must be closed, target the "my-repo-default" version, and be in one of the following states: VALIDATED. After being linked to a pull request, bugs will be moved to the POST state and updated to refer to the pull request using the external bug tracker.</li>
-<li>on the "my-repo-branch" branch, valid bugs must be closed, target the "my-repo-branch" version, and be in one of the following states: MODIFIED. After being linked to a pull request, bugs will be moved to the PRE state, updated to refer to the pull request using the external bug tracker, and moved to the MODIFIED state when all linked pull requests are merged.</li>
-</ul>`,
-		},
-		Commands: []pluginhelp.Command{
-			{
-				Usage:       "/jira refresh",
-				Description: "Check Jira for a valid bug referenced in the PR title",
-				Featured:    false,
-				WhoCanUse:   "Anyone",
-				Examples:    []string{"/jira refresh"},
-			}, {
-				Usage:       "/jira cc-qa",
-				Description: "Request PR review from QA contact specified in Jira",
-				Featured:    false,
-				WhoCanUse:   "Anyone",
-				Examples:    []string{"/jira cc-qa"},
-			}, {
-				Usage:       "/jira cherrypick jiraBugKey",
-				Description: "Cherrypick a jira bug and link it to the current PR",
-				Featured:    false,
-				WhoCanUse:   "Anyone",
-				Examples:    []string{"/jira cherrypick OCPBUGS-1234"},
+/assign user`,
+				},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "release-4.4", number: 3, opened: true, body: "This is an automated cherry-pick of #2\n\n/assign user", title: "[release-4.4] fixing a typo", htmlUrl: "http.com", login: "user", author: "user", cherrypick: true, cherrypickFromPRNum: 2, missing: true,
 			},
 		},
-	}
-
-	if actual := help; !reflect.DeepEqual(actual, expected) {
-		t.Errorf("resolved incorrect plugin help: %v", cmp.Diff(actual, expected, allowEventAndDate))
-	}
-}
-
-func TestDigestPR(t *testing.T) {
-	yes := true
-	var testCases = []struct {
-		name              string
-		pre               github.PullRequestEvent
-		validateByDefault *bool
-		expected          *event
-		expectedErr       bool
-	}{
 		{
-			name: "unrelated event gets ignored",
+			name: "cherrypicked PR gets cherrypick event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestFileAdded,
+				Action: github.PullRequestActionOpened,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4044,18 +9382,27 @@ func TestDigestPR(t *testing.T) {
 							},
 							Name: "repo",
 						},
-						Ref: "branch",
+						Ref: "release-4.4",
 					},
-					Number: 1,
-					Title:  "OCPBUGS-123: fixed it!",
-					State:  "open",
+					Number:  3,
+					Title:   "[release-4.4] OCPBUGS-123: fixed it!",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
+					},
+					Body: `This is an automated cherry-pick of #2
+
+/assign user`,
 				},
 			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "release-4.4", number: 3, opened: true, body: "This is an automated cherry-pick of #2\n\n/assign user", title: "[release-4.4] OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user", cherrypick: true, cherrypickFromPRNum: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}},
+			},
 		},
 		{
-			name: "unrelated title gets ignored",
+			name: "edited cherrypicked PR gets normal event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
+				Action: github.PullRequestActionEdited,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4064,16 +9411,25 @@ func TestDigestPR(t *testing.T) {
 							},
 							Name: "repo",
 						},
-						Ref: "branch",
+						Ref: "release-4.4",
+					},
+					Number:  3,
+					Title:   "[release-4.4] OCPBUGS-123: fixed it!",
+					HTMLURL: "http.com",
+					User: github.User{
+						Login: "user",
 					},
-					Number: 1,
-					Title:  "fixing a typo",
-					State:  "open",
+					Body: `This is an automated cherry-pick of #2
+
+/assign user`,
 				},
 			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "release-4.4", number: 3, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This is an automated cherry-pick of #2\n\n/assign user", title: "[release-4.4] OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
+			},
 		},
 		{
-			name: "unrelated title gets handled when validating by default",
+			name: "title change referencing same bug gets no event",
 			pre: github.PullRequestEvent{
 				Action: github.PullRequestActionOpened,
 				PullRequest: github.PullRequest{
@@ -4087,21 +9443,17 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "fixing a typo",
-					State:   "open",
+					Title:   "OCPBUGS-123: fixed it!",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
-			},
-			validateByDefault: &yes,
-			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", missing: true, opened: true, issues: nil, title: "fixing a typo", htmlUrl: "http.com", login: "user",
+				Changes: []byte(`{"title":{"from":"OCPBUGS-123: fixed it! (WIP)"}}`),
 			},
 		},
 		{
-			name: "title referencing bug gets an event",
+			name: "title change referencing new bug gets event",
 			pre: github.PullRequestEvent{
 				Action: github.PullRequestActionOpened,
 				PullRequest: github.PullRequest{
@@ -4116,19 +9468,19 @@ func TestDigestPR(t *testing.T) {
 					},
 					Number:  1,
 					Title:   "OCPBUGS-123: fixed it!",
-					State:   "open",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
+				Changes: []byte(`{"title":{"from":"fixed it! (WIP)"}}`),
 			},
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
 			},
 		},
 		{
-			name: "title referencing DFBUGS bug gets an event",
+			name: "title change dereferencing bug gets event",
 			pre: github.PullRequestEvent{
 				Action: github.PullRequestActionOpened,
 				PullRequest: github.PullRequest{
@@ -4142,20 +9494,20 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "DFBUGS-123: fixed it!",
-					State:   "open",
+					Title:   "fixed it!",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
+				Changes: []byte(`{"title":{"from":"OCPBUGS-123: fixed it! (WIP)"}}`),
 			},
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "DFBUGS", ID: "123", IsBug: true}}, title: "DFBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, opened: true, missing: true, title: "fixed it!", htmlUrl: "http.com", login: "user", author: "user",
 			},
 		},
 		{
-			name: "title referencing issue with alphanumeric project name gets an event",
+			name: "title change to no bug with unrelated changes gets no event",
 			pre: github.PullRequestEvent{
 				Action: github.PullRequestActionOpened,
 				PullRequest: github.PullRequest{
@@ -4169,22 +9521,19 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "OCP123BUGS-456: fixed it!",
-					State:   "open",
+					Title:   "fixed it!",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
-			},
-			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCP123BUGS", ID: "456"}}, title: "OCP123BUGS-456: fixed it!", htmlUrl: "http.com", login: "user",
+				Changes: []byte(`{"oops":{"doops":"payload"}}`),
 			},
 		},
 		{
-			name: "title referencing multiple bugs gets an event",
+			name: "qe-approved labeling gets event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
+				Action: github.PullRequestActionLabeled,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4196,22 +9545,25 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "OCPBUGS-123,OCPBUGS-124: fixed it!",
+					Title:   "OCPBUGS-123: fixed it!",
 					State:   "open",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
+				Label: github.Label{
+					Name: labels.QEApproved,
+				},
 			},
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}}, title: "OCPBUGS-123,OCPBUGS-124: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: false, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
 			},
 		},
 		{
-			name: "title referencing bug and jira issue gets an event",
+			name: "qe-approved unlabeling gets event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
+				Action: github.PullRequestActionUnlabeled,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4223,22 +9575,25 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "OCPBUGS-123,JIRA-123: fixed it!",
+					Title:   "OCPBUGS-123: fixed it!",
 					State:   "open",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
+				Label: github.Label{
+					Name: labels.QEApproved,
+				},
 			},
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "JIRA", ID: "123", IsBug: false}}, title: "OCPBUGS-123,JIRA-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: false, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user",
 			},
 		},
 		{
-			name: "title referencing non-bug jira gets an event",
+			name: "non qe-approved labeling does not get event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
+				Action: github.PullRequestActionLabeled,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4250,22 +9605,22 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "SOMEJIRA-123: implement feature!",
+					Title:   "OCPBUGS-123: fixed it!",
 					State:   "open",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
-			},
-			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: []referencedIssue{{Project: "SOMEJIRA", ID: "123", IsBug: false}}, title: "SOMEJIRA-123: implement feature!", htmlUrl: "http.com", login: "user",
+				Label: github.Label{
+					Name: labels.JiraValidRef,
+				},
 			},
 		},
 		{
-			name: "title explicitly referencing no-issue gets an event",
+			name: "qe-approved unlabeling does not get event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
+				Action: github.PullRequestActionUnlabeled,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4277,22 +9632,22 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "NO-ISSUE: typo fixup",
+					Title:   "OCPBUGS-123: fixed it!",
 					State:   "open",
 					HTMLURL: "http.com",
 					User: github.User{
 						Login: "user",
 					},
 				},
-			},
-			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: nil, noJira: true, title: "NO-ISSUE: typo fixup", htmlUrl: "http.com", login: "user",
+				Label: github.Label{
+					Name: labels.JiraValidRef,
+				},
 			},
 		},
 		{
-			name: "title referencing no-jira gets an event",
+			name: "pull request synchronized action adds fileChanged to event",
 			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
+				Action: github.PullRequestActionSynchronize,
 				PullRequest: github.PullRequest{
 					Base: github.PullRequestBranch{
 						Repo: github.Repo{
@@ -4304,7 +9659,7 @@ func TestDigestPR(t *testing.T) {
 						Ref: "branch",
 					},
 					Number:  1,
-					Title:   "NO-JIRA: typo fixup",
+					Title:   "OCPBUGS-123: fixed it!",
 					State:   "open",
 					HTMLURL: "http.com",
 					User: github.User{
@@ -4313,431 +9668,567 @@ func TestDigestPR(t *testing.T) {
 				},
 			},
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: true, issues: nil, noJira: true, title: "NO-JIRA: typo fixup", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: false, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", author: "user", fileChanged: true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			event, err := digestPR(logrus.WithField("testCase", testCase.name), testCase.pre, JiraBranchOptions{ValidateByDefault: testCase.validateByDefault, ValidateByDefaultMinimumSeverity: testCase.validateByDefaultMinimumSeverity, ReferencesFromBody: testCase.referencesFromBody, PullRequestActions: testCase.pullRequestActions, SkipDrafts: testCase.skipDrafts}, defaultBugProjects)
+			if err == nil && testCase.expectedErr {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if err != nil && !testCase.expectedErr {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, err)
+			}
+
+			if actual, expected := event, testCase.expected; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: did not get correct event: %v", testCase.name, cmp.Diff(actual, expected, allowEventAndDate))
+			}
+		})
+	}
+}
+
+func TestDigestComment(t *testing.T) {
+	yes := true
+	var testCases = []struct {
+		name               string
+		e                  github.IssueCommentEvent
+		title              string
+		prBody             string
+		referencesFromBody *bool
+		merged             bool
+		expected           *event
+		expectedComment    string
+		expectedErr        bool
+	}{
+		{
+			name: "unrelated event gets ignored",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionDeleted,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "OCPBUGS-123: oopsie doopsie",
+		},
+		{
+			name: "unrelated title gets an event saying so",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
+					User: github.User{
+						Login: "user",
+					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "cole, please review this typo fix",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, missing: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+			},
+		},
+		{
+			name: "body referencing bug gets an event when references from the body are enabled",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
+					User: github.User{
+						Login: "user",
+					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title:              "cole, please review this typo fix",
+			prBody:             "Fixes OCPBUGS-123",
+			referencesFromBody: &yes,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true,
+			},
+		},
+		{
+			name: "comment on issue gets no event but a comment",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number: 1,
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "someone misspelled words in this repo",
+			expectedComment: `org/repo#1:@: Jira bug referencing is only supported for Pull Requests, not issues.
+
+<details>
+
+In response to [this]():
+
+>/jira refresh
+
+
+Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
+</details>`,
+		},
+		{
+			name: "title referencing bug gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
+					User: github.User{
+						Login: "user",
+					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "OCPBUGS-123: oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+			},
+		},
+		{
+			name: "title referencing DFBUGS bug gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
+					User: github.User{
+						Login: "user",
+					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "DFBUGS-123: oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "DFBUGS", ID: "123", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
 		{
-			name: "title referencing bug gets an event on PR merge",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionClosed,
-				PullRequest: github.PullRequest{
-					Merged: true,
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					HTMLURL: "http.com",
+			name: "title referencing multiple bugs gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "OCPBUGS-123,OCPBUGS-124: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, merged: true, closed: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
 		{
-			name: "title referencing bug gets an event on PR close",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionClosed,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					HTMLURL: "http.com",
+			name: "title referencing bug and jira issue gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "OCPBUGS-123,JIRA-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, merged: false, closed: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "JIRA", ID: "123", IsBug: false}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
 		{
-			name: "non-jira cherrypick PR sets e.missing to true",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "release-4.4",
-					},
-					Number:  3,
-					Title:   "[release-4.4] fixing a typo",
-					HTMLURL: "http.com",
+			name: "title referencing jira gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
 					User: github.User{
 						Login: "user",
 					},
-					Body: `This is an automated cherry-pick of #2
-
-/assign user`,
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "SOMEJIRA-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "release-4.4", number: 3, opened: true, body: "This is an automated cherry-pick of #2\n\n/assign user", title: "[release-4.4] fixing a typo", htmlUrl: "http.com", login: "user", cherrypick: true, cherrypickFromPRNum: 2, missing: true,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "SOMEJIRA", ID: "123", IsBug: false}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
 		{
-			name: "cherrypicked PR gets cherrypick event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "release-4.4",
-					},
-					Number:  3,
-					Title:   "[release-4.4] OCPBUGS-123: fixed it!",
-					HTMLURL: "http.com",
+			name: "title referencing no-jira gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
 					User: github.User{
 						Login: "user",
 					},
-					Body: `This is an automated cherry-pick of #2
-
-/assign user`,
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "NO-JIRA: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "release-4.4", number: 3, opened: true, body: "This is an automated cherry-pick of #2\n\n/assign user", title: "[release-4.4] OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", cherrypick: true, cherrypickFromPRNum: 2, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}},
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: nil, noJira: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
 		{
-			name: "edited cherrypicked PR gets normal event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionEdited,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "release-4.4",
-					},
-					Number:  3,
-					Title:   "[release-4.4] OCPBUGS-123: fixed it!",
-					HTMLURL: "http.com",
+			name: "title referencing no-issue gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
 					User: github.User{
 						Login: "user",
 					},
-					Body: `This is an automated cherry-pick of #2
-
-/assign user`,
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "NO-ISSUE: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "release-4.4", number: 3, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "This is an automated cherry-pick of #2\n\n/assign user", title: "[release-4.4] OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: nil, noJira: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
 		{
-			name: "title change referencing same bug gets no event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					HTMLURL: "http.com",
+			name: "title referencing bug in a merged PR gets an event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira refresh",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-				Changes: []byte(`{"title":{"from":"OCPBUGS-123: fixed it! (WIP)"}}`),
-			},
-		},
-		{
-			name: "title change referencing new bug gets event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					HTMLURL: "http.com",
-					User: github.User{
-						Login: "user",
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
 					},
+					Name: "repo",
 				},
-				Changes: []byte(`{"title":{"from":"fixed it! (WIP)"}}`),
 			},
+			title:  "OCPBUGS-123: oopsie doopsie",
+			merged: true,
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, opened: true, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, merged: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
 			},
 		},
-		{
-			name: "title change dereferencing bug gets event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "fixed it!",
-					HTMLURL: "http.com",
+		{
+			name: "cc-qa comment event has cc bool set to true",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira cc-qa",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
+				},
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
-				Changes: []byte(`{"title":{"from":"OCPBUGS-123: fixed it! (WIP)"}}`),
 			},
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, opened: true, missing: true, title: "fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira cc-qa", htmlUrl: "www.com", login: "user", cc: true,
 			},
 		},
 		{
-			name: "title change to no bug with unrelated changes gets no event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionOpened,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "fixed it!",
-					HTMLURL: "http.com",
+			name: "cherrypick comment event has cherrypick bools set to true and correct bug key set",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira cherrypick OCPBUGS-1234",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-				Changes: []byte(`{"oops":{"doops":"payload"}}`),
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1234", IsBug: true}}, body: "/jira cherrypick OCPBUGS-1234", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: true, cherrypick: true,
 			},
 		},
 		{
-			name: "qe-approved labeling gets event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionLabeled,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					State:   "open",
-					HTMLURL: "http.com",
+			name: "cherrypick comment event has cherrypick bools set to true and correct bug key set even for non-bug issue and dash",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira cherry-pick OTHER-1234",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-				Label: github.Label{
-					Name: labels.QEApproved,
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: false, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OTHER", ID: "1234", IsBug: false}}, body: "/jira cherry-pick OTHER-1234", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: true, cherrypick: true,
 			},
 		},
 		{
-			name: "qe-approved unlabeling gets event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionUnlabeled,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					State:   "open",
-					HTMLURL: "http.com",
+			name: "cherrypick comment event for multiple bugs has cherrypick bools set to true and correct bug keys set",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira cherrypick OCPBUGS-1234,OTHER-1235",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-				Label: github.Label{
-					Name: labels.QEApproved,
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: false, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user",
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1234", IsBug: true}, {Project: "OTHER", ID: "1235", IsBug: false}}, body: "/jira cherrypick OCPBUGS-1234,OTHER-1235", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: true, cherrypick: true,
 			},
 		},
 		{
-			name: "non qe-approved labeling does not get event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionLabeled,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					State:   "open",
-					HTMLURL: "http.com",
+			name: "multiline cherrypick comment event",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira cherrypick OCPBUGS-1234\r\nThis is part of a\r\nmultiline comment",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-				Label: github.Label{
-					Name: labels.JiraValidRef,
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "OCPBUGS-123: oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1234", IsBug: true}}, body: "/jira cherrypick OCPBUGS-1234\r\nThis is part of a\r\nmultiline comment", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: false, cherrypick: true,
+			},
 		},
 		{
-			name: "qe-approved unlabeling does not get event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionUnlabeled,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					State:   "open",
-					HTMLURL: "http.com",
+			name: "backport comment event for multiple branches has backport bool set to true and correct branches set",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira backport release-4.16,release-4.15,release-4.14,release-4.13",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-				Label: github.Label{
-					Name: labels.JiraValidRef,
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
 				},
 			},
+			title: "OCPBUGS-123: oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira backport release-4.16,release-4.15,release-4.14,release-4.13", htmlUrl: "www.com", login: "user", backport: true, backportBranches: []string{"release-4.16", "release-4.15", "release-4.14", "release-4.13"},
+			},
 		},
 		{
-			name: "pull request synchronized action adds fileChanged to event",
-			pre: github.PullRequestEvent{
-				Action: github.PullRequestActionSynchronize,
-				PullRequest: github.PullRequest{
-					Base: github.PullRequestBranch{
-						Repo: github.Repo{
-							Owner: github.User{
-								Login: "org",
-							},
-							Name: "repo",
-						},
-						Ref: "branch",
-					},
-					Number:  1,
-					Title:   "OCPBUGS-123: fixed it!",
-					State:   "open",
-					HTMLURL: "http.com",
+			name: "backport-check comment event has backportCheck bool set to true and correct branches set",
+			e: github.IssueCommentEvent{
+				Action: github.IssueCommentActionCreated,
+				Issue: github.Issue{
+					Number:      1,
+					PullRequest: &struct{}{},
+				},
+				Comment: github.IssueComment{
+					Body: "/jira backport-check release-4.15,release-4.14",
 					User: github.User{
 						Login: "user",
 					},
+					HTMLURL: "www.com",
 				},
-			},
-			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, state: "open", opened: false, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, title: "OCPBUGS-123: fixed it!", htmlUrl: "http.com", login: "user", fileChanged: true,
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			event, err := digestPR(logrus.WithField("testCase", testCase.name), testCase.pre, testCase.validateByDefault)
-			if err == nil && testCase.expectedErr {
-				t.Errorf("%s: expected an error but got none", testCase.name)
-			}
-			if err != nil && !testCase.expectedErr {
-				t.Errorf("%s: expected no error but got one: %v", testCase.name, err)
-			}
-
-			if actual, expected := event, testCase.expected; !reflect.DeepEqual(actual, expected) {
-				t.Errorf("%s: did not get correct event: %v", testCase.name, cmp.Diff(actual, expected, allowEventAndDate))
-			}
-		})
-	}
-}
-
-func TestDigestComment(t *testing.T) {
-	var testCases = []struct {
-		name            string
-		e               github.IssueCommentEvent
-		title           string
-		merged          bool
-		expected        *event
-		expectedComment string
-		expectedErr     bool
-	}{
+				Repo: github.Repo{
+					Owner: github.User{
+						Login: "org",
+					},
+					Name: "repo",
+				},
+			},
+			title: "OCPBUGS-123: oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira backport-check release-4.15,release-4.14", htmlUrl: "www.com", login: "user", backportCheck: true, backportBranches: []string{"release-4.15", "release-4.14"},
+			},
+		},
 		{
-			name: "unrelated event gets ignored",
+			name: "finalize comment on merged PR gets finalize event",
 			e: github.IssueCommentEvent{
-				Action: github.IssueCommentActionDeleted,
+				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
 					Number:      1,
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/jira finalize",
+					User: github.User{
+						Login: "user",
+					},
+					HTMLURL: "www.com",
 				},
 				Repo: github.Repo{
 					Owner: github.User{
@@ -4746,10 +10237,14 @@ func TestDigestComment(t *testing.T) {
 					Name: "repo",
 				},
 			},
-			title: "OCPBUGS-123: oopsie doopsie",
+			title:  "OCPBUGS-123: oopsie doopsie",
+			merged: true,
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira finalize", htmlUrl: "www.com", login: "user", merged: true, finalize: true,
+			},
 		},
 		{
-			name: "unrelated title gets an event saying so",
+			name: "verified by comment with 1 item gets verification event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4757,7 +10252,7 @@ func TestDigestComment(t *testing.T) {
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified by @tester",
 					User: github.User{
 						Login: "user",
 					},
@@ -4770,20 +10265,25 @@ func TestDigestComment(t *testing.T) {
 					Name: "repo",
 				},
 			},
-			title: "cole, please review this typo fix",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, missing: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by @tester", htmlUrl: "www.com", login: "user", verify: []string{"@tester"},
 			},
 		},
 		{
-			name: "comment on issue gets no event but a comment",
+			name: "verified by comment with 2 items gets verification event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
-					Number: 1,
+					Number:      1,
+					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified by @tester,@tester2",
+					User: github.User{
+						Login: "user",
+					},
+					HTMLURL: "www.com",
 				},
 				Repo: github.Repo{
 					Owner: github.User{
@@ -4792,21 +10292,13 @@ func TestDigestComment(t *testing.T) {
 					Name: "repo",
 				},
 			},
-			title: "someone misspelled words in this repo",
-			expectedComment: `org/repo#1:@: Jira bug referencing is only supported for Pull Requests, not issues.
-
-<details>
-
-In response to [this]():
-
->/jira refresh
-
-
-Instructions for interacting with me using PR comments are available [here](https://prow.ci.openshift.org/command-help?repo=org%2Frepo).  If you have questions or suggestions related to my behavior, please file an issue against the [openshift-eng/jira-lifecycle-plugin](https://github.com/openshift-eng/jira-lifecycle-plugin/issues/new) repository.
-</details>`,
+			title: "OCPBUGS-123: oopsie doopsie",
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by @tester,@tester2", htmlUrl: "www.com", login: "user", verify: []string{"@tester", "@tester2"},
+			},
 		},
 		{
-			name: "title referencing bug gets an event",
+			name: "verified by comment with a plain reason gets verification event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4814,7 +10306,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified by automated-e2e",
 					User: github.User{
 						Login: "user",
 					},
@@ -4829,11 +10321,11 @@ Instructions for interacting with me using PR comments are available [here](http
 			},
 			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by automated-e2e", htmlUrl: "www.com", login: "user", verify: []string{"automated-e2e"},
 			},
 		},
 		{
-			name: "title referencing DFBUGS bug gets an event",
+			name: "verified by comment with a free-form reason keeps the reason verbatim",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4841,7 +10333,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified by ran the e2e suite, then checked the console",
 					User: github.User{
 						Login: "user",
 					},
@@ -4854,13 +10346,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "DFBUGS-123: oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "DFBUGS", ID: "123", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by ran the e2e suite, then checked the console", htmlUrl: "www.com", login: "user", verify: []string{"ran the e2e suite, then checked the console"},
 			},
 		},
 		{
-			name: "title referencing multiple bugs gets an event",
+			name: "verified by comment with mentions separated by spaces gets verification event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4868,7 +10360,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified by @tester, @tester2",
 					User: github.User{
 						Login: "user",
 					},
@@ -4881,13 +10373,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "OCPBUGS-123,OCPBUGS-124: oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by @tester, @tester2", htmlUrl: "www.com", login: "user", verify: []string{"@tester", "@tester2"},
 			},
 		},
 		{
-			name: "title referencing bug and jira issue gets an event",
+			name: "verified later comment verification later event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4895,7 +10387,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified later @tester",
 					User: github.User{
 						Login: "user",
 					},
@@ -4908,13 +10400,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "OCPBUGS-123,JIRA-123: oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}, {Project: "JIRA", ID: "123", IsBug: false}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified later @tester", htmlUrl: "www.com", login: "user", verifyLater: []string{"@tester"},
 			},
 		},
 		{
-			name: "title referencing jira gets an event",
+			name: "verified remove comment creates verified remove event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4922,7 +10414,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/verified remove",
 					User: github.User{
 						Login: "user",
 					},
@@ -4935,13 +10427,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "SOMEJIRA-123: oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "SOMEJIRA", ID: "123", IsBug: false}}, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified remove", htmlUrl: "www.com", login: "user", verifiedRemove: true,
 			},
 		},
 		{
-			name: "title referencing no-jira gets an event",
+			name: "set-priority comment creates set-priority event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4949,7 +10441,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/jira set-priority Major",
 					User: github.User{
 						Login: "user",
 					},
@@ -4962,13 +10454,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "NO-JIRA: oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: nil, noJira: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira set-priority Major", htmlUrl: "www.com", login: "user", priority: "Major",
 			},
 		},
 		{
-			name: "title referencing no-issue gets an event",
+			name: "set-version comment creates set-version event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -4976,7 +10468,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/jira set-version 4.16.0",
 					User: github.User{
 						Login: "user",
 					},
@@ -4989,13 +10481,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "NO-ISSUE: oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: nil, noJira: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira set-version 4.16.0", htmlUrl: "www.com", login: "user", targetVersion: "4.16.0",
 			},
 		},
 		{
-			name: "title referencing bug in a merged PR gets an event",
+			name: "create comment on a NO-ISSUE PR creates create event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5003,7 +10495,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira refresh",
+					Body: "/jira create",
 					User: github.User{
 						Login: "user",
 					},
@@ -5016,14 +10508,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title:  "OCPBUGS-123: oopsie doopsie",
-			merged: true,
+			title: "NO-ISSUE: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, merged: true, body: "/jira refresh", htmlUrl: "www.com", login: "user", refresh: true, cc: false,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, body: "/jira create", htmlUrl: "www.com", login: "user", noJira: true, create: true,
 			},
 		},
 		{
-			name: "cc-qa comment event has cc bool set to true",
+			name: "set-fixversion comment creates set-fixversion event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5031,7 +10522,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira cc-qa",
+					Body: "/jira set-fixversion 4.16.0",
 					User: github.User{
 						Login: "user",
 					},
@@ -5046,11 +10537,11 @@ Instructions for interacting with me using PR comments are available [here](http
 			},
 			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira cc-qa", htmlUrl: "www.com", login: "user", cc: true,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira set-fixversion 4.16.0", htmlUrl: "www.com", login: "user", fixVersion: "4.16.0",
 			},
 		},
 		{
-			name: "cherrypick comment event has cherrypick bools set to true and correct bug key set",
+			name: "status comment creates status event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5058,7 +10549,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira cherrypick OCPBUGS-1234",
+					Body: "/jira status",
 					User: github.User{
 						Login: "user",
 					},
@@ -5071,13 +10562,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1234", IsBug: true}}, body: "/jira cherrypick OCPBUGS-1234", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: true, cherrypick: true,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira status", htmlUrl: "www.com", login: "user", bugStatus: true,
 			},
 		},
 		{
-			name: "cherrypick comment event has cherrypick bools set to true and correct bug key set even for non-bug issue and dash",
+			name: "move comment with a resolution creates move event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5085,7 +10576,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira cherry-pick OTHER-1234",
+					Body: "/jira move CLOSED:Won't Do",
 					User: github.User{
 						Login: "user",
 					},
@@ -5098,13 +10589,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OTHER", ID: "1234", IsBug: false}}, body: "/jira cherry-pick OTHER-1234", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: true, cherrypick: true,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira move CLOSED:Won't Do", htmlUrl: "www.com", login: "user", move: &JiraBugState{Status: "CLOSED", Resolution: "Won't Do"},
 			},
 		},
 		{
-			name: "cherrypick comment event for multiple bugs has cherrypick bools set to true and correct bug keys set",
+			name: "multiline jira comment event keeps the full comment text",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5112,7 +10603,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira cherrypick OCPBUGS-1234,OTHER-1235",
+					Body: "/jira comment This is part of a\r\nmultiline comment\r\n",
 					User: github.User{
 						Login: "user",
 					},
@@ -5125,13 +10616,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "oopsie doopsie",
+			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1234", IsBug: true}, {Project: "OTHER", ID: "1235", IsBug: false}}, body: "/jira cherrypick OCPBUGS-1234,OTHER-1235", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: true, cherrypick: true,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira comment This is part of a\r\nmultiline comment\r\n", htmlUrl: "www.com", login: "user", jiraComment: "This is part of a\nmultiline comment",
 			},
 		},
 		{
-			name: "multiline cherrypick comment event",
+			name: "jira comment event ends the comment text at the next command",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5139,7 +10630,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira cherrypick OCPBUGS-1234\r\nThis is part of a\r\nmultiline comment",
+					Body: "/jira comment This is part of a\r\nmultiline comment\r\n/lgtm\r\n/hold",
 					User: github.User{
 						Login: "user",
 					},
@@ -5154,11 +10645,11 @@ Instructions for interacting with me using PR comments are available [here](http
 			},
 			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "1234", IsBug: true}}, body: "/jira cherrypick OCPBUGS-1234\r\nThis is part of a\r\nmultiline comment", htmlUrl: "www.com", login: "user", cherrypickCmd: true, missing: false, cherrypick: true,
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira comment This is part of a\r\nmultiline comment\r\n/lgtm\r\n/hold", htmlUrl: "www.com", login: "user", jiraComment: "This is part of a\nmultiline comment",
 			},
 		},
 		{
-			name: "backport comment event for multiple branches has backport bool set to true and correct branches set",
+			name: "jira comment without any text is ignored",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5166,7 +10657,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/jira backport release-4.16,release-4.15,release-4.14,release-4.13",
+					Body: "/jira comment",
 					User: github.User{
 						Login: "user",
 					},
@@ -5180,12 +10671,9 @@ Instructions for interacting with me using PR comments are available [here](http
 				},
 			},
 			title: "OCPBUGS-123: oopsie doopsie",
-			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira backport release-4.16,release-4.15,release-4.14,release-4.13", htmlUrl: "www.com", login: "user", backport: true, backportBranches: []string{"release-4.16", "release-4.15", "release-4.14", "release-4.13"},
-			},
 		},
 		{
-			name: "verified by comment with 1 item gets verification event",
+			name: "uncherrypick comment creates uncherrypick event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5193,7 +10681,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/verified by @tester",
+					Body: "/jira uncherrypick",
 					User: github.User{
 						Login: "user",
 					},
@@ -5206,13 +10694,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "OCPBUGS-123: oopsie doopsie",
+			title: "OCPBUGS-124: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by @tester", htmlUrl: "www.com", login: "user", verify: []string{"@tester"},
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira uncherrypick", htmlUrl: "www.com", login: "user", uncherrypick: true,
 			},
 		},
 		{
-			name: "verified by comment with 2 items gets verification event",
+			name: "unlink comment creates unlink event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5220,7 +10708,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/verified by @tester,@tester2",
+					Body: "/jira unlink",
 					User: github.User{
 						Login: "user",
 					},
@@ -5233,13 +10721,13 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "OCPBUGS-123: oopsie doopsie",
+			title: "OCPBUGS-124: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified by @tester,@tester2", htmlUrl: "www.com", login: "user", verify: []string{"@tester", "@tester2"},
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "124", IsBug: true}}, body: "/jira unlink", htmlUrl: "www.com", login: "user", unlink: true,
 			},
 		},
 		{
-			name: "verified later comment verification later event",
+			name: "link-clone comment creates link-clone event",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
@@ -5247,7 +10735,7 @@ Instructions for interacting with me using PR comments are available [here](http
 					PullRequest: &struct{}{},
 				},
 				Comment: github.IssueComment{
-					Body: "/verified later @tester",
+					Body: "/jira link-clone ocpbugs-124",
 					User: github.User{
 						Login: "user",
 					},
@@ -5262,19 +10750,18 @@ Instructions for interacting with me using PR comments are available [here](http
 			},
 			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified later @tester", htmlUrl: "www.com", login: "user", verifyLater: []string{"@tester"},
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/jira link-clone ocpbugs-124", htmlUrl: "www.com", login: "user", linkClone: "OCPBUGS-124",
 			},
 		},
 		{
-			name: "verified remove comment creates verified remove event",
+			name: "refresh-all command on an issue is digested without looking up a pull request",
 			e: github.IssueCommentEvent{
 				Action: github.IssueCommentActionCreated,
 				Issue: github.Issue{
-					Number:      1,
-					PullRequest: &struct{}{},
+					Number: 10,
 				},
 				Comment: github.IssueComment{
-					Body: "/verified remove",
+					Body: "/jira refresh-all",
 					User: github.User{
 						Login: "user",
 					},
@@ -5287,9 +10774,8 @@ Instructions for interacting with me using PR comments are available [here](http
 					Name: "repo",
 				},
 			},
-			title: "OCPBUGS-123: oopsie doopsie",
 			expected: &event{
-				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "/verified remove", htmlUrl: "www.com", login: "user", verifiedRemove: true,
+				org: "org", repo: "repo", number: 10, body: "/jira refresh-all", htmlUrl: "www.com", login: "user", refreshAll: true,
 			},
 		},
 	}
@@ -5298,10 +10784,11 @@ Instructions for interacting with me using PR comments are available [here](http
 		t.Run(testCase.name, func(t *testing.T) {
 			client := fakegithub.NewFakeClient()
 			client.PullRequests = map[int]*github.PullRequest{
-				1: {Base: github.PullRequestBranch{Ref: "branch"}, Title: testCase.title, Merged: testCase.merged},
+				1: {Base: github.PullRequestBranch{Ref: "branch"}, Title: testCase.title, Body: testCase.prBody, Merged: testCase.merged},
 			}
 			fakeClient := fakeGHClient{client}
-			event, err := digestComment(fakeClient, logrus.WithField("testCase", testCase.name), testCase.e)
+			cfg := &Config{Default: map[string]JiraBranchOptions{"*": {ReferencesFromBody: testCase.referencesFromBody}}}
+			event, err := digestComment(fakeClient, logrus.WithField("testCase", testCase.name), testCase.e, cfg)
 			if err == nil && testCase.expectedErr {
 				t.Errorf("%s: expected an error but got none", testCase.name)
 			}
@@ -5318,9 +10805,79 @@ Instructions for interacting with me using PR comments are available [here](http
 	}
 }
 
+func TestDigestReview(t *testing.T) {
+	pr := github.PullRequest{
+		Number: 1,
+		Title:  "OCPBUGS-123: oopsie doopsie",
+		Base:   github.PullRequestBranch{Ref: "branch"},
+		User:   github.User{Login: "author"},
+	}
+	repo := github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}
+	yes := true
+	options := JiraBranchOptions{QEReviewers: []string{"qe-user"}, ReferencesFromBody: &yes}
+	var testCases = []struct {
+		name     string
+		e        github.ReviewEvent
+		expected *event
+	}{
+		{
+			name: "approving review from QE reviewer gets verification event",
+			e: github.ReviewEvent{
+				Action:      github.ReviewActionSubmitted,
+				PullRequest: pr,
+				Repo:        repo,
+				Review:      github.Review{User: github.User{Login: "QE-User"}, Body: "looks good", State: github.ReviewStateApproved, HTMLURL: "www.com"},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "looks good", title: "OCPBUGS-123: oopsie doopsie", htmlUrl: "www.com", login: "QE-User", author: "author", verify: []string{"@QE-User"}, verifiedByReview: true,
+			},
+		},
+		{
+			name: "approving review of pull request referencing bug in the body gets verification event",
+			e: github.ReviewEvent{
+				Action:      github.ReviewActionSubmitted,
+				PullRequest: github.PullRequest{Number: 1, Title: "chore: cleanup", Body: "Fixes OCPBUGS-123", Base: github.PullRequestBranch{Ref: "branch"}, User: github.User{Login: "author"}},
+				Repo:        repo,
+				Review:      github.Review{User: github.User{Login: "qe-user"}, Body: "looks good", State: github.ReviewStateApproved, HTMLURL: "www.com"},
+			},
+			expected: &event{
+				org: "org", repo: "repo", baseRef: "branch", number: 1, issues: []referencedIssue{{Project: "OCPBUGS", ID: "123", IsBug: true}}, body: "looks good", title: "chore: cleanup", htmlUrl: "www.com", login: "qe-user", author: "author", verify: []string{"@qe-user"}, verifiedByReview: true,
+			},
+		},
+		{
+			name: "approving review from non-QE reviewer is ignored",
+			e: github.ReviewEvent{
+				Action:      github.ReviewActionSubmitted,
+				PullRequest: pr,
+				Repo:        repo,
+				Review:      github.Review{User: github.User{Login: "dev"}, State: github.ReviewStateApproved, HTMLURL: "www.com"},
+			},
+		},
+		{
+			name: "non-approving review from QE reviewer is ignored",
+			e: github.ReviewEvent{
+				Action:      github.ReviewActionSubmitted,
+				PullRequest: pr,
+				Repo:        repo,
+				Review:      github.Review{User: github.User{Login: "qe-user"}, State: github.ReviewStateChangesRequested, HTMLURL: "www.com"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			event := digestReview(logrus.WithField("testCase", testCase.name), testCase.e, options, defaultBugProjects)
+			if actual, expected := event, testCase.expected; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: did not get correct event: %v", testCase.name, cmp.Diff(actual, expected, allowEventAndDate))
+			}
+		})
+	}
+}
+
 func TestBugKeyFromTitle(t *testing.T) {
 	var testCases = []struct {
 		title            string
+		bugProjects      []string
 		expectedRefBugs  []referencedIssue
 		expectedNotFound bool
 		expectedNoJira   bool
@@ -5409,10 +10966,48 @@ func TestBugKeyFromTitle(t *testing.T) {
 			expectedRefBugs: nil,
 			expectedNoJira:  true,
 		},
+		{
+			title:           "MYBUGS-12,OCPBUGS-13: Custom bug projects",
+			bugProjects:     []string{"MYBUGS"},
+			expectedRefBugs: []referencedIssue{{Project: "MYBUGS", ID: "12", IsBug: true}, {Project: "OCPBUGS", ID: "13", IsBug: false}},
+		},
+		{
+			title:           "MYBUGS-12: Custom bug project not configured",
+			expectedRefBugs: []referencedIssue{{Project: "MYBUGS", ID: "12", IsBug: false}},
+		},
+		{
+			title:           "OCPBUGS-12,OCPBUGS-12: Repeated",
+			expectedRefBugs: []referencedIssue{{Project: "OCPBUGS", ID: "12", IsBug: true}},
+		},
+		{
+			title:           "OCPBUGS-13,JIRA-12,OCPBUGS-13,JIRA-12,OCPBUGS-12: Repeated bug and non-bug references",
+			expectedRefBugs: []referencedIssue{{Project: "OCPBUGS", ID: "13", IsBug: true}, {Project: "JIRA", ID: "12", IsBug: false}, {Project: "OCPBUGS", ID: "12", IsBug: true}},
+		},
+		{
+			title:           "OCPBUGS-12,ocpbugs-12: Repeated with different case",
+			expectedRefBugs: []referencedIssue{{Project: "OCPBUGS", ID: "12", IsBug: true}},
+		},
+		{
+			title:           "ocpbugs-12: Lowercase",
+			expectedRefBugs: []referencedIssue{{Project: "OCPBUGS", ID: "12", IsBug: true}},
+		},
+		{
+			title:           "OcpBugs-12,jira-13: Mixed case",
+			expectedRefBugs: []referencedIssue{{Project: "OCPBUGS", ID: "12", IsBug: true}, {Project: "JIRA", ID: "13", IsBug: false}},
+		},
+		{
+			title:           "[rebase release-1.0] ocpbugs-12: Lowercase prefix",
+			expectedRefBugs: []referencedIssue{{Project: "OCPBUGS", ID: "12", IsBug: true}},
+		},
+		{
+			title:           "Revert: \"dfbugs-12: Lowercase revert\"",
+			expectedRefBugs: []referencedIssue{{Project: "DFBUGS", ID: "12", IsBug: true}},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.title, func(t *testing.T) {
-			bugs, notFound, noJira := jiraKeyFromTitle(testCase.title)
+			config := Config{BugProjects: testCase.bugProjects}
+			bugs, notFound, noJira := jiraKeyFromTitle(testCase.title, config.BugProjectSet())
 			if diff := cmp.Diff(bugs, testCase.expectedRefBugs); diff != "" {
 				t.Errorf("%s: incorrect bugs: %v", testCase.title, diff)
 			}
@@ -5426,9 +11021,47 @@ func TestBugKeyFromTitle(t *testing.T) {
 	}
 }
 
+func TestCloneTitle(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		title       string
+		retitleList map[string]string
+		expected    string
+	}{
+		{
+			name:        "canonical key is replaced",
+			title:       "OCPBUGS-12: fixed it!",
+			retitleList: map[string]string{"OCPBUGS-12": "OCPBUGS-13"},
+			expected:    "OCPBUGS-13: fixed it!",
+		},
+		{
+			name:        "lowercase key is replaced",
+			title:       "ocpbugs-12: fixed it!",
+			retitleList: map[string]string{"OCPBUGS-12": "OCPBUGS-13"},
+			expected:    "OCPBUGS-13: fixed it!",
+		},
+		{
+			name:        "mixed case keys are all replaced",
+			title:       "OcpBugs-12,OCPBUGS-14: fixed it!",
+			retitleList: map[string]string{"OCPBUGS-12": "OCPBUGS-13", "OCPBUGS-14": "OCPBUGS-15"},
+			expected:    "OCPBUGS-13,OCPBUGS-15: fixed it!",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := cloneTitle(event{title: testCase.title}, testCase.retitleList); actual != testCase.expected {
+				t.Errorf("%s: expected title %q, got %q", testCase.name, testCase.expected, actual)
+			}
+		})
+	}
+}
+
 func TestValidateBug(t *testing.T) {
 	yes, no := true, false
 	oneStr, twoStr, threeStr := "v1", "v2", "v3"
+	zStreamStr, prefixedZStreamStr, otherZStreamStr := "4.13.5", "openshift-4.13.2", "4.14.1"
+	activeSprint := "com.atlassian.greenhopper.service.sprint.Sprint@11b54434[id=57955,rapidViewId=14885,state=ACTIVE,name=uShift Sprint 248,startDate=2024-01-15T09:00:00.000Z,endDate=2024-02-05T09:00:00.000Z,completeDate=<null>,activatedDate=2024-01-15T08:17:37.677Z,sequence=57955,goal=,autoStartStop=false,synced=false]"
+	closedSprint := "com.atlassian.greenhopper.service.sprint.Sprint@57a3e8ba[id=57484,rapidViewId=14885,state=CLOSED,name=uShift Sprint 247,startDate=2023-12-25T17:07:00.000Z,endDate=2024-01-15T17:07:00.000Z,completeDate=2024-01-15T08:15:40.614Z,activatedDate=2023-12-25T14:11:56.948Z,sequence=57484,goal=,autoStartStop=false,synced=false]"
 	one := []*jira.Version{{Name: "v1"}}
 	two := []*jira.Version{{Name: "v2"}}
 	dfbugsOne := []*jira.Version{{Name: "odf-v1.1.z"}}
@@ -5438,12 +11071,15 @@ func TestValidateBug(t *testing.T) {
 	verified := JiraBugState{Status: "VERIFIED"}
 	modified := JiraBugState{Status: "MODIFIED"}
 	updated := JiraBugState{Status: "UPDATED"}
+	embargoed := "Embargoed"
 	var testCases = []struct {
 		name        string
 		issue       *jira.Issue
 		dependents  []dependent
+		blockers    []dependent
 		options     JiraBranchOptions
 		valid       bool
+		incomplete  bool
 		validations []string
 		why         []string
 	}{
@@ -5481,6 +11117,56 @@ func TestValidateBug(t *testing.T) {
 			valid:   false,
 			why:     []string{"expected the bug to not be open, but it is"},
 		},
+		{
+			name:    "closed bug is invalid when closed bugs are rejected",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{Status: &jira.Status{Name: "CLOSED"}}},
+			options: JiraBranchOptions{RejectClosedBugs: &yes},
+			valid:   false,
+			why:     []string{"expected the bug to not be closed, but it is in state CLOSED"},
+		},
+		{
+			name:        "open bug is valid when closed bugs are rejected",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{Status: &jira.Status{Name: "POST"}}},
+			options:     JiraBranchOptions{RejectClosedBugs: &yes},
+			valid:       true,
+			validations: []string{"bug is not closed"},
+		},
+		{
+			name: "matching required security level means a valid bug",
+			issue: &jira.Issue{Fields: &jira.IssueFields{
+				Unknowns: tcontainer.MarshalMap{
+					"security": jiraclient.SecurityLevel{Name: "Embargoed"},
+				},
+			}},
+			options:     JiraBranchOptions{RequiredSecurityLevel: &embargoed},
+			valid:       true,
+			validations: []string{`bug has the required "Embargoed" security level`},
+		},
+		{
+			name: "mismatching required security level means an invalid bug",
+			issue: &jira.Issue{Fields: &jira.IssueFields{
+				Unknowns: tcontainer.MarshalMap{
+					"security": jiraclient.SecurityLevel{Name: "Restricted"},
+				},
+			}},
+			options: JiraBranchOptions{RequiredSecurityLevel: &embargoed},
+			valid:   false,
+			why:     []string{`expected the bug to have the "Embargoed" security level, but it has the "Restricted" security level instead`},
+		},
+		{
+			name:       "unset security level with a required security level means an incomplete bug",
+			issue:      &jira.Issue{Fields: &jira.IssueFields{}},
+			options:    JiraBranchOptions{RequiredSecurityLevel: &embargoed},
+			valid:      false,
+			incomplete: true,
+			why:        []string{`expected the bug to have the "Embargoed" security level, but no security level was set`},
+		},
+		{
+			name:    "closed bug is valid when closed bugs are not rejected",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{Status: &jira.Status{Name: "CLOSED"}}},
+			options: JiraBranchOptions{RejectClosedBugs: &no},
+			valid:   true,
+		},
 		{
 			name: "matching release notes requirement means a valid bug",
 			issue: &jira.Issue{Fields: &jira.IssueFields{
@@ -5560,9 +11246,10 @@ func TestValidateBug(t *testing.T) {
 					Name: "Bug",
 				},
 			}},
-			options: JiraBranchOptions{TargetVersion: &oneStr},
-			valid:   false,
-			why:     []string{"expected the bug to target the \"v1\" version, but no target version was set"},
+			options:    JiraBranchOptions{TargetVersion: &oneStr},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the bug to target the \"v1\" version, but no target version was set"},
 		},
 		{
 			name:        "matching status requirement means a valid bug",
@@ -5609,13 +11296,35 @@ func TestValidateBug(t *testing.T) {
 			why:         []string{"expected dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to be in one of the following states: VERIFIED, but it is MODIFIED instead"},
 		},
 		{
-			name:        "not matching dependent bug target version requirement means an invalid bug",
+			name:        "not matching dependent bug target version requirement means an invalid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}},
+			dependents:  []dependent{{key: "OCPBUGS-124", bugState: JiraBugState{Status: "MODIFIED"}, targetVersion: &twoStr}},
+			options:     JiraBranchOptions{DependentBugTargetVersions: &[]string{oneStr}},
+			valid:       false,
+			validations: []string{"bug has dependents"},
+			why:         []string{"expected dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to target a version in v1.*, openshift-v1.*, but it targets \"v2\" instead"},
+		},
+		{
+			name:       "dependent bugs targeting z-stream versions of an allowed version, with or without the prefix, means a valid bug",
+			issue:      &jira.Issue{Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}},
+			dependents: []dependent{{key: "OCPBUGS-124", targetVersion: &zStreamStr}, {key: "OCPBUGS-125", targetVersion: &prefixedZStreamStr}, {key: "OCPBUGS-126", targetVersion: &twoStr}},
+			options:    JiraBranchOptions{DependentBugTargetVersions: &[]string{"4.13.0", "4.13.z", twoStr}},
+			valid:      true,
+			validations: []string{
+				`dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) targets the "4.13.5" version, which is one of the valid target versions: 4.13.*, openshift-4.13.*, v2.*, openshift-v2.*`,
+				`dependent [Jira Issue OCPBUGS-125](https://my-jira.com/browse/OCPBUGS-125) targets the "openshift-4.13.2" version, which is one of the valid target versions: 4.13.*, openshift-4.13.*, v2.*, openshift-v2.*`,
+				`dependent [Jira Issue OCPBUGS-126](https://my-jira.com/browse/OCPBUGS-126) targets the "v2" version, which is one of the valid target versions: 4.13.*, openshift-4.13.*, v2.*, openshift-v2.*`,
+				"bug has dependents",
+			},
+		},
+		{
+			name:        "dependent bug targeting a z-stream version of another version means an invalid bug",
 			issue:       &jira.Issue{Fields: &jira.IssueFields{Project: jira.Project{Key: "OCPBUGS"}}},
-			dependents:  []dependent{{key: "OCPBUGS-124", bugState: JiraBugState{Status: "MODIFIED"}, targetVersion: &twoStr}},
-			options:     JiraBranchOptions{DependentBugTargetVersions: &[]string{oneStr}},
+			dependents:  []dependent{{key: "OCPBUGS-124", targetVersion: &otherZStreamStr}},
+			options:     JiraBranchOptions{DependentBugTargetVersions: &[]string{"4.13.0", twoStr}},
 			valid:       false,
 			validations: []string{"bug has dependents"},
-			why:         []string{"expected dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to target a version in v1, but it targets \"v2\" instead"},
+			why:         []string{`expected dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to target a version in 4.13.*, openshift-4.13.*, v2.*, openshift-v2.*, but it targets "4.14.1" instead`},
 		},
 		{
 			name:        "not having a dependent bug target version means an invalid bug",
@@ -5624,7 +11333,7 @@ func TestValidateBug(t *testing.T) {
 			options:     JiraBranchOptions{DependentBugTargetVersions: &[]string{oneStr}},
 			valid:       false,
 			validations: []string{"bug has dependents"},
-			why:         []string{"expected dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to target a version in v1, but no target version was set"},
+			why:         []string{"expected dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) to target a version in v1.*, openshift-v1.*, but no target version was set"},
 		},
 		{
 			name: "matching all requirements means a valid bug",
@@ -5641,7 +11350,7 @@ func TestValidateBug(t *testing.T) {
 				`bug target version (v1) matches configured target version for branch (v1)`,
 				"bug is in the state MODIFIED, which is one of the valid states (MODIFIED)",
 				"dependent bug [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) is in the state MODIFIED, which is one of the valid states (MODIFIED)",
-				`dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) targets the "v2" version, which is one of the valid target versions: v2`,
+				`dependent [Jira Issue OCPBUGS-124](https://my-jira.com/browse/OCPBUGS-124) targets the "v2" version, which is one of the valid target versions: v2.*, openshift-v2.*`,
 				"bug has dependents"},
 			valid: true,
 		},
@@ -5660,7 +11369,7 @@ func TestValidateBug(t *testing.T) {
 				`bug target version (v1) matches configured target version for branch (v1)`,
 				"bug is in the state MODIFIED, which is one of the valid states (MODIFIED)",
 				"dependent bug [Jira Issue DFBUGS-124](https://my-jira.com/browse/DFBUGS-124) is in the state MODIFIED, which is one of the valid states (MODIFIED)",
-				`dependent [Jira Issue DFBUGS-124](https://my-jira.com/browse/DFBUGS-124) targets the "v2" version, which is one of the valid target versions: v2`,
+				`dependent [Jira Issue DFBUGS-124](https://my-jira.com/browse/DFBUGS-124) targets the "v2" version, which is one of the valid target versions: v2.*, openshift-v2.*`,
 				"bug has dependents"},
 			valid: true,
 		},
@@ -5859,14 +11568,246 @@ func TestValidateBug(t *testing.T) {
 				"dependent bug OCPBUGSM-38676 is not in the required `OCPBUGS` project",
 			},
 		},
+		{
+			name:        "matching fix version requirement means a valid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v1"}}}},
+			options:     JiraBranchOptions{FixVersion: &oneStr},
+			valid:       true,
+			validations: []string{"bug fix version (v1) matches configured fix version for branch (v1)"},
+		},
+		{
+			name:        "matching prefixed fix version requirement means a valid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "openshift-v3"}}}},
+			options:     JiraBranchOptions{FixVersion: &threeStr},
+			valid:       true,
+			validations: []string{"bug fix version (v3) matches configured fix version for branch (v3)"},
+		},
+		{
+			name:        "one of multiple fix versions matching requirement means a valid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{FixVersions: []*jira.FixVersion{{Name: "v2"}, {Name: "v1"}}}},
+			options:     JiraBranchOptions{FixVersion: &oneStr},
+			valid:       true,
+			validations: []string{"bug fix version (v1) matches configured fix version for branch (v1)"},
+		},
+		{
+			name:    "not matching fix version requirement means an invalid bug",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}, FixVersions: []*jira.FixVersion{{Name: "v2"}, {Name: "openshift-v3"}}}},
+			options: JiraBranchOptions{FixVersion: &oneStr},
+			valid:   false,
+			why:     []string{"expected the bug to have a fix version of either \"v1.*\" or \"openshift-v1.*\", but it has \"v2\", \"openshift-v3\" instead"},
+		},
+		{
+			name:       "not setting fix version requirement means an invalid bug",
+			issue:      &jira.Issue{Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}}},
+			options:    JiraBranchOptions{FixVersion: &oneStr},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the bug to have the \"v1\" fix version, but no fix version was set"},
+		},
+		{
+			name:        "no target version with single target version requirement means a valid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{}},
+			options:     JiraBranchOptions{RequireSingleTargetVersion: &yes},
+			valid:       true,
+			validations: []string{"bug does not target more than one version"},
+		},
+		{
+			name:        "one target version with single target version requirement means a valid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &one}}},
+			options:     JiraBranchOptions{RequireSingleTargetVersion: &yes},
+			valid:       true,
+			validations: []string{"bug does not target more than one version"},
+		},
+		{
+			name:    "two target versions with single target version requirement means an invalid bug",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &[]*jira.Version{{Name: "v1"}, {Name: "v2"}}}}},
+			options: JiraBranchOptions{RequireSingleTargetVersion: &yes},
+			valid:   false,
+			why:     []string{"expected the bug to target exactly one version, but it targets: v1, v2"},
+		},
+		{
+			name:    "two target versions with single target version and target version requirements reports both failures",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}, Unknowns: tcontainer.MarshalMap{helpers.TargetVersionField: &[]*jira.Version{{Name: "v1"}, {Name: "v2"}}}}},
+			options: JiraBranchOptions{TargetVersion: &oneStr, RequireSingleTargetVersion: &yes},
+			valid:   false,
+			why: []string{
+				"expected the bug to target only the \"v1\" version, but multiple target versions were set",
+				"expected the bug to target exactly one version, but it targets: v1, v2",
+			},
+		},
+		{
+			name:        "bug in an active sprint with active sprint requirement means a valid bug",
+			issue:       &jira.Issue{Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.SprintField: []any{closedSprint, activeSprint}}}},
+			options:     JiraBranchOptions{RequireActiveSprint: &yes},
+			valid:       true,
+			validations: []string{"bug is assigned to active sprint uShift Sprint 248"},
+		},
+		{
+			name:    "bug in only closed sprints with active sprint requirement means an invalid bug",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{Unknowns: tcontainer.MarshalMap{helpers.SprintField: []any{closedSprint}}}},
+			options: JiraBranchOptions{RequireActiveSprint: &yes},
+			valid:   false,
+			why:     []string{"expected the bug to be assigned to an active sprint, but it is not"},
+		},
+		{
+			name:    "bug without sprint with active sprint requirement means an invalid bug",
+			issue:   &jira.Issue{Fields: &jira.IssueFields{}},
+			options: JiraBranchOptions{RequireActiveSprint: &yes},
+			valid:   false,
+			why:     []string{"expected the bug to be assigned to an active sprint, but it is not"},
+		},
+		{
+			name: "bug blocked by another bug with blocked-by requirement means a valid bug",
+			issue: &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{IssueLinks: []*jira.IssueLink{{
+				Type:        jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+				InwardIssue: &jira.Issue{Key: "OCPBUGS-1"},
+			}}}},
+			options:     JiraBranchOptions{RequireBlockedBy: &yes},
+			valid:       true,
+			validations: []string{"bug is blocked by [Jira Issue OCPBUGS-1](https://my-jira.com/browse/OCPBUGS-1)"},
+		},
+		{
+			name: "bug that only blocks another bug with blocked-by requirement means an invalid bug",
+			issue: &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{IssueLinks: []*jira.IssueLink{{
+				Type:         jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+				OutwardIssue: &jira.Issue{Key: "OCPBUGS-3"},
+			}}}},
+			options: JiraBranchOptions{RequireBlockedBy: &yes},
+			valid:   false,
+			why:     []string{"expected [Jira Issue OCPBUGS-2](https://my-jira.com/browse/OCPBUGS-2) to be blocked by at least one other bug, but no blocking bugs were found"},
+		},
+		{
+			name:    "bug without links with blocked-by requirement means an invalid bug",
+			issue:   &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			options: JiraBranchOptions{RequireBlockedBy: &yes},
+			valid:   false,
+			why:     []string{"expected [Jira Issue OCPBUGS-2](https://my-jira.com/browse/OCPBUGS-2) to be blocked by at least one other bug, but no blocking bugs were found"},
+		},
+		{
+			name:        "bug with resolved blockers and resolved-blockers requirement means a valid bug",
+			issue:       &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			blockers:    []dependent{{key: "OCPBUGS-1", bugState: JiraBugState{Status: "CLOSED", Resolution: "Done"}}},
+			options:     JiraBranchOptions{RequireBlockersResolved: &yes},
+			valid:       true,
+			validations: []string{"blocker [Jira Issue OCPBUGS-1](https://my-jira.com/browse/OCPBUGS-1) is resolved as CLOSED (Done)"},
+		},
+		{
+			name:  "bug with an unresolved blocker and resolved-blockers requirement means an invalid bug",
+			issue: &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			blockers: []dependent{
+				{key: "OCPBUGS-1", bugState: JiraBugState{Status: "CLOSED", Resolution: "Done"}},
+				{key: "OCPBUGS-3", bugState: JiraBugState{Status: "POST"}},
+			},
+			options:     JiraBranchOptions{RequireBlockersResolved: &yes},
+			valid:       false,
+			validations: []string{"blocker [Jira Issue OCPBUGS-1](https://my-jira.com/browse/OCPBUGS-1) is resolved as CLOSED (Done)"},
+			why:         []string{"expected blocker [Jira Issue OCPBUGS-3](https://my-jira.com/browse/OCPBUGS-3) to be resolved, but it is POST without a resolution"},
+		},
+		{
+			name:    "bug without blockers and resolved-blockers requirement means a valid bug",
+			issue:   &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			options: JiraBranchOptions{RequireBlockersResolved: &yes},
+			valid:   true,
+		},
+		{
+			name:        "bug with description and description requirement means a valid bug",
+			issue:       &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Description: "The operator crashes on startup."}},
+			options:     JiraBranchOptions{RequireDescription: &yes},
+			valid:       true,
+			validations: []string{"bug has a description"},
+		},
+		{
+			name:       "bug without description and description requirement means an invalid bug",
+			issue:      &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			options:    JiraBranchOptions{RequireDescription: &yes},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the bug to have a description, but it is empty"},
+		},
+		{
+			name:       "bug with whitespace-only description and description requirement means an invalid bug",
+			issue:      &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Description: " \n\t "}},
+			options:    JiraBranchOptions{RequireDescription: &yes},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the bug to have a description, but it is empty"},
+		},
+		{
+			name:        "bug with affects version and affects version requirement means a valid bug",
+			issue:       &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{AffectsVersions: []*jira.AffectsVersion{{Name: "4.16"}, {Name: "4.15"}}}},
+			options:     JiraBranchOptions{RequireAffectsVersion: &yes},
+			valid:       true,
+			validations: []string{"bug has affects version(s) set: 4.16, 4.15"},
+		},
+		{
+			name:       "bug without affects version and affects version requirement means an invalid bug",
+			issue:      &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			options:    JiraBranchOptions{RequireAffectsVersion: &yes},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the bug to have at least one affects version set, but it has none"},
+		},
+		{
+			name:        "bug with allowed type means a valid bug",
+			issue:       &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}}},
+			options:     JiraBranchOptions{AllowedIssueTypes: &[]string{"Story", "bug"}},
+			valid:       true,
+			validations: []string{"bug is of type Bug, which is allowed"},
+		},
+		{
+			name:    "bug with disallowed type means an invalid bug",
+			issue:   &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Task"}}},
+			options: JiraBranchOptions{AllowedIssueTypes: &[]string{"Story", "Bug"}},
+			valid:   false,
+			why:     []string{"expected the issue to be of one of the following types: Story, Bug, but it is of type Task instead"},
+		},
+		{
+			name:    "bug without type means an invalid bug when types are restricted",
+			issue:   &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{}},
+			options: JiraBranchOptions{AllowedIssueTypes: &[]string{"Story", "Bug"}},
+			valid:   false,
+			why:     []string{"expected the issue to be of one of the following types: Story, Bug, but it has no type set"},
+		},
+		{
+			name:        "story linked to an epic means a valid bug when epic links are required",
+			issue:       &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Story"}, Unknowns: tcontainer.MarshalMap{helpers.EpicLinkField: "OCPBUGS-1"}}},
+			options:     JiraBranchOptions{RequireEpicLink: &yes},
+			valid:       true,
+			validations: []string{"story is linked to the epic OCPBUGS-1"},
+		},
+		{
+			name:       "story without an epic link means an incomplete bug when epic links are required",
+			issue:      &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Story"}}},
+			options:    JiraBranchOptions{RequireEpicLink: &yes},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the story to be linked to an epic, but no epic link was set"},
+		},
+		{
+			name:    "bug without an epic link means a valid bug when epic links are only required for stories",
+			issue:   &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Bug"}}},
+			options: JiraBranchOptions{RequireEpicLink: &yes},
+			valid:   true,
+		},
+		{
+			name:       "task without an epic link means an invalid bug when epic links are required for tasks",
+			issue:      &jira.Issue{Key: "OCPBUGS-2", Fields: &jira.IssueFields{Type: jira.IssueType{Name: "Task"}}},
+			options:    JiraBranchOptions{RequireEpicLink: &yes, EpicLinkIssueTypes: &[]string{"task"}},
+			valid:      false,
+			incomplete: true,
+			why:        []string{"expected the task to be linked to an epic, but no epic link was set"},
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			valid, validations, why := validateBug(testCase.issue, testCase.dependents, testCase.options, "https://my-jira.com")
+			valid, incomplete, validations, why := validateBug(testCase.issue, testCase.dependents, testCase.blockers, testCase.options, "https://my-jira.com")
 			if valid != testCase.valid {
 				t.Errorf("%s: didn't validate bug correctly, expected %t got %t", testCase.name, testCase.valid, valid)
 			}
+			if incomplete != testCase.incomplete {
+				t.Errorf("%s: didn't identify missing fields correctly, expected %t got %t", testCase.name, testCase.incomplete, incomplete)
+			}
 			if !reflect.DeepEqual(validations, testCase.validations) {
 				t.Errorf("%s: didn't get correct validations: %v", testCase.name, cmp.Diff(testCase.validations, validations, allowEventAndDate))
 			}
@@ -5941,6 +11882,202 @@ func TestProcessQuery(t *testing.T) {
 	}
 }
 
+func TestShouldPostComment(t *testing.T) {
+	isBot := func(login string) bool { return login == "bot" }
+	var testCases = []struct {
+		name     string
+		comments []github.IssueComment
+		body     string
+		expected bool
+	}{
+		{
+			name:     "no prior comments posts",
+			body:     "No Jira issue is referenced in the title of this pull request.",
+			expected: true,
+		},
+		{
+			name: "latest bot comment matching the body does not post",
+			comments: []github.IssueComment{
+				{User: github.User{Login: "user"}, Body: "/jira refresh"},
+				{User: github.User{Login: "bot"}, Body: "@user: No Jira issue is referenced in the title of this pull request.\n\n<details></details>"},
+			},
+			body:     "No Jira issue is referenced in the title of this pull request.",
+			expected: false,
+		},
+		{
+			name: "matching bot comment that is not the latest bot comment posts",
+			comments: []github.IssueComment{
+				{User: github.User{Login: "bot"}, Body: "@user: No Jira issue is referenced in the title of this pull request."},
+				{User: github.User{Login: "bot"}, Body: "@user: This pull request references OCPBUGS-123 which is a valid jira issue."},
+			},
+			body:     "No Jira issue is referenced in the title of this pull request.",
+			expected: true,
+		},
+		{
+			name: "matching comment by a human after the latest bot comment is ignored",
+			comments: []github.IssueComment{
+				{User: github.User{Login: "bot"}, Body: "@user: No Jira issue is referenced in the title of this pull request."},
+				{User: github.User{Login: "user"}, Body: "This pull request references OCPBUGS-123 which is a valid jira issue."},
+			},
+			body:     "No Jira issue is referenced in the title of this pull request.",
+			expected: false,
+		},
+		{
+			name: "matching comment by a human only posts",
+			comments: []github.IssueComment{
+				{User: github.User{Login: "user"}, Body: "No Jira issue is referenced in the title of this pull request."},
+			},
+			body:     "No Jira issue is referenced in the title of this pull request.",
+			expected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := shouldPostComment(testCase.comments, isBot, testCase.body); actual != testCase.expected {
+				t.Errorf("%s: expected %t, got %t", testCase.name, testCase.expected, actual)
+			}
+		})
+	}
+}
+
+// changelogJiraClient serves searches for a single issue by key with the changelog of the known issue,
+// as the upstream fake jira client only serves registered searches
+type changelogJiraClient struct {
+	*fakejira.FakeClient
+	searches []string
+}
+
+func (f *changelogJiraClient) SearchWithContext(ctx context.Context, jql string, options *jira.SearchOptions) ([]jira.Issue, *jira.Response, error) {
+	f.searches = append(f.searches, jql)
+	issue, err := f.GetIssue(strings.TrimPrefix(jql, "key = "))
+	if err != nil {
+		return nil, nil, nil
+	}
+	return []jira.Issue{*issue}, nil, nil
+}
+
+func TestGetIssueChangelog(t *testing.T) {
+	changelog := &jira.Changelog{Histories: []jira.ChangelogHistory{{Id: "1", Items: []jira.ChangelogItems{{Field: "status", ToString: "MODIFIED"}}}}}
+	var testCases = []struct {
+		name             string
+		issue            *jira.Issue
+		known            []*jira.Issue
+		expected         *jira.Changelog
+		expectedSearches []string
+		expectedFetches  int64
+		expectedErr      bool
+	}{
+		{
+			name:     "expanded changelog is not fetched again",
+			issue:    &jira.Issue{ID: "1", Key: "OCPBUGS-123", Changelog: changelog},
+			expected: changelog,
+		},
+		{
+			name:             "changelog is fetched through the wrapped client",
+			issue:            &jira.Issue{ID: "1", Key: "OCPBUGS-123"},
+			known:            []*jira.Issue{{ID: "1", Key: "OCPBUGS-123", Changelog: changelog}},
+			expected:         changelog,
+			expectedSearches: []string{"key = OCPBUGS-123"},
+			expectedFetches:  1,
+		},
+		{
+			name:             "missing issue is not found",
+			issue:            &jira.Issue{ID: "1", Key: "OCPBUGS-123"},
+			expectedSearches: []string{"key = OCPBUGS-123"},
+			expectedFetches:  1,
+			expectedErr:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &changelogJiraClient{FakeClient: &fakejira.FakeClient{Issues: tc.known}}
+			counts := &callCounts{}
+			actual, err := getIssueChangelog(&countingJiraClient{Client: fake, counts: counts}, tc.issue)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr && !jiraclient.IsNotFound(err) {
+				t.Errorf("expected a not found error, got %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("changelog differs from expected: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSearches, fake.searches); diff != "" {
+				t.Errorf("searches differ from expected: %s", diff)
+			}
+			if fetches := counts.jiraGetIssue.Load(); fetches != tc.expectedFetches {
+				t.Errorf("expected %d fetches to be counted, got %d", tc.expectedFetches, fetches)
+			}
+		})
+	}
+}
+
+func TestStateEnteredAt(t *testing.T) {
+	first, _ := time.Parse(jiraChangelogTimeLayout, "2024-01-10T09:00:00.000+0000")
+	second, _ := time.Parse(jiraChangelogTimeLayout, "2024-01-15T09:00:00.000+0000")
+	var testCases = []struct {
+		name          string
+		changelog     *jira.Changelog
+		status        string
+		expected      time.Time
+		expectedFound bool
+		expectedErr   bool
+	}{
+		{
+			name:   "nil changelog is not found",
+			status: "MODIFIED",
+		},
+		{
+			name: "no matching status change is not found",
+			changelog: &jira.Changelog{Histories: []jira.ChangelogHistory{
+				{Id: "1", Created: "2024-01-10T09:00:00.000+0000", Items: []jira.ChangelogItems{{Field: "assignee", ToString: "MODIFIED"}, {Field: "status", ToString: "POST"}}},
+			}},
+			status: "MODIFIED",
+		},
+		{
+			name: "latest matching status change wins",
+			changelog: &jira.Changelog{Histories: []jira.ChangelogHistory{
+				{Id: "2", Created: "2024-01-15T09:00:00.000+0000", Items: []jira.ChangelogItems{{Field: "status", ToString: "Modified"}}},
+				{Id: "1", Created: "2024-01-10T09:00:00.000+0000", Items: []jira.ChangelogItems{{Field: "status", ToString: "MODIFIED"}}},
+			}},
+			status:        "MODIFIED",
+			expected:      second,
+			expectedFound: true,
+		},
+		{
+			name: "single matching status change is found",
+			changelog: &jira.Changelog{Histories: []jira.ChangelogHistory{
+				{Id: "1", Created: "2024-01-10T09:00:00.000+0000", Items: []jira.ChangelogItems{{Field: "status", ToString: "MODIFIED"}}},
+			}},
+			status:        "MODIFIED",
+			expected:      first,
+			expectedFound: true,
+		},
+		{
+			name: "unparsable creation time errors",
+			changelog: &jira.Changelog{Histories: []jira.ChangelogHistory{
+				{Id: "1", Created: "yesterday", Items: []jira.ChangelogItems{{Field: "status", ToString: "MODIFIED"}}},
+			}},
+			status:      "MODIFIED",
+			expectedErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entered, found, err := stateEnteredAt(testCase.changelog, testCase.status)
+			if (err != nil) != testCase.expectedErr {
+				t.Fatalf("%s: expected error %t, got %v", testCase.name, testCase.expectedErr, err)
+			}
+			if found != testCase.expectedFound {
+				t.Errorf("%s: expected found %t, got %t", testCase.name, testCase.expectedFound, found)
+			}
+			if !entered.Equal(testCase.expected) {
+				t.Errorf("%s: expected %s, got %s", testCase.name, testCase.expected, entered)
+			}
+		})
+	}
+}
+
 func TestGetCherrypickPRMatch(t *testing.T) {
 	var prNum = 123
 	var branch = "v2"
@@ -6186,7 +12323,7 @@ func TestCherryPickCommandMatches(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
-			got, err := cherryPickCommandMatches(testCase.body)
+			got, err := cherryPickCommandMatches(testCase.body, defaultBugProjects)
 			if err == nil && testCase.expectedErr {
 				t.Errorf("expected an error but got none")
 			}
@@ -6226,6 +12363,133 @@ func TestBackportCommandMatches(t *testing.T) {
 	}
 }
 
+func TestResolveBackportChain(t *testing.T) {
+	v3, v3z, v4, v4z, v5 := "v3", "v3z", "v4", "v4z", "v5"
+	for _, testCase := range []struct {
+		name                string
+		backportBranches    []string
+		repoOptions         map[string]JiraBranchOptions
+		expectedChildren    map[string][]string
+		expectedMissingDeps []string
+	}{{
+		name:             "complete chain",
+		backportBranches: []string{"v3", "v4"},
+		repoOptions: map[string]JiraBranchOptions{
+			"v3": {TargetVersion: &v3z, DependentBugTargetVersions: &[]string{v4, v4z}},
+			"v4": {TargetVersion: &v4z, DependentBugTargetVersions: &[]string{v5}},
+			"v5": {TargetVersion: &v5},
+		},
+		expectedChildren:    map[string][]string{"v4": {"v3"}, "v5": {"v4"}},
+		expectedMissingDeps: []string{},
+	}, {
+		name:             "branch for dependent target version is not requested",
+		backportBranches: []string{"v3"},
+		repoOptions: map[string]JiraBranchOptions{
+			"v3": {TargetVersion: &v3z, DependentBugTargetVersions: &[]string{v4, v4z}},
+			"v4": {TargetVersion: &v4z, DependentBugTargetVersions: &[]string{v5}},
+			"v5": {TargetVersion: &v5},
+		},
+		expectedChildren:    map[string][]string{},
+		expectedMissingDeps: []string{"v4, "},
+	}, {
+		name:             "no branch is configured for dependent target versions",
+		backportBranches: []string{"v3", "v4"},
+		repoOptions: map[string]JiraBranchOptions{
+			"v3": {TargetVersion: &v3, DependentBugTargetVersions: &[]string{v4, v4z}},
+			"v5": {TargetVersion: &v5},
+		},
+		expectedChildren:    map[string][]string{},
+		expectedMissingDeps: []string{"branch with one of the following target versions: [v4 v4z]"},
+	}} {
+		t.Run(testCase.name, func(t *testing.T) {
+			children, missingDeps := resolveBackportChain("v5", testCase.backportBranches, testCase.repoOptions)
+			if diff := cmp.Diff(testCase.expectedChildren, children); diff != "" {
+				t.Errorf("invalid child branches: %v", diff)
+			}
+			if diff := cmp.Diff(testCase.expectedMissingDeps, missingDeps); diff != "" {
+				t.Errorf("invalid missing dependencies: %v", diff)
+			}
+		})
+	}
+}
+
+func TestResolveBackportBranches(t *testing.T) {
+	v3z, v4z := "v3z", "v4z"
+	yes, no := true, false
+	repoOptions := map[string]JiraBranchOptions{
+		"v3":      {TargetVersion: &v3z},
+		"v4":      {TargetVersion: &v4z},
+		"v4-hack": {TargetVersion: &v4z},
+	}
+	eligibleRepoOptions := map[string]JiraBranchOptions{
+		JiraOptionsWildcard: {BackportEligible: &no},
+		"v3":                {TargetVersion: &v3z, BackportEligible: &yes},
+		"v4":                {TargetVersion: &v4z, BackportEligible: &yes},
+		"v4-hack":           {TargetVersion: &v4z},
+	}
+	for _, testCase := range []struct {
+		name        string
+		repoOptions map[string]JiraBranchOptions
+		requested   []string
+		expected    []string
+		expectedErr string
+	}{{
+		name:      "branches are kept",
+		requested: []string{"v3", "v4"},
+		expected:  []string{"v3", "v4"},
+	}, {
+		name:      "target version is resolved to its branch",
+		requested: []string{"v3z", "v4"},
+		expected:  []string{"v3", "v4"},
+	}, {
+		name:      "unknown target is kept",
+		requested: []string{"release-1.0"},
+		expected:  []string{"release-1.0"},
+	}, {
+		name:        "target version of multiple branches is rejected",
+		requested:   []string{"v4z"},
+		expectedErr: "target version v4z is configured for multiple branches (v4, v4-hack); please request the backport by branch instead",
+	}, {
+		name:        "eligible branches are kept",
+		repoOptions: eligibleRepoOptions,
+		requested:   []string{"v3", "v4"},
+		expected:    []string{"v3", "v4"},
+	}, {
+		name:        "target version is resolved to its eligible branch",
+		repoOptions: eligibleRepoOptions,
+		requested:   []string{"v3z"},
+		expected:    []string{"v3"},
+	}, {
+		name:        "ineligible branches are rejected",
+		repoOptions: eligibleRepoOptions,
+		requested:   []string{"v3", "v4-hack", "release-1.0"},
+		expectedErr: "the following branches are not eligible for backports: v4-hack, release-1.0; please request the backport to eligible branches only",
+	}} {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := repoOptions
+			if testCase.repoOptions != nil {
+				options = testCase.repoOptions
+			}
+			resolved, err := resolveBackportBranches(testCase.requested, options)
+			if err != nil {
+				if testCase.expectedErr == "" {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err.Error() != testCase.expectedErr {
+					t.Fatalf("expected error %q, got %q", testCase.expectedErr, err.Error())
+				}
+				return
+			}
+			if testCase.expectedErr != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedErr)
+			}
+			if diff := cmp.Diff(testCase.expected, resolved); diff != "" {
+				t.Errorf("invalid resolved branches: %v", diff)
+			}
+		})
+	}
+}
+
 func TestCheckRHRestrictedIssue(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -6292,3 +12556,95 @@ func TestCheckRHRestrictedIssue(t *testing.T) {
 		}
 	}
 }
+
+func TestPRPartsFromRemoteLink(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		link         jira.RemoteLink
+		expected     prParts
+		expectedIsPR bool
+		expectedErr  bool
+	}{{
+		name: "link without an object is not a pull request",
+		link: jira.RemoteLink{},
+	}, {
+		name: "link to an issue is not a pull request",
+		link: jira.RemoteLink{Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/issues/1"}},
+	}, {
+		name:         "link to a pull request",
+		link:         jira.RemoteLink{Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/pull/1"}},
+		expected:     prParts{Org: "org", Repo: "repo", Num: 1},
+		expectedIsPR: true,
+	}, {
+		name:         "link to the files of a pull request",
+		link:         jira.RemoteLink{Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/pull/1/files"}},
+		expected:     prParts{Org: "org", Repo: "repo", Num: 1},
+		expectedIsPR: true,
+	}, {
+		name:         "link to a commit of a pull request",
+		link:         jira.RemoteLink{Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/pull/1/commits/abc"}},
+		expected:     prParts{Org: "org", Repo: "repo", Num: 1},
+		expectedIsPR: true,
+	}, {
+		name:         "link to a pull request with an invalid number",
+		link:         jira.RemoteLink{Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/pull/one"}},
+		expectedIsPR: true,
+		expectedErr:  true,
+	}, {
+		name:         "link to an unknown page of a pull request",
+		link:         jira.RemoteLink{Object: &jira.RemoteLinkObject{URL: "https://github.com/org/repo/pull/1/checks"}},
+		expectedIsPR: true,
+		expectedErr:  true,
+	}} {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, isPR, err := prPartsFromRemoteLink(testCase.link)
+			if err == nil && testCase.expectedErr {
+				t.Errorf("expected an error but got none")
+			}
+			if err != nil && !testCase.expectedErr {
+				t.Errorf("expected no error but got: %v", err)
+			}
+			if isPR != testCase.expectedIsPR {
+				t.Errorf("expected the link to be a pull request: %t, got %t", testCase.expectedIsPR, isPR)
+			}
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("invalid pull request: %v", diff)
+			}
+		})
+	}
+}
+
+func TestTargetVersionAllowed(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		project  string
+		version  string
+		expected bool
+	}{{
+		name:     "versions are truncated for most projects",
+		project:  "OCPBUGS",
+		version:  "4.16.2",
+		expected: true,
+	}, {
+		name:     "versions may be prefixed",
+		project:  "OCPBUGS",
+		version:  "openshift-4.16.2",
+		expected: true,
+	}, {
+		name:     "versions are not truncated for DFBUGS",
+		project:  "DFBUGS",
+		version:  "4.16.2",
+		expected: false,
+	}, {
+		name:     "matching versions are allowed for DFBUGS",
+		project:  "DFBUGS",
+		version:  "4.16.1",
+		expected: true,
+	}} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if allowed := targetVersionAllowed(testCase.project, []string{"4.16.1"}, testCase.version); allowed != testCase.expected {
+				t.Errorf("expected %q to be allowed for %s: %t, got %t", testCase.version, testCase.project, testCase.expected, allowed)
+			}
+		})
+	}
+}
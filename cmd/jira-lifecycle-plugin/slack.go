@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SlackNotifier posts messages to Slack channels. It is satisfied by the prow Slack client.
+type SlackNotifier interface {
+	WriteMessage(text, channel string) error
+}
+
+type slackMessage struct {
+	channel string
+	text    string
+}
+
+type fakeSlackNotifier struct {
+	messages []slackMessage
+}
+
+func (f *fakeSlackNotifier) WriteMessage(text, channel string) error {
+	f.messages = append(f.messages, slackMessage{channel: channel, text: text})
+	return nil
+}
+
+// notifySlack posts the message to the Slack channel configured for the branch, if any.
+// Failures are logged, as notifications must never block handling of the event.
+func notifySlack(notifier SlackNotifier, options JiraBranchOptions, message string, log *logrus.Entry) {
+	if notifier == nil || options.SlackChannel == nil || *options.SlackChannel == "" {
+		return
+	}
+	if err := notifier.WriteMessage(message, *options.SlackChannel); err != nil {
+		log.WithError(err).Error("Failed to post Slack notification")
+	}
+}
+
+func invalidBugSlackMessage(e event, reasons []string) string {
+	return fmt.Sprintf("%s references Jira issue(s) which are invalid:\n - %s", e.htmlUrl, strings.Join(reasons, "\n - "))
+}
+
+func errorSlackMessage(e event, err error) string {
+	return fmt.Sprintf("An error was encountered handling %s: %v", e.htmlUrl, err)
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/openshift-eng/jira-lifecycle-plugin/pkg/status"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -14,41 +17,192 @@ func validateConfig(rawConfig []byte) error {
 	if err := yaml.UnmarshalStrict(rawConfig, &config); err != nil {
 		return fmt.Errorf("failed to read config: %v", err)
 	}
+	return config.Validate()
+}
+
+// Validate normalizes the casing of the statuses in all configured bug states and then checks the
+// configuration, returning an error that names every invalid option and the branch it is set on.
+func (c *Config) Validate() error {
+	normalizeStatuses(c)
 	errors := []error{}
-	errors = append(errors, validateStatuses(&config)...)
+	errors = append(errors, validateStatuses(c)...)
+	errors = append(errors, validateCommentTemplates(c)...)
+	errors = append(errors, validateSeverityLabels(c)...)
+	errors = append(errors, validateMinimumSeverities(c)...)
+	errors = append(errors, validateSeverityOrders(c)...)
+	errors = append(errors, validateCommentVisibilities(c)...)
 	return utilerrors.NewAggregate(errors)
 }
 
-func validateStatuses(c *Config) []error {
+// normalizeStatuses rewrites the statuses of all configured bug states that match a known status regardless
+// of casing and surrounding whitespace (e.g. `on_qa`) to that status, so that they are matched and shown
+// consistently. Unknown statuses are left as they are to be reported by validateStatuses.
+func normalizeStatuses(c *Config) {
+	validateBranches(c, "statuses", func(_ string, options JiraBranchOptions) []error {
+		for _, state := range configuredStates(options) {
+			for _, known := range validStatusSet.UnsortedList() {
+				if strings.EqualFold(strings.TrimSpace(state.Status), known) {
+					state.Status = known
+				}
+			}
+			state.Resolution = strings.TrimSpace(state.Resolution)
+		}
+		return nil
+	})
+}
+
+// configuredStates returns pointers to all bug states configured for a branch
+func configuredStates(options JiraBranchOptions) []*JiraBugState {
+	var states []*JiraBugState
+	for _, state := range []*JiraBugState{options.StateAfterValidation, options.PreMergeStateAfterValidation, options.StateAfterMerge, options.PreMergeStateAfterMerge, options.StateAfterClose, options.PreMergeStateAfterClose} {
+		if state != nil {
+			states = append(states, state)
+		}
+	}
+	for _, list := range []*[]JiraBugState{options.ValidStates, options.DependentBugStates} {
+		if list == nil {
+			continue
+		}
+		for i := range *list {
+			states = append(states, &(*list)[i])
+		}
+	}
+	return states
+}
+
+// validateBranches runs the provided check against the options of every branch in the config
+func validateBranches(c *Config, kind string, check func(name string, options JiraBranchOptions) []error) []error {
 	errors := []error{}
 	for branchName, options := range c.Default {
-		newErrs := checkBranchStatuses(branchName, options)
+		newErrs := check(branchName, options)
 		if len(newErrs) == 0 {
 			continue
 		}
-		errors = append(errors, fmt.Errorf("invalid statuses in `default`: %v", utilerrors.NewAggregate(newErrs)))
+		errors = append(errors, fmt.Errorf("invalid %s in `default`: %v", kind, utilerrors.NewAggregate(newErrs)))
 	}
 	for orgName, orgOptions := range c.Orgs {
 		for orgBranchName, orgBranchOptions := range orgOptions.Default {
-			newErrs := checkBranchStatuses(orgBranchName, orgBranchOptions)
+			newErrs := check(orgBranchName, orgBranchOptions)
 			if len(newErrs) == 0 {
 				continue
 			}
-			errors = append(errors, fmt.Errorf("invalid statuses in `%s/default`: %v", orgName, utilerrors.NewAggregate(newErrs)))
+			errors = append(errors, fmt.Errorf("invalid %s in `%s/default`: %v", kind, orgName, utilerrors.NewAggregate(newErrs)))
 		}
 		for repoName, repoOptions := range orgOptions.Repos {
 			for branchName, branchOptions := range repoOptions.Branches {
-				newErrs := checkBranchStatuses(branchName, branchOptions)
+				newErrs := check(branchName, branchOptions)
 				if len(newErrs) == 0 {
 					continue
 				}
-				errors = append(errors, fmt.Errorf("invalid statuses in `%s/%s`: %v", orgName, repoName, utilerrors.NewAggregate(newErrs)))
+				errors = append(errors, fmt.Errorf("invalid %s in `%s/%s`: %v", kind, orgName, repoName, utilerrors.NewAggregate(newErrs)))
 			}
 		}
 	}
 	return errors
 }
 
+func validateStatuses(c *Config) []error {
+	return validateBranches(c, "statuses", checkBranchStatuses)
+}
+
+// validateCommentTemplates makes sure that all configured comment templates are of a known type and can be parsed,
+// along with the remote link title template
+func validateCommentTemplates(c *Config) []error {
+	return validateBranches(c, "comment templates", checkBranchCommentTemplates)
+}
+
+// validateSeverityLabels makes sure that labels are only configured for known severities
+func validateSeverityLabels(c *Config) []error {
+	return validateBranches(c, "severity labels", checkBranchSeverityLabels)
+}
+
+func checkBranchSeverityLabels(name string, options JiraBranchOptions) []error {
+	errors := []error{}
+	ranking := severityRankingFor(options)
+	for severity, label := range options.SeverityLabels {
+		if !slices.Contains(ranking, severity) {
+			errors = append(errors, fmt.Errorf("%s has a label for unknown severity `%s`, valid severities are: %s", name, severity, strings.Join(ranking, ", ")))
+		}
+		if label == "" {
+			errors = append(errors, fmt.Errorf("%s has an empty label for severity `%s`", name, severity))
+		}
+	}
+	return errors
+}
+
+// validateMinimumSeverities makes sure that validation by default is only limited to known severities
+func validateMinimumSeverities(c *Config) []error {
+	return validateBranches(c, "minimum severity", checkBranchMinimumSeverity)
+}
+
+func checkBranchMinimumSeverity(name string, options JiraBranchOptions) []error {
+	errors := []error{}
+	ranking := severityRankingFor(options)
+	if options.ValidateByDefaultMinimumSeverity != nil && !slices.Contains(ranking, *options.ValidateByDefaultMinimumSeverity) {
+		errors = append(errors, fmt.Errorf("%s has unknown `validate_by_default_minimum_severity` `%s`, valid severities are: %s", name, *options.ValidateByDefaultMinimumSeverity, strings.Join(ranking, ", ")))
+	}
+	return errors
+}
+
+// validateSeverityOrders makes sure that configured severity orders list each severity once
+func validateSeverityOrders(c *Config) []error {
+	return validateBranches(c, "severity order", checkBranchSeverityOrder)
+}
+
+func checkBranchSeverityOrder(name string, options JiraBranchOptions) []error {
+	errors := []error{}
+	seen := sets.New[string]()
+	for _, severity := range options.SeverityOrder {
+		if severity == "" {
+			errors = append(errors, fmt.Errorf("%s has an empty severity in `severity_order`", name))
+			continue
+		}
+		if seen.Has(severity) {
+			errors = append(errors, fmt.Errorf("%s lists severity `%s` more than once in `severity_order`", name, severity))
+		}
+		seen.Insert(severity)
+	}
+	return errors
+}
+
+// validateCommentVisibilities makes sure that private comments are restricted to a valid group or role
+func validateCommentVisibilities(c *Config) []error {
+	return validateBranches(c, "private comment visibility", checkBranchCommentVisibility)
+}
+
+func checkBranchCommentVisibility(name string, options JiraBranchOptions) []error {
+	errors := []error{}
+	if options.PrivateCommentVisibility == nil {
+		return errors
+	}
+	if options.PrivateCommentVisibility.Type != "group" && options.PrivateCommentVisibility.Type != "role" {
+		errors = append(errors, fmt.Errorf("%s has unknown `private_comment_visibility` type `%s`, valid types are: group, role", name, options.PrivateCommentVisibility.Type))
+	}
+	if options.PrivateCommentVisibility.Value == "" {
+		errors = append(errors, fmt.Errorf("%s has an empty `private_comment_visibility` value", name))
+	}
+	return errors
+}
+
+func checkBranchCommentTemplates(name string, options JiraBranchOptions) []error {
+	errors := []error{}
+	for templateType, commentTemplate := range options.CommentTemplates {
+		if !commentTemplateTypes.Has(templateType) {
+			errors = append(errors, fmt.Errorf("%s has unknown comment template type `%s`, valid types are: %s", name, templateType, strings.Join(sets.List(commentTemplateTypes), ", ")))
+			continue
+		}
+		if _, err := template.New(templateType).Parse(commentTemplate); err != nil {
+			errors = append(errors, fmt.Errorf("%s has invalid `%s` comment template: %w", name, templateType, err))
+		}
+	}
+	if options.RemoteLinkTitleTemplate != nil {
+		if _, err := template.New("remote_link_title_template").Parse(*options.RemoteLinkTitleTemplate); err != nil {
+			errors = append(errors, fmt.Errorf("%s has invalid `remote_link_title_template`: %w", name, err))
+		}
+	}
+	return errors
+}
+
 func checkBranchStatuses(name string, options JiraBranchOptions) []error {
 	errors := []error{}
 	if options.StateAfterClose != nil && !validStatusSet.Has(options.StateAfterClose.Status) {
@@ -60,6 +214,19 @@ func checkBranchStatuses(name string, options JiraBranchOptions) []error {
 	if options.StateAfterValidation != nil && !validStatusSet.Has(options.StateAfterValidation.Status) {
 		errors = append(errors, fmt.Errorf("%s has invalid status for `state_after_validation`: `%s`", name, options.StateAfterValidation.Status))
 	}
+	// the pre-merge states may only set a resolution, leaving the status as it is
+	for _, preMerge := range []struct {
+		field string
+		state *JiraBugState
+	}{
+		{field: "premerge_state_after_validation", state: options.PreMergeStateAfterValidation},
+		{field: "premerge_state_after_merge", state: options.PreMergeStateAfterMerge},
+		{field: "premerge_state_after_close", state: options.PreMergeStateAfterClose},
+	} {
+		if preMerge.state != nil && preMerge.state.Status != "" && !validStatusSet.Has(preMerge.state.Status) {
+			errors = append(errors, fmt.Errorf("%s has invalid status for `%s`: `%s`", name, preMerge.field, preMerge.state.Status))
+		}
+	}
 	if options.ValidStates != nil {
 		for _, state := range *options.ValidStates {
 			if !validStatusSet.Has(state.Status) {
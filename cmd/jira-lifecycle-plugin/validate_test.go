@@ -82,6 +82,57 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		config   Config
+		expected Config
+		err      string
+	}{{
+		name: "statuses with mismatched casing are normalized",
+		config: Config{Default: map[string]JiraBranchOptions{"*": {
+			ValidStates:                  &[]JiraBugState{{Status: "new"}, {Status: " Release Pending "}},
+			StateAfterMerge:              &JiraBugState{Status: "on_qa"},
+			PreMergeStateAfterMerge:      &JiraBugState{Resolution: " ERRATA "},
+			StateAfterValidation:         &JiraBugState{Status: "Post"},
+			PreMergeStateAfterValidation: &JiraBugState{Status: "verified"},
+		}}},
+		expected: Config{Default: map[string]JiraBranchOptions{"*": {
+			ValidStates:                  &[]JiraBugState{{Status: status.New}, {Status: status.ReleasePending}},
+			StateAfterMerge:              &JiraBugState{Status: status.OnQA},
+			PreMergeStateAfterMerge:      &JiraBugState{Resolution: "ERRATA"},
+			StateAfterValidation:         &JiraBugState{Status: status.Post},
+			PreMergeStateAfterValidation: &JiraBugState{Status: status.Verified},
+		}}},
+	}, {
+		name: "malformed states are reported with the option and branch they are set on",
+		config: Config{Orgs: map[string]JiraOrgOptions{"org": {Repos: map[string]JiraRepoOptions{"repo": {Branches: map[string]JiraBranchOptions{"main": {
+			StateAfterMerge:         &JiraBugState{Status: "ON_DEV"},
+			PreMergeStateAfterClose: &JiraBugState{Status: "DONE"},
+		}}}}}}},
+		err: "invalid statuses in `org/repo`: [main has invalid status for `state_after_merge`: `ON_DEV`, main has invalid status for `premerge_state_after_close`: `DONE`]",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.config.Validate()
+			if tc.err == "" && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got: %v", tc.err, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.expected, tc.config); diff != "" {
+				t.Errorf("config differs from expected after normalization: %s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateStatuses(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -462,3 +513,261 @@ func TestCheckBranchStatuses(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckBranchMinimumSeverity(t *testing.T) {
+	t.Parallel()
+	known, unknown := "Moderate", "Urgent"
+	testCases := []struct {
+		name        string
+		fieldName   string
+		options     JiraBranchOptions
+		expectedErr []error
+	}{{
+		name:        "Empty config",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{},
+		expectedErr: []error{},
+	}, {
+		name:        "Known severity",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{ValidateByDefaultMinimumSeverity: &known},
+		expectedErr: []error{},
+	}, {
+		name:      "Unknown severity",
+		fieldName: "my-repo",
+		options:   JiraBranchOptions{ValidateByDefaultMinimumSeverity: &unknown},
+		expectedErr: []error{
+			errors.New("my-repo has unknown `validate_by_default_minimum_severity` `Urgent`, valid severities are: Critical, Important, Moderate, Low, Informational"),
+		},
+	}, {
+		name:        "Severity from configured order",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{ValidateByDefaultMinimumSeverity: &unknown, SeverityOrder: []string{"Urgent", "High", "Medium"}},
+		expectedErr: []error{},
+	}, {
+		name:      "Default severity missing from configured order",
+		fieldName: "my-repo",
+		options:   JiraBranchOptions{ValidateByDefaultMinimumSeverity: &known, SeverityOrder: []string{"Urgent", "High", "Medium"}},
+		expectedErr: []error{
+			errors.New("my-repo has unknown `validate_by_default_minimum_severity` `Moderate`, valid severities are: Urgent, High, Medium"),
+		},
+	}}
+	for _, tc := range testCases {
+		errs := checkBranchMinimumSeverity(tc.fieldName, tc.options)
+		if len(errs) != len(tc.expectedErr) {
+			t.Errorf("%s: Got different number of errors (%d) than expected (%d): %+v", tc.name, len(errs), len(tc.expectedErr), errs)
+		} else {
+			for index, err := range errs {
+				if err.Error() != tc.expectedErr[index].Error() {
+					t.Errorf("%s: Got different error at index %d than expected: %v", tc.name, index, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckBranchCommentVisibility(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		fieldName   string
+		options     JiraBranchOptions
+		expectedErr []error
+	}{{
+		name:        "Empty config",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{},
+		expectedErr: []error{},
+	}, {
+		name:        "Valid role",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{PrivateCommentVisibility: &JiraCommentVisibility{Type: "role", Value: "Developers"}},
+		expectedErr: []error{},
+	}, {
+		name:      "Unknown type and empty value",
+		fieldName: "my-repo",
+		options:   JiraBranchOptions{PrivateCommentVisibility: &JiraCommentVisibility{Type: "team"}},
+		expectedErr: []error{
+			errors.New("my-repo has unknown `private_comment_visibility` type `team`, valid types are: group, role"),
+			errors.New("my-repo has an empty `private_comment_visibility` value"),
+		},
+	}}
+	for _, tc := range testCases {
+		errs := checkBranchCommentVisibility(tc.fieldName, tc.options)
+		if len(errs) != len(tc.expectedErr) {
+			t.Errorf("%s: Got different number of errors (%d) than expected (%d): %+v", tc.name, len(errs), len(tc.expectedErr), errs)
+		} else {
+			for index, err := range errs {
+				if err.Error() != tc.expectedErr[index].Error() {
+					t.Errorf("%s: Got different error at index %d than expected: %v", tc.name, index, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckBranchCommentTemplates(t *testing.T) {
+	t.Parallel()
+	invalidLinkTitleTemplate := "{{.Title"
+	testCases := []struct {
+		name        string
+		fieldName   string
+		options     JiraBranchOptions
+		expectedErr []error
+	}{{
+		name:        "Empty config",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{},
+		expectedErr: []error{},
+	}, {
+		name:      "Correct config",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			CommentTemplates: map[string]string{
+				"valid":   "{{.Key}} is valid.",
+				"invalid": "{{.Key}} is invalid:{{range .Validations}}\n- {{.}}{{end}}",
+				"merged":  "{{.Key}} merged.",
+			},
+		},
+		expectedErr: []error{},
+	}, {
+		name:      "Unknown template type",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			CommentTemplates: map[string]string{"closed": "{{.Key}} was closed."},
+		},
+		expectedErr: []error{
+			errors.New("my-repo has unknown comment template type `closed`, valid types are: invalid, merged, valid"),
+		},
+	}, {
+		name:      "Template does not parse",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			CommentTemplates: map[string]string{"valid": "{{.Key"},
+		},
+		expectedErr: []error{
+			errors.New("my-repo has invalid `valid` comment template: template: valid:1: unclosed action"),
+		},
+	}, {
+		name:      "Remote link title template does not parse",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			RemoteLinkTitleTemplate: &invalidLinkTitleTemplate,
+		},
+		expectedErr: []error{
+			errors.New("my-repo has invalid `remote_link_title_template`: template: remote_link_title_template:1: unclosed action"),
+		},
+	}}
+	for _, tc := range testCases {
+		errs := checkBranchCommentTemplates(tc.fieldName, tc.options)
+		if len(errs) != len(tc.expectedErr) {
+			t.Errorf("%s: Got different number of errors (%d) than expected (%d): %+v", tc.name, len(errs), len(tc.expectedErr), errs)
+		} else {
+			for index, err := range errs {
+				if err.Error() != tc.expectedErr[index].Error() {
+					t.Errorf("%s: Got different error at index %d than expected: %v", tc.name, index, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckBranchSeverityLabels(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		fieldName   string
+		options     JiraBranchOptions
+		expectedErr []error
+	}{{
+		name:        "Empty config",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{},
+		expectedErr: []error{},
+	}, {
+		name:      "Correct config",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			SeverityLabels: map[string]string{"Critical": "severity/p0", "Informational": "severity/p4"},
+		},
+		expectedErr: []error{},
+	}, {
+		name:      "Unknown severity",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			SeverityLabels: map[string]string{"Urgent": "severity/p0"},
+		},
+		expectedErr: []error{
+			errors.New("my-repo has a label for unknown severity `Urgent`, valid severities are: Critical, Important, Moderate, Low, Informational"),
+		},
+	}, {
+		name:      "Empty label",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			SeverityLabels: map[string]string{"Low": ""},
+		},
+		expectedErr: []error{
+			errors.New("my-repo has an empty label for severity `Low`"),
+		},
+	}, {
+		name:      "Label for severity from configured order",
+		fieldName: "my-repo",
+		options: JiraBranchOptions{
+			SeverityLabels: map[string]string{"Urgent": "severity/p0"},
+			SeverityOrder:  []string{"Urgent", "High"},
+		},
+		expectedErr: []error{},
+	}}
+	for _, tc := range testCases {
+		errs := checkBranchSeverityLabels(tc.fieldName, tc.options)
+		if len(errs) != len(tc.expectedErr) {
+			t.Errorf("%s: Got different number of errors (%d) than expected (%d): %+v", tc.name, len(errs), len(tc.expectedErr), errs)
+		} else {
+			for index, err := range errs {
+				if err.Error() != tc.expectedErr[index].Error() {
+					t.Errorf("%s: Got different error at index %d than expected: %v", tc.name, index, err)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckBranchSeverityOrder(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		fieldName   string
+		options     JiraBranchOptions
+		expectedErr []error
+	}{{
+		name:        "Empty config",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{},
+		expectedErr: []error{},
+	}, {
+		name:        "Correct config",
+		fieldName:   "my-repo",
+		options:     JiraBranchOptions{SeverityOrder: []string{"Urgent", "High", "Medium", "Low"}},
+		expectedErr: []error{},
+	}, {
+		name:      "Empty and duplicate severities",
+		fieldName: "my-repo",
+		options:   JiraBranchOptions{SeverityOrder: []string{"Urgent", "", "High", "Urgent"}},
+		expectedErr: []error{
+			errors.New("my-repo has an empty severity in `severity_order`"),
+			errors.New("my-repo lists severity `Urgent` more than once in `severity_order`"),
+		},
+	}}
+	for _, tc := range testCases {
+		errs := checkBranchSeverityOrder(tc.fieldName, tc.options)
+		if len(errs) != len(tc.expectedErr) {
+			t.Errorf("%s: Got different number of errors (%d) than expected (%d): %+v", tc.name, len(errs), len(tc.expectedErr), errs)
+		} else {
+			for index, err := range errs {
+				if err.Error() != tc.expectedErr[index].Error() {
+					t.Errorf("%s: Got different error at index %d than expected: %v", tc.name, index, err)
+				}
+			}
+		}
+	}
+}
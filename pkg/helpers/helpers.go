@@ -20,6 +20,8 @@ const (
 	SprintField           = "customfield_12310940"
 	ReleaseNoteTypeField  = "customfield_12320850"
 	ContributorsField     = "customfield_12319640"
+	EpicLinkField         = "customfield_12311140"
+	StoryPointsField      = "customfield_12310243"
 )
 
 // GetUnknownField will attempt to get the specified field from the Unknowns struct and unmarshal
@@ -94,6 +96,55 @@ func GetIssueQaContact(issue *jira.Issue) (*jira.User, error) {
 	return obj, err
 }
 
+// GetIssueQaContacts returns all QA contacts of an issue. The QA contact field holds a single user
+// on most instances, but may also be configured to hold a list of users.
+func GetIssueQaContacts(issue *jira.Issue) ([]*jira.User, error) {
+	var obj *[]*jira.User
+	isSet, err := GetUnknownField(QAContactField, issue, func() any {
+		obj = &[]*jira.User{}
+		return obj
+	})
+	if !isSet {
+		return nil, err
+	}
+	if err == nil {
+		return *obj, nil
+	}
+	contact, err := GetIssueQaContact(issue)
+	if err != nil || contact == nil {
+		return nil, err
+	}
+	return []*jira.User{contact}, nil
+}
+
+// GetIssueEpicLink returns the key of the epic an issue is linked to. If no epic link is set,
+// the returned key and error will both be nil.
+func GetIssueEpicLink(issue *jira.Issue) (*string, error) {
+	var obj *string
+	isSet, err := GetUnknownField(EpicLinkField, issue, func() any {
+		var field string
+		obj = &field
+		return obj
+	})
+	if !isSet || obj == nil || *obj == "" {
+		return nil, err
+	}
+	return obj, err
+}
+
+// GetIssueStoryPoints returns the story points of an issue. If no story points are set,
+// the returned story points and error will both be nil.
+func GetIssueStoryPoints(issue *jira.Issue) (*float64, error) {
+	var obj *float64
+	isSet, err := GetUnknownField(StoryPointsField, issue, func() any {
+		return &obj
+	})
+	if !isSet {
+		return nil, err
+	}
+	return obj, err
+}
+
 func GetIssueTargetVersion(issue *jira.Issue) ([]*jira.Version, error) {
 	var obj *[]*jira.Version
 	isSet, err := GetUnknownField(TargetVersionField, issue, func() any {
@@ -159,31 +210,59 @@ func GetIssueReleaseNoteType(issue *jira.Issue) (*CustomField, error) {
 
 var activeSprintReg = regexp.MustCompile(",state=ACTIVE,")
 var sprintIDReg = regexp.MustCompile("id=([0-9]+)")
+var sprintNameReg = regexp.MustCompile(",name=([^,]*),")
 
-func GetActiveSprintID(sprintField any) (int, error) {
+// getActiveSprint returns the raw sprint string of the first active sprint in the sprint field, or an empty string
+// if there is no active sprint
+func getActiveSprint(sprintField any) (string, error) {
 	if sprintField == nil {
-		return -1, nil
+		return "", nil
 	}
 	sprintFieldSlice, ok := sprintField.([]any)
 	if !ok {
-		return -1, errors.New("failed to convert sprint field to slice of interfaces")
+		return "", errors.New("failed to convert sprint field to slice of interfaces")
 	}
 	for _, sprint := range sprintFieldSlice {
-		sprintString := sprint.(string)
+		sprintString, ok := sprint.(string)
+		if !ok {
+			return "", fmt.Errorf("failed to convert sprint %v to string", sprint)
+		}
 		if activeSprintReg.MatchString(sprintString) {
-			if submatch := sprintIDReg.FindStringSubmatch(sprintString); submatch != nil {
-				sprintID, err := strconv.Atoi(submatch[1])
-				if err != nil {
-					// should be impossible based on the regex
-					return -1, fmt.Errorf("failed to parse sprint ID. Err: %w", err)
-				}
-				return sprintID, nil
-			}
+			return sprintString, nil
 		}
 	}
+	return "", nil
+}
+
+func GetActiveSprintID(sprintField any) (int, error) {
+	sprintString, err := getActiveSprint(sprintField)
+	if err != nil || sprintString == "" {
+		return -1, err
+	}
+	if submatch := sprintIDReg.FindStringSubmatch(sprintString); submatch != nil {
+		sprintID, err := strconv.Atoi(submatch[1])
+		if err != nil {
+			// should be impossible based on the regex
+			return -1, fmt.Errorf("failed to parse sprint ID. Err: %w", err)
+		}
+		return sprintID, nil
+	}
 	return -1, nil
 }
 
+// GetActiveSprintName returns the name of the active sprint in the sprint field and whether
+// an active sprint was found at all
+func GetActiveSprintName(sprintField any) (string, bool, error) {
+	sprintString, err := getActiveSprint(sprintField)
+	if err != nil || sprintString == "" {
+		return "", false, err
+	}
+	if submatch := sprintNameReg.FindStringSubmatch(sprintString); submatch != nil {
+		return submatch[1], true, nil
+	}
+	return "", true, nil
+}
+
 type Contributor struct {
 	Self string `json:"self"`
 	Name string `json:"name"`
@@ -40,3 +40,111 @@ func TestGetActiveSprintIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetActiveSprintName(t *testing.T) {
+	t.Parallel()
+	active1 := "com.atlassian.greenhopper.service.sprint.Sprint@11b54434[id=57955,rapidViewId=14885,state=ACTIVE,name=uShift Sprint 248,startDate=2024-01-15T09:00:00.000Z,endDate=2024-02-05T09:00:00.000Z,completeDate=<null>,activatedDate=2024-01-15T08:17:37.677Z,sequence=57955,goal=,autoStartStop=false,synced=false]"
+	closed1 := "com.atlassian.greenhopper.service.sprint.Sprint@57a3e8ba[id=57484,rapidViewId=14885,state=CLOSED,name=uShift Sprint 247,startDate=2023-12-25T17:07:00.000Z,endDate=2024-01-15T17:07:00.000Z,completeDate=2024-01-15T08:15:40.614Z,activatedDate=2023-12-25T14:11:56.948Z,sequence=57484,goal=,autoStartStop=false,synced=false]"
+	var testCases = []struct {
+		name          string
+		issue         any
+		expectedName  string
+		expectedFound bool
+	}{{
+		name: "Empty",
+	}, {
+		name:          "One active, one closed",
+		issue:         []any{closed1, active1},
+		expectedName:  "uShift Sprint 248",
+		expectedFound: true,
+	}, {
+		name:  "Closed",
+		issue: []any{closed1},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, found, err := GetActiveSprintName(tc.issue)
+			if err != nil {
+				t.Errorf("Received error when none were expected: %v", err)
+			}
+			if name != tc.expectedName || found != tc.expectedFound {
+				t.Errorf("Expected (%q, %t), got (%q, %t)", tc.expectedName, tc.expectedFound, name, found)
+			}
+		})
+	}
+}
+
+func TestGetIssueStoryPoints(t *testing.T) {
+	t.Parallel()
+	zero, three := 0.0, 3.0
+	var testCases = []struct {
+		name     string
+		field    any
+		set      bool
+		expected *float64
+	}{{
+		name: "Unset",
+	}, {
+		name: "Null",
+		set:  true,
+	}, {
+		name:     "Zero",
+		field:    0,
+		set:      true,
+		expected: &zero,
+	}, {
+		name:     "Set",
+		field:    3,
+		set:      true,
+		expected: &three,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &jira.Issue{Fields: &jira.IssueFields{Unknowns: map[string]any{}}}
+			if tc.set {
+				issue.Fields.Unknowns[StoryPointsField] = tc.field
+			}
+			points, err := GetIssueStoryPoints(issue)
+			if err != nil {
+				t.Errorf("Received error when none were expected: %v", err)
+			}
+			if diff := cmp.Diff(points, tc.expected); diff != "" {
+				t.Errorf("Expected results do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetIssueQaContacts(t *testing.T) {
+	t.Parallel()
+	var testCases = []struct {
+		name     string
+		field    any
+		expected []*jira.User
+	}{{
+		name: "Unset",
+	}, {
+		name:     "Single contact",
+		field:    map[string]any{"emailAddress": "qa@example.com"},
+		expected: []*jira.User{{EmailAddress: "qa@example.com"}},
+	}, {
+		name:     "Multiple contacts",
+		field:    []any{map[string]any{"emailAddress": "qa@example.com"}, map[string]any{"emailAddress": "qa2@example.com"}},
+		expected: []*jira.User{{EmailAddress: "qa@example.com"}, {EmailAddress: "qa2@example.com"}},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issue := &jira.Issue{Fields: &jira.IssueFields{Unknowns: map[string]any{}}}
+			if tc.field != nil {
+				issue.Fields.Unknowns[QAContactField] = tc.field
+			}
+			contacts, err := GetIssueQaContacts(issue)
+			if err != nil {
+				t.Errorf("Received error when none were expected: %v", err)
+			}
+			if diff := cmp.Diff(contacts, tc.expected); diff != "" {
+				t.Errorf("Expected results do not match: %s", diff)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package labels
 
 const (
+	Approved              = "approved"
+	ClonePrefix           = "jira/clone-"
 	JiraValidRef          = "jira/valid-reference"
 	JiraValidBug          = "jira/valid-bug"
 	JiraInvalidBug        = "jira/invalid-bug"
+	LGTM                  = "lgtm"
+	NeedsInformation      = "jira/needs-information"
 	QEApproved            = "qe-approved"
 	SeverityCritical      = "jira/severity-critical"
 	SeverityImportant     = "jira/severity-important"